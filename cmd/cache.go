@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/redis"
+)
+
+// uncachedStores keep tasks in memory or in Redis already, so a cache in front of them gains nothing.
+var uncachedStores = map[string]bool{
+	"redis":  true,
+	"memory": true,
+}
+
+// cacheDecorators put the Redis task cache in front of the repositories of store.
+func cacheDecorators(store string) []interface{} {
+	if uncachedStores[store] {
+		return nil
+	}
+	return []interface{}{
+		newCachedTaskRepository,
+		newCachedTagRepository,
+		repository.WithCommitHooks,
+	}
+}
+
+// newCachedTaskRepository caches tasks when TASK_CACHE_ENABLED is set,
+// and serves them uncached when Redis is unreachable. The hit and miss counts of the cache
+// are logged every TASK_CACHE_STATS_INTERVAL until ctx is done.
+func newCachedTaskRepository(
+	ctx context.Context,
+	conf *config.TaskCacheConfig,
+	tr repository.TaskRepository,
+) repository.TaskRepository {
+	if !conf.Enabled {
+		return tr
+	}
+	client := redis.NewRedisClient(ctx)
+	if client == nil {
+		log.Warn("Redis is unavailable, tasks are not cached")
+		return tr
+	}
+	cached := redis.NewCachedTaskRepository(client, tr, conf)
+	if conf.StatsInterval > 0 {
+		go logCacheStats(ctx, cached, conf.StatsInterval)
+	}
+	return cached
+}
+
+// newCachedTagRepository invalidates the cached tasks of deleted tags, when tr is the task cache.
+func newCachedTagRepository(tgr repository.TagRepository, tr repository.TaskRepository) repository.TagRepository {
+	cached, ok := tr.(redis.CachedTaskRepository)
+	if !ok {
+		return tgr
+	}
+	return redis.NewCachedTagRepository(tgr, cached)
+}
+
+// logCacheStats logs the counts of cr every interval, skipping intervals without lookups.
+func logCacheStats(ctx context.Context, cr redis.CachedTaskRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last redis.CacheStats
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := cr.Stats()
+			if stats == last {
+				continue
+			}
+			last = stats
+			log.Info("Task cache stats", log.Fuint64("hits", stats.Hits), log.Fuint64("misses", stats.Misses))
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"go.uber.org/dig"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/mock"
+)
+
+func Test_cacheDecorators(t *testing.T) {
+	t.Parallel()
+
+	if decorators := cacheDecorators("redis"); len(decorators) != 0 {
+		t.Errorf("want: no cache in front of redis, got: %v decorators", len(decorators))
+	}
+
+	ctrl := gomock.NewController(t)
+	tr := mock.NewMockTaskRepository(ctrl)
+	tgr := mock.NewMockTagRepository(ctrl)
+	txr := mock.NewMockTransactionRepository(ctrl)
+
+	container := dig.New()
+	providers := []interface{}{
+		func() context.Context { return context.Background() },
+		func() *config.TaskCacheConfig { return &config.TaskCacheConfig{Enabled: false} },
+		func() repository.TaskRepository { return tr },
+		func() repository.TagRepository { return tgr },
+		func() repository.TransactionRepository { return txr },
+	}
+	for _, provider := range providers {
+		if err := container.Provide(provider); err != nil {
+			t.Fatalf("Provide() error = %v", err)
+		}
+	}
+	for _, decorator := range cacheDecorators("mysql") {
+		if err := container.Decorate(decorator); err != nil {
+			t.Fatalf("Decorate() error = %v", err)
+		}
+	}
+
+	err := container.Invoke(func(
+		got repository.TaskRepository,
+		gotTag repository.TagRepository,
+		gotTx repository.TransactionRepository,
+	) {
+		if got != tr {
+			t.Errorf("want: the store's TaskRepository while the cache is disabled, got: %T", got)
+		}
+		if gotTag != tgr {
+			t.Errorf("want: the store's TagRepository while the cache is disabled, got: %T", gotTag)
+		}
+		if gotTx == txr {
+			t.Errorf("want: TransactionRepository wrapped with commit hooks")
+		}
+	})
+	if err != nil {
+		t.Errorf("Invoke() error = %v", err)
+	}
+}
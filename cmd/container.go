@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"go.uber.org/dig"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository/auth"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+// transports maps the values of --transport to the container builder of each web framework.
+// The builders of the web frameworks provide an http.Handler serving the API, the gRPC one a *grpc.Server.
+var transports = map[string]func(ctx context.Context, store string) (*dig.Container, error){
+	"chi":  HTTPBuildContainer,
+	"echo": EchoBuildContainer,
+	"gin":  GinBuildContainer,
+	"grpc": GRPCBuildContainer,
+}
+
+func BuildContainer(ctx context.Context, transport, store string) (*dig.Container, error) {
+	build, ok := transports[transport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported transport %q, must be one of %s", transport, strings.Join(sortedKeys(transports), ", "))
+	}
+	return build(ctx, store)
+}
+
+// sharedProviders are the dependencies every transport and store combination has in common.
+func sharedProviders() []interface{} {
+	return []interface{}{
+		config.NewServerConfig,
+		config.NewAuthConfig,
+		config.NewTaskCacheConfig,
+		config.NewKeySet,
+		newRevocationRepository,
+		auth.NewAuthRepository,
+		auth.NewPasswordRepository,
+		entity.NewClock,
+		usecase.NewTaskEventBroker,
+		usecase.NewTaskUseCase,
+		usecase.NewTagUseCase,
+		usecase.NewUserUseCase,
+		usecase.NewJWKSUseCase,
+	}
+}
+
+func buildContainer(ctx context.Context, store string, transportProviders []interface{}) (*dig.Container, error) {
+	repositoryProviders, err := storeProviders(store)
+	if err != nil {
+		log.Critical("Failed to select store", log.Ferror(err))
+		return nil, err
+	}
+
+	container := dig.New()
+
+	if err = container.Provide(func() context.Context {
+		return ctx
+	}); err != nil {
+		log.Error("Failed to provide context")
+		return nil, err
+	}
+
+	providers := sharedProviders()
+	providers = append(providers, repositoryProviders...)
+	providers = append(providers, transportProviders...)
+
+	for _, provider := range providers {
+		if err = container.Provide(provider); err != nil {
+			log.Critical("Failed to provide dependency", log.Fstring("provider", fmt.Sprintf("%T", provider)))
+			return nil, err
+		}
+	}
+
+	for _, decorator := range cacheDecorators(store) {
+		if err = container.Decorate(decorator); err != nil {
+			log.Critical("Failed to decorate dependency", log.Fstring("decorator", fmt.Sprintf("%T", decorator)))
+			return nil, err
+		}
+	}
+
+	log.Info("Container built successfully", log.Fstring("store", store))
+	return container, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,49 +2,35 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
-	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 	"go.uber.org/dig"
 
 	"github.com/tusmasoma/go-clean-arch/config"
 	handler "github.com/tusmasoma/go-clean-arch/interfaces/handler/echo"
 	middleware "github.com/tusmasoma/go-clean-arch/interfaces/middleware/echo"
-	"github.com/tusmasoma/go-clean-arch/repository/auth"
-	"github.com/tusmasoma/go-clean-arch/repository/mysql"
-	"github.com/tusmasoma/go-clean-arch/usecase"
 )
 
-func EchoBuildContainer(ctx context.Context) (*dig.Container, error) {
-	container := dig.New()
-
-	if err := container.Provide(func() context.Context {
-		return ctx
-	}); err != nil {
-		log.Error("Failed to provide context")
-		return nil, err
-	}
+// EchoBuildContainer assembles the echo router on top of the repositories of store.
+func EchoBuildContainer(ctx context.Context, store string) (*dig.Container, error) {
+	return buildContainer(ctx, store, echoProviders())
+}
 
-	providers := []interface{}{
-		config.NewServerConfig,
-		config.NewDBConfig,
-		// This is database-agnostic and can be swapped with another database like PostgreSQL
-		mysql.NewMySQLDB,
-		mysql.NewTransactionRepository,
-		mysql.NewTaskRepository,
-		mysql.NewUserRepository,
-		auth.NewAuthRepository,
-		usecase.NewTaskUseCase,
-		usecase.NewUserUseCase,
+func echoProviders() []interface{} {
+	return []interface{}{
 		handler.NewTaskHandler,
+		handler.NewTagHandler,
 		handler.NewUserHandler,
+		handler.NewJWKSHandler,
 		middleware.NewAuthMiddleware,
 		func(
 			serverConfig *config.ServerConfig,
 			taskHandler handler.TaskHandler,
+			tagHandler handler.TagHandler,
 			userHandler handler.UserHandler,
+			jwksHandler handler.JWKSHandler,
 			authMiddleware middleware.AuthMiddleware,
 		) *echo.Echo {
 			e := echo.New()
@@ -60,13 +46,16 @@ func EchoBuildContainer(ctx context.Context) (*dig.Container, error) {
 
 			e.Use(middleware.Logging)
 
+			e.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 			api := e.Group("/api")
 			{
 				user := api.Group("/user")
 				{
 					user.POST("/create", userHandler.CreateUser)
+					user.POST("/login", userHandler.Login)
 
-					authorized := user.Group("/")
+					authorized := user.Group("")
 					authorized.Use(authMiddleware.Authenticate)
 					{
 						authorized.GET("/get", userHandler.GetUser)
@@ -74,6 +63,14 @@ func EchoBuildContainer(ctx context.Context) (*dig.Container, error) {
 					}
 				}
 			}
+			{
+				authGroup := api.Group("/auth")
+				{
+					authGroup.POST("/refresh", userHandler.RefreshToken)
+					authGroup.POST("/logout", userHandler.Logout, authMiddleware.Authenticate)
+					authGroup.POST("/logout_all", userHandler.LogoutEverywhere, authMiddleware.Authenticate)
+				}
+			}
 			{
 				task := api.Group("/task")
 				{
@@ -82,21 +79,26 @@ func EchoBuildContainer(ctx context.Context) (*dig.Container, error) {
 					task.GET("/list", taskHandler.ListTasks)
 					task.POST("/create", taskHandler.CreateTask)
 					task.PUT("/update", taskHandler.UpdateTask)
+					task.PUT("/update_status", taskHandler.UpdateTaskStatus)
 					task.DELETE("/delete", taskHandler.DeleteTask)
+					task.GET("/watch", taskHandler.WatchTasks)
+				}
+			}
+			{
+				tag := api.Group("/tag")
+				{
+					tag.Use(authMiddleware.Authenticate)
+					tag.GET("/list", tagHandler.ListTags)
+					tag.POST("/create", tagHandler.CreateTag)
+					tag.PUT("/update", tagHandler.UpdateTag)
+					tag.DELETE("/delete", tagHandler.DeleteTag)
 				}
 			}
 
 			return e
 		},
+		func(e *echo.Echo) http.Handler {
+			return e
+		},
 	}
-
-	for _, provider := range providers {
-		if err := container.Provide(provider); err != nil {
-			log.Critical("Failed to provide dependency", log.Fstring("provider", fmt.Sprintf("%T", provider)))
-			return nil, err
-		}
-	}
-
-	log.Info("Container built successfully")
-	return container, nil
 }
@@ -2,52 +2,36 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 	"go.uber.org/dig"
 
 	"github.com/tusmasoma/go-clean-arch/config"
 	handler "github.com/tusmasoma/go-clean-arch/interfaces/handler/gin"
 	middleware "github.com/tusmasoma/go-clean-arch/interfaces/middleware/gin"
-	"github.com/tusmasoma/go-clean-arch/repository/auth"
-	"github.com/tusmasoma/go-clean-arch/repository/mysql"
-	"github.com/tusmasoma/go-clean-arch/usecase"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
-func GinBuildContainer(ctx context.Context) (*dig.Container, error) {
-	container := dig.New()
-
-	if err := container.Provide(func() context.Context {
-		return ctx
-	}); err != nil {
-		log.Error("Failed to provide context")
-		return nil, err
-	}
+// GinBuildContainer assembles the gin router on top of the repositories of store.
+func GinBuildContainer(ctx context.Context, store string) (*dig.Container, error) {
+	return buildContainer(ctx, store, ginProviders())
+}
 
-	providers := []interface{}{
-		config.NewServerConfig,
-		config.NewDBConfig,
-		// This is database-agnostic and can be swapped with another database like PostgreSQL
-		mysql.NewMySQLDB,
-		mysql.NewTransactionRepository,
-		mysql.NewTaskRepository,
-		mysql.NewUserRepository,
-		auth.NewAuthRepository,
-		usecase.NewTaskUseCase,
-		usecase.NewUserUseCase,
+func ginProviders() []interface{} {
+	return []interface{}{
 		handler.NewTaskHandler,
+		handler.NewTagHandler,
 		handler.NewUserHandler,
+		handler.NewJWKSHandler,
 		middleware.NewAuthMiddleware,
 		func(
 			serverConfig *config.ServerConfig,
 			taskHandler handler.TaskHandler,
+			tagHandler handler.TagHandler,
 			userHandler handler.UserHandler,
+			jwksHandler handler.JWKSHandler,
 			authMiddleware middleware.AuthMiddleware,
 		) *gin.Engine {
 			r := gin.Default()
@@ -63,11 +47,14 @@ func GinBuildContainer(ctx context.Context) (*dig.Container, error) {
 
 			r.Use(middleware.Logging())
 
+			r.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 			api := r.Group("/api")
 			{
 				user := api.Group("/user")
 				{
 					user.POST("/create", userHandler.CreateUser)
+					user.POST("/login", userHandler.Login)
 
 					authorized := user.Group("/")
 					authorized.Use(authMiddleware.Authenticate())
@@ -77,6 +64,14 @@ func GinBuildContainer(ctx context.Context) (*dig.Container, error) {
 					}
 				}
 			}
+			{
+				authGroup := api.Group("/auth")
+				{
+					authGroup.POST("/refresh", userHandler.RefreshToken)
+					authGroup.POST("/logout", authMiddleware.Authenticate(), userHandler.Logout)
+					authGroup.POST("/logout_all", authMiddleware.Authenticate(), userHandler.LogoutEverywhere)
+				}
+			}
 			{
 				task := api.Group("/task")
 				{
@@ -85,21 +80,26 @@ func GinBuildContainer(ctx context.Context) (*dig.Container, error) {
 					task.GET("/list", taskHandler.ListTasks)
 					task.POST("/create", taskHandler.CreateTask)
 					task.PUT("/update", taskHandler.UpdateTask)
+					task.PUT("/update_status", taskHandler.UpdateTaskStatus)
 					task.DELETE("/delete", taskHandler.DeleteTask)
+					task.GET("/watch", taskHandler.WatchTasks)
+				}
+			}
+			{
+				tag := api.Group("/tag")
+				{
+					tag.Use(authMiddleware.Authenticate())
+					tag.GET("/list", tagHandler.ListTags)
+					tag.POST("/create", tagHandler.CreateTag)
+					tag.PUT("/update", tagHandler.UpdateTag)
+					tag.DELETE("/delete", tagHandler.DeleteTag)
 				}
 			}
 
 			return r
 		},
+		func(r *gin.Engine) http.Handler {
+			return r
+		},
 	}
-
-	for _, provider := range providers {
-		if err := container.Provide(provider); err != nil {
-			log.Critical("Failed to provide dependency", log.Fstring("provider", fmt.Sprintf("%T", provider)))
-			return nil, err
-		}
-	}
-
-	log.Info("Container built successfully")
-	return container, nil
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+	"google.golang.org/grpc"
+
+	handler "github.com/tusmasoma/go-clean-arch/interfaces/handler/grpc"
+	pb "github.com/tusmasoma/go-clean-arch/interfaces/handler/grpc/proto/gateway"
+	middleware "github.com/tusmasoma/go-clean-arch/interfaces/middleware/grpc"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+// GRPCBuildContainer assembles the gRPC server on top of the repositories of store.
+// The REST gateway in front of it is started by serveGRPC, as it dials the address the server listens on.
+func GRPCBuildContainer(ctx context.Context, store string) (*dig.Container, error) {
+	return buildContainer(ctx, store, grpcProviders())
+}
+
+// grpcPublicMethods are the RPCs that can be called without an access token.
+var grpcPublicMethods = []string{
+	pb.UserService_CreateUser_FullMethodName,
+	pb.UserService_Login_FullMethodName,
+}
+
+func grpcProviders() []interface{} {
+	return []interface{}{
+		func(tuc usecase.TaskUseCase) pb.TaskServiceServer {
+			return handler.NewTaskHandler(tuc)
+		},
+		func(uuc usecase.UserUseCase) pb.UserServiceServer {
+			return handler.NewUserHandler(uuc)
+		},
+		func(ar repository.AuthRepository, vr repository.RevocationRepository) middleware.AuthInterceptor {
+			return middleware.NewAuthInterceptor(ar, vr, grpcPublicMethods...)
+		},
+		func(
+			taskServer pb.TaskServiceServer,
+			userServer pb.UserServiceServer,
+			authInterceptor middleware.AuthInterceptor,
+		) *grpc.Server {
+			s := grpc.NewServer(
+				grpc.ChainUnaryInterceptor(
+					middleware.LoggingUnaryInterceptor,
+					authInterceptor.Unary,
+				),
+				grpc.ChainStreamInterceptor(
+					middleware.LoggingStreamInterceptor,
+					authInterceptor.Stream,
+				),
+			)
+			pb.RegisterTaskServiceServer(s, taskServer)
+			pb.RegisterUserServiceServer(s, userServer)
+			return s
+		},
+	}
+}
@@ -2,51 +2,35 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"net/http"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/cors"
-	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 	"go.uber.org/dig"
 
 	"github.com/tusmasoma/go-clean-arch/config"
 	handler "github.com/tusmasoma/go-clean-arch/interfaces/handler/http"
 	middleware "github.com/tusmasoma/go-clean-arch/interfaces/middleware/http"
-	"github.com/tusmasoma/go-clean-arch/repository/auth"
-	"github.com/tusmasoma/go-clean-arch/repository/mysql"
-	"github.com/tusmasoma/go-clean-arch/usecase"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
-func HTTPBuildContainer(ctx context.Context) (*dig.Container, error) {
-	container := dig.New()
-
-	if err := container.Provide(func() context.Context {
-		return ctx
-	}); err != nil {
-		log.Error("Failed to provide context")
-		return nil, err
-	}
+// HTTPBuildContainer assembles the chi router on top of the repositories of store.
+func HTTPBuildContainer(ctx context.Context, store string) (*dig.Container, error) {
+	return buildContainer(ctx, store, httpProviders())
+}
 
-	providers := []interface{}{
-		config.NewServerConfig,
-		config.NewDBConfig,
-		// This is database-agnostic and can be swapped with another database like PostgreSQL
-		mysql.NewMySQLDB,
-		mysql.NewTransactionRepository,
-		mysql.NewTaskRepository,
-		mysql.NewUserRepository,
-		auth.NewAuthRepository,
-		usecase.NewTaskUseCase,
-		usecase.NewUserUseCase,
+func httpProviders() []interface{} {
+	return []interface{}{
 		handler.NewTaskHandler,
+		handler.NewTagHandler,
 		handler.NewUserHandler,
+		handler.NewJWKSHandler,
 		middleware.NewAuthMiddleware,
 		func(
 			serverConfig *config.ServerConfig,
 			taskHandler handler.TaskHandler,
+			tagHandler handler.TagHandler,
 			userHandler handler.UserHandler,
+			jwksHandler handler.JWKSHandler,
 			authMiddleware middleware.AuthMiddleware,
 		) *chi.Mux {
 			r := chi.NewRouter()
@@ -61,9 +45,12 @@ func HTTPBuildContainer(ctx context.Context) (*dig.Container, error) {
 			}))
 			r.Use(middleware.Logging)
 
+			r.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 			r.Route("/api", func(r chi.Router) {
 				r.Route("/user", func(r chi.Router) {
 					r.Post("/create", userHandler.CreateUser)
+					r.Post("/login", userHandler.Login)
 					r.Group(func(r chi.Router) {
 						r.Use(authMiddleware.Authenticate)
 						r.Get("/get", userHandler.GetUser)
@@ -71,27 +58,39 @@ func HTTPBuildContainer(ctx context.Context) (*dig.Container, error) {
 					})
 				})
 
+				r.Route("/auth", func(r chi.Router) {
+					r.Post("/refresh", userHandler.RefreshToken)
+					r.Group(func(r chi.Router) {
+						r.Use(authMiddleware.Authenticate)
+						r.Post("/logout", userHandler.Logout)
+						r.Post("/logout_all", userHandler.LogoutEverywhere)
+					})
+				})
+
 				r.Route("/task", func(r chi.Router) {
 					r.Use(authMiddleware.Authenticate)
 					r.Get("/get", taskHandler.GetTask)
 					r.Get("/list", taskHandler.ListTasks)
 					r.Post("/create", taskHandler.CreateTask)
 					r.Put("/update", taskHandler.UpdateTask)
+					r.Put("/update_status", taskHandler.UpdateTaskStatus)
 					r.Delete("/delete", taskHandler.DeleteTask)
+					r.Get("/watch", taskHandler.WatchTasks)
+				})
+
+				r.Route("/tag", func(r chi.Router) {
+					r.Use(authMiddleware.Authenticate)
+					r.Get("/list", tagHandler.ListTags)
+					r.Post("/create", tagHandler.CreateTag)
+					r.Put("/update", tagHandler.UpdateTag)
+					r.Delete("/delete", tagHandler.DeleteTag)
 				})
 			})
 
 			return r
 		},
+		func(r *chi.Mux) http.Handler {
+			return r
+		},
 	}
-
-	for _, provider := range providers {
-		if err := container.Provide(provider); err != nil {
-			log.Critical("Failed to provide dependency", log.Fstring("provider", fmt.Sprintf("%T", provider)))
-			return nil, err
-		}
-	}
-
-	log.Info("Container built successfully")
-	return container, nil
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func Test_storeProviders(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name    string
+		store   string
+		wantErr string
+	}{
+		{
+			name:  "Success: mysql",
+			store: "mysql",
+		},
+		{
+			name:  "Success: postgres",
+			store: "postgres",
+		},
+		{
+			name:  "Success: gorm",
+			store: "gorm",
+		},
+		{
+			name:  "Success: mongodb",
+			store: "mongodb",
+		},
+		{
+			name:  "Success: sqlite",
+			store: "sqlite",
+		},
+		{
+			name:  "Success: memory",
+			store: "memory",
+		},
+		{
+			name:    "Fail: unknown store",
+			store:   "cassandra",
+			wantErr: `unsupported store "cassandra"`,
+		},
+		{
+			name:    "Fail: store without TransactionRepository",
+			store:   "redis",
+			wantErr: `store "redis" does not implement repository.TransactionRepository`,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := storeProviders(tt.store)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("storeProviders() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("storeProviders() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_BuildContainer_unsupportedTransport(t *testing.T) {
+	t.Parallel()
+
+	_, err := BuildContainer(context.Background(), "fasthttp", "mysql")
+	if err == nil || !strings.Contains(err.Error(), `unsupported transport "fasthttp"`) {
+		t.Errorf("BuildContainer() error = %v, want unsupported transport", err)
+	}
+}
+
+func Test_BuildContainer(t *testing.T) {
+	t.Parallel()
+
+	for transport := range transports {
+		if _, err := BuildContainer(context.Background(), transport, "mysql"); err != nil {
+			t.Errorf("BuildContainer(%q) error = %v", transport, err)
+		}
+	}
+}
@@ -0,0 +1,512 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
+)
+
+// volatileFields matches the JSON values that differ between runs, like generated IDs and timestamps.
+var volatileFields = regexp.MustCompile(`"(id|created_at|completed_at|refresh_token)":"[^"]*"`)
+
+type contractResponse struct {
+	status      int
+	contentType string
+	header      http.Header
+	body        string
+}
+
+// Test_httpContract sends the same requests to the chi, echo and gin routers and checks that every
+// transport answers them in exactly the same way.
+func Test_httpContract(t *testing.T) {
+	t.Setenv("AUTH_BCRYPT_COST", "4")
+
+	dueDate := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	problemBody := func(p *problem.Problem) string {
+		return string(core.ProblemResponse(p).Body)
+	}
+
+	patterns := []struct {
+		name   string
+		method string
+		path   string
+		// path and body may refer to the ID of the listed task as {id}, and of the listed tag as {tag_id}.
+		body string
+		auth bool
+		// header lists the headers to compare besides Content-Type.
+		header []string
+		// volatile is set when the body differs between runs, so that it is not compared at all.
+		volatile    bool
+		status      int
+		contentType string
+		// want is the expected body after the volatile fields are masked, if it is given.
+		want string
+	}{
+		{
+			name:   "JWKS",
+			method: http.MethodGet,
+			path:   "/.well-known/jwks.json",
+			header: []string{"Cache-Control"},
+			// the keys are generated for each container
+			volatile:    true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+		},
+		{
+			name:        "Fail: create user with a malformed body",
+			method:      http.MethodPost,
+			path:        "/api/user/create",
+			body:        `{"email":`,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+			want:        problemBody(problem.MalformedBody("/api/user/create")),
+		},
+		{
+			name:        "Fail: create user without credentials",
+			method:      http.MethodPost,
+			path:        "/api/user/create",
+			body:        `{}`,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+			want: problemBody(problem.Validation("/api/user/create", []problem.FieldError{
+				{Field: "email", Reason: "is required"},
+				{Field: "password", Reason: "is required"},
+			})),
+		},
+		{
+			name:        "Success: create user",
+			method:      http.MethodPost,
+			path:        "/api/user/create",
+			body:        `{"email":"contract@example.com","password":"password123"}`,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want:        "{\"refresh_token\":\"*\"}\n",
+		},
+		{
+			name:        "Fail: create user with a taken email",
+			method:      http.MethodPost,
+			path:        "/api/user/create",
+			body:        `{"email":"contract@example.com","password":"password123"}`,
+			status:      http.StatusConflict,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Fail: get user without a token",
+			method:      http.MethodGet,
+			path:        "/api/user/get",
+			status:      http.StatusUnauthorized,
+			contentType: problem.ContentType,
+			want: problemBody(problem.New(
+				http.StatusUnauthorized, "Authentication failed: missing Authorization header", "/api/user/get",
+			)),
+		},
+		{
+			name:        "Success: get user",
+			method:      http.MethodGet,
+			path:        "/api/user/get",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+		},
+		{
+			name:        "Fail: update user without a name",
+			method:      http.MethodPut,
+			path:        "/api/user/update",
+			body:        `{"due_soon_hours":-1}`,
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:   "Success: update user",
+			method: http.MethodPut,
+			path:   "/api/user/update",
+			body:   `{"name":"contract","due_soon_hours":12}`,
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:        "Success: get updated user",
+			method:      http.MethodGet,
+			path:        "/api/user/get",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want:        "{\"id\":\"*\",\"name\":\"contract\",\"email\":\"contract@example.com\",\"due_soon_hours\":12}\n",
+		},
+		{
+			name:        "Fail: list tasks without a token",
+			method:      http.MethodGet,
+			path:        "/api/task/list",
+			status:      http.StatusUnauthorized,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Fail: create task with a malformed body",
+			method:      http.MethodPost,
+			path:        "/api/task/create",
+			body:        `[]`,
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+			want:        problemBody(problem.MalformedBody("/api/task/create")),
+		},
+		{
+			name:        "Fail: create task with invalid fields",
+			method:      http.MethodPost,
+			path:        "/api/task/create",
+			body:        `{"title":"Task","priority":9}`,
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Success: create tag",
+			method:      http.MethodPost,
+			path:        "/api/tag/create",
+			body:        `{"name":"backend"}`,
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want:        "{\"id\":\"*\",\"name\":\"backend\",\"created_at\":\"*\"}\n",
+		},
+		{
+			name:        "Fail: create tag with a taken name",
+			method:      http.MethodPost,
+			path:        "/api/tag/create",
+			body:        `{"name":"backend"}`,
+			auth:        true,
+			status:      http.StatusConflict,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Success: list tags",
+			method:      http.MethodGet,
+			path:        "/api/tag/list",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want:        "{\"tags\":[{\"id\":\"*\",\"name\":\"backend\",\"created_at\":\"*\"}]}\n",
+		},
+		{
+			name:        "Fail: create task with an unknown tag",
+			method:      http.MethodPost,
+			path:        "/api/task/create",
+			body:        `{"title":"Task","description":"Description","due_date":"` + dueDate + `","priority":3,"tags":["frontend"]}`,
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:   "Success: create task",
+			method: http.MethodPost,
+			path:   "/api/task/create",
+			body:   `{"title":"Task","description":"Description","due_date":"` + dueDate + `","priority":3,"tags":["backend"]}`,
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:        "Fail: list tasks with invalid parameters",
+			method:      http.MethodGet,
+			path:        "/api/task/list?limit=x&order=up&sort_by=owner",
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Success: list tasks",
+			method:      http.MethodGet,
+			path:        "/api/task/list?sort_by=priority&order=desc",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+		},
+		{
+			name:        "Fail: list tasks with an invalid tag match",
+			method:      http.MethodGet,
+			path:        "/api/task/list?tags=backend&tag_match=some",
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Success: list tasks with all of the tags",
+			method:      http.MethodGet,
+			path:        "/api/task/list?tags=backend&tag_match=all",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+		},
+		{
+			name:        "Success: list tasks with an unknown tag",
+			method:      http.MethodGet,
+			path:        "/api/task/list?tags=frontend",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want:        "{\"tasks\":null}\n",
+		},
+		{
+			name:        "Fail: get task without an ID",
+			method:      http.MethodGet,
+			path:        "/api/task/get",
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+			want: problemBody(problem.Validation("/api/task/get", []problem.FieldError{
+				{Field: "id", Reason: "is required"},
+			})),
+		},
+		{
+			name:        "Fail: get a missing task",
+			method:      http.MethodGet,
+			path:        "/api/task/get?id=missing",
+			auth:        true,
+			status:      http.StatusNotFound,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Success: get task",
+			method:      http.MethodGet,
+			path:        "/api/task/get?id={id}",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+		},
+		{
+			name:        "Fail: update task status to an unknown status",
+			method:      http.MethodPut,
+			path:        "/api/task/update_status",
+			body:        `{"id":"{id}","status":"finished"}`,
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:   "Success: update task status",
+			method: http.MethodPut,
+			path:   "/api/task/update_status",
+			body:   `{"id":"{id}","status":"done"}`,
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:   "Success: update task",
+			method: http.MethodPut,
+			path:   "/api/task/update",
+			body:   `{"id":"{id}","title":"Updated","description":"Description","due_date":"` + dueDate + `","priority":1}`,
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:        "Success: get updated task",
+			method:      http.MethodGet,
+			path:        "/api/task/get?id={id}",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			// the update did not mention the tags, so they are kept
+			want: "{\"id\":\"*\",\"title\":\"Updated\",\"description\":\"Description\",\"due_date\":\"" + dueDate +
+				"\",\"priority\":1,\"status\":\"done\",\"completed_at\":\"*\",\"created_at\":\"*\"," +
+				"\"is_overdue\":false,\"is_due_soon\":false,\"tags\":[\"backend\"]}\n",
+		},
+		{
+			name:   "Success: update task removing its tags",
+			method: http.MethodPut,
+			path:   "/api/task/update",
+			body:   `{"id":"{id}","title":"Updated","description":"Description","due_date":"` + dueDate + `","priority":1,"tags":[]}`,
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:        "Success: get untagged task",
+			method:      http.MethodGet,
+			path:        "/api/task/get?id={id}",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want: "{\"id\":\"*\",\"title\":\"Updated\",\"description\":\"Description\",\"due_date\":\"" + dueDate +
+				"\",\"priority\":1,\"status\":\"done\",\"completed_at\":\"*\",\"created_at\":\"*\"," +
+				"\"is_overdue\":false,\"is_due_soon\":false,\"tags\":[]}\n",
+		},
+		{
+			name:   "Success: delete task",
+			method: http.MethodDelete,
+			path:   "/api/task/delete?id={id}",
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:        "Fail: get a deleted task",
+			method:      http.MethodGet,
+			path:        "/api/task/get?id={id}",
+			auth:        true,
+			status:      http.StatusNotFound,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Fail: delete tag without an ID",
+			method:      http.MethodDelete,
+			path:        "/api/tag/delete",
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Fail: delete a missing tag",
+			method:      http.MethodDelete,
+			path:        "/api/tag/delete?id=missing",
+			auth:        true,
+			status:      http.StatusNotFound,
+			contentType: problem.ContentType,
+		},
+		{
+			name:        "Fail: update tag without a name",
+			method:      http.MethodPut,
+			path:        "/api/tag/update",
+			body:        `{"id":"{tag_id}"}`,
+			auth:        true,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+			want: problemBody(problem.Validation("/api/tag/update", []problem.FieldError{
+				{Field: "name", Reason: "is required"},
+			})),
+		},
+		{
+			name:   "Success: update tag",
+			method: http.MethodPut,
+			path:   "/api/tag/update",
+			body:   `{"id":"{tag_id}","name":"server"}`,
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:        "Success: list updated tags",
+			method:      http.MethodGet,
+			path:        "/api/tag/list",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want:        "{\"tags\":[{\"id\":\"*\",\"name\":\"server\",\"created_at\":\"*\"}]}\n",
+		},
+		{
+			name:   "Success: delete tag",
+			method: http.MethodDelete,
+			path:   "/api/tag/delete?id={tag_id}",
+			auth:   true,
+			status: http.StatusOK,
+		},
+		{
+			name:        "Success: list tags after delete",
+			method:      http.MethodGet,
+			path:        "/api/tag/list",
+			auth:        true,
+			status:      http.StatusOK,
+			contentType: core.JSONContentType,
+			want:        "{\"tags\":[]}\n",
+		},
+		{
+			name:        "Fail: refresh without a token",
+			method:      http.MethodPost,
+			path:        "/api/auth/refresh",
+			body:        `{}`,
+			status:      http.StatusBadRequest,
+			contentType: problem.ContentType,
+		},
+		{
+			name:   "Success: logout without a body",
+			method: http.MethodPost,
+			path:   "/api/auth/logout",
+			auth:   true,
+			status: http.StatusOK,
+		},
+	}
+
+	transports := []string{"chi", "echo", "gin"}
+	responses := make(map[string][]contractResponse, len(transports))
+	for _, transport := range transports {
+		container, err := BuildContainer(context.Background(), transport, "memory")
+		if err != nil {
+			t.Fatalf("BuildContainer(%q) error = %v", transport, err)
+		}
+		if err = container.Invoke(func(h http.Handler) {
+			var token, taskID, tagID string
+			for _, tt := range patterns {
+				ids := strings.NewReplacer("{id}", taskID, "{tag_id}", tagID)
+				req := httptest.NewRequest(tt.method, ids.Replace(tt.path), strings.NewReader(ids.Replace(tt.body)))
+				req.Header.Set("Content-Type", "application/json")
+				if tt.auth {
+					req.Header.Set("Authorization", token)
+				}
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				if auth := rec.Header().Get("Authorization"); auth != "" {
+					token = auth
+				}
+				if strings.HasPrefix(tt.path, "/api/task/list") && rec.Code == http.StatusOK {
+					var list core.ListTasksResponse
+					if err := json.Unmarshal(rec.Body.Bytes(), &list); err == nil && len(list.Tasks) != 0 {
+						taskID = list.Tasks[0].ID
+					}
+				}
+				if tt.path == "/api/tag/list" && rec.Code == http.StatusOK {
+					var list core.ListTagsResponse
+					if err := json.Unmarshal(rec.Body.Bytes(), &list); err == nil && len(list.Tags) != 0 {
+						tagID = list.Tags[0].ID
+					}
+				}
+				responses[transport] = append(responses[transport], contractResponse{
+					status:      rec.Code,
+					contentType: rec.Header().Get("Content-Type"),
+					header:      rec.Header(),
+					body:        volatileFields.ReplaceAllString(rec.Body.String(), `"$1":"*"`),
+				})
+			}
+		}); err != nil {
+			t.Fatalf("Invoke(%q) error = %v", transport, err)
+		}
+	}
+
+	for i, tt := range patterns {
+		i, tt := i, tt
+		t.Run(tt.name, func(t *testing.T) {
+			want := responses[transports[0]][i]
+			if want.status != tt.status {
+				t.Errorf("%s: status = %v, want %v: %s", transports[0], want.status, tt.status, want.body)
+			}
+			if want.contentType != tt.contentType {
+				t.Errorf("%s: Content-Type = %q, want %q", transports[0], want.contentType, tt.contentType)
+			}
+			if tt.want != "" && want.body != tt.want {
+				t.Errorf("%s: body = %q, want %q", transports[0], want.body, tt.want)
+			}
+
+			for _, transport := range transports[1:] {
+				got := responses[transport][i]
+				if got.status != want.status {
+					t.Errorf("%s: status = %v, want %v as %s: %s", transport, got.status, want.status, transports[0], got.body)
+				}
+				if got.contentType != want.contentType {
+					t.Errorf("%s: Content-Type = %q, want %q as %s", transport, got.contentType, want.contentType, transports[0])
+				}
+				for _, key := range tt.header {
+					if got.header.Get(key) != want.header.Get(key) {
+						t.Errorf("%s: %s = %q, want %q as %s", transport, key, got.header.Get(key), want.header.Get(key), transports[0])
+					}
+				}
+				if !tt.volatile && got.body != want.body {
+					t.Errorf("%s: body = %q, want %q as %s", transport, got.body, want.body, transports[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	handler "github.com/tusmasoma/go-clean-arch/interfaces/handler/http"
+)
+
+// Test_embeddedStores runs the whole stack of every HTTP transport on the stores that need no external service.
+func Test_embeddedStores(t *testing.T) {
+	t.Setenv("AUTH_BCRYPT_COST", "4")
+
+	for _, store := range []string{"memory", "sqlite"} {
+		for _, transport := range []string{"chi", "echo", "gin"} {
+			store, transport := store, transport
+			t.Run(store+"/"+transport, func(t *testing.T) {
+				t.Setenv("SQLITE_PATH", filepath.Join(t.TempDir(), "e2e.db"))
+
+				container, err := BuildContainer(context.Background(), transport, store)
+				if err != nil {
+					t.Fatalf("BuildContainer() error = %v", err)
+				}
+				if err = container.Invoke(func(h http.Handler) {
+					testTaskFlow(t, h)
+				}); err != nil {
+					t.Fatalf("Invoke() error = %v", err)
+				}
+			})
+		}
+	}
+}
+
+func testTaskFlow(t *testing.T, h http.Handler) {
+	t.Helper()
+
+	do := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		t.Helper()
+		var buf bytes.Buffer
+		if body != nil {
+			if err := json.NewEncoder(&buf).Encode(body); err != nil {
+				t.Fatalf("Failed to encode request body: %v", err)
+			}
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	credentials := handler.CreateUserRequest{Email: "e2e@example.com", Password: "password123"}
+	rec := do(http.MethodPost, "/api/user/create", "", credentials)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("create user: want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	token := rec.Header().Get("Authorization")
+
+	rec = do(http.MethodPost, "/api/user/create", "", credentials)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("create duplicate user: want status %v, got %v", http.StatusConflict, rec.Code)
+	}
+
+	rec = do(http.MethodPost, "/api/task/create", token, handler.CreateTaskRequest{
+		Title:       "Task",
+		Description: "Description",
+		DueDate:     time.Now().Add(24 * time.Hour),
+		Priority:    3,
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("create task: want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+
+	rec = do(http.MethodGet, "/api/task/list", token, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list tasks: want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	var list handler.ListTasksResponse
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+	if len(list.Tasks) != 1 || list.Tasks[0].Title != "Task" {
+		t.Errorf("list tasks: want the created task, got: %+v", list.Tasks)
+	}
+
+	// logging straight back in, usually within the second of the logout, issues a token that is accepted
+	rec = do(http.MethodPost, "/api/auth/logout_all", token, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("logout everywhere: want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	rec = do(http.MethodPost, "/api/user/login", "", handler.LoginRequest{Email: credentials.Email, Password: credentials.Password})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login: want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	rec = do(http.MethodGet, "/api/task/list", rec.Header().Get("Authorization"), nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("list tasks after login: want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+}
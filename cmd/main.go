@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/go-chi/chi"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/joho/godotenv"
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"go.uber.org/dig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/tusmasoma/go-clean-arch/config"
+	pb "github.com/tusmasoma/go-clean-arch/interfaces/handler/grpc/proto/gateway"
 )
 
 func main() {
@@ -21,36 +27,57 @@ func main() {
 		log.Info("No .env file found", log.Ferror(err))
 	}
 
-	var addr string
+	var addr, grpcAddr, transport, store string
 	flag.StringVar(&addr, "addr", ":8083", "tcp host:port to connect")
+	flag.StringVar(&grpcAddr, "grpc-addr", ":50051", "tcp host:port of the gRPC server, when the transport is grpc")
+	flag.StringVar(&transport, "transport", getEnv("SERVER_TRANSPORT", "chi"),
+		"framework serving the API: chi, echo, gin or grpc (with a REST gateway on addr)")
+	flag.StringVar(&store, "store", getEnv("SERVER_STORE", "mysql"),
+		"storage backend: mysql, postgres, gorm, mongodb, redis, sqlite or memory")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [migrate up|down|status|to <version>]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(context.Background(), store, flag.Args()[1:], os.Stdout); err != nil {
+			log.Critical("Failed to migrate", log.Ferror(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	mainCtx, cancelMain := context.WithCancel(context.Background())
 	defer cancelMain()
 
-	// This is framework-agnostic and can be replaced with any HTTP framework like net/http, Gin, or Echo
-	// The following example uses net/http.
-	container, err := HTTPBuildContainer(mainCtx)
+	container, err := BuildContainer(mainCtx, transport, store)
 	if err != nil {
 		log.Critical("Failed to build container", log.Ferror(err))
 		return
 	}
 
-	err = container.Invoke(func(router *chi.Mux, config *config.ServerConfig) {
-		srv := &http.Server{
-			Addr:         addr,
-			Handler:      router,
-			ReadTimeout:  config.ReadTimeout,
-			WriteTimeout: config.WriteTimeout,
-			IdleTimeout:  config.IdleTimeout,
-		}
-		log.Info("Server running...")
+	log.Info("Server running...", log.Fstring("transport", transport), log.Fstring("store", store))
+	if transport == "grpc" {
+		err = serveGRPC(mainCtx, container, grpcAddr, addr)
+	} else {
+		err = serveHTTP(container, addr)
+	}
+	if err != nil {
+		log.Critical("Failed to start server", log.Ferror(err))
+		return
+	}
+}
+
+func serveHTTP(container *dig.Container, addr string) error {
+	return container.Invoke(func(handler http.Handler, config *config.ServerConfig) {
+		srv := newHTTPServer(addr, handler, config)
 
 		signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt, os.Kill)
 		defer stop()
 
 		go func() {
-			if err = srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				log.Error("Server failed", log.Ferror(err))
 				return
 			}
@@ -62,13 +89,93 @@ func main() {
 		tctx, cancelShutdown := context.WithTimeout(context.Background(), config.GracefulShutdownTimeout)
 		defer cancelShutdown()
 
-		if err = srv.Shutdown(tctx); err != nil {
+		if err := srv.Shutdown(tctx); err != nil {
 			log.Error("Failed to shutdown http server", log.Ferror(err))
 		}
 		log.Info("Server exited")
 	})
-	if err != nil {
-		log.Critical("Failed to start server", log.Ferror(err))
-		return
+}
+
+// serveGRPC serves the gRPC server on grpcAddr and the REST gateway, which forwards to it, on addr.
+// Both are stopped within ServerConfig.GracefulShutdownTimeout, or when either of them fails.
+func serveGRPC(ctx context.Context, container *dig.Container, grpcAddr, addr string) error {
+	return container.Invoke(func(grpcServer *grpc.Server, config *config.ServerConfig) error {
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Error("Failed to listen", log.Fstring("addr", grpcAddr), log.Ferror(err))
+			return err
+		}
+
+		gateway := runtime.NewServeMux()
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		for _, register := range []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+			pb.RegisterTaskServiceHandlerFromEndpoint,
+			pb.RegisterUserServiceHandlerFromEndpoint,
+		} {
+			if err = register(ctx, gateway, lis.Addr().String(), opts); err != nil {
+				log.Error("Failed to register gateway", log.Ferror(err))
+				lis.Close()
+				return err
+			}
+		}
+		srv := newHTTPServer(addr, gateway, config)
+
+		signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt, os.Kill)
+		defer stop()
+
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Error("gRPC server failed", log.Ferror(err))
+				stop()
+			}
+		}()
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("Gateway failed", log.Ferror(err))
+				stop()
+			}
+		}()
+
+		<-signalCtx.Done()
+		log.Info("Server stopping...")
+
+		tctx, cancelShutdown := context.WithTimeout(context.Background(), config.GracefulShutdownTimeout)
+		defer cancelShutdown()
+
+		if err = srv.Shutdown(tctx); err != nil {
+			log.Error("Failed to shutdown gateway", log.Ferror(err))
+		}
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-tctx.Done():
+			log.Warn("Graceful shutdown timed out, closing open gRPC connections")
+			grpcServer.Stop()
+		}
+		log.Info("Server exited")
+		return nil
+	})
+}
+
+func newHTTPServer(addr string, handler http.Handler, config *config.ServerConfig) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}
+}
+
+// getEnv lets the environment set the default of a flag.
+func getEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
 	}
+	return fallback
 }
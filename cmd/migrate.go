@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/repository/migrate"
+	"github.com/tusmasoma/go-clean-arch/repository/mysql"
+	"github.com/tusmasoma/go-clean-arch/repository/postgres"
+	"github.com/tusmasoma/go-clean-arch/repository/sqlite"
+)
+
+const migrateUsage = "usage: migrate up|down|status|to <version>"
+
+type sqlStore struct {
+	connect     func(ctx context.Context) (*sql.DB, error)
+	newMigrator func(db *sql.DB) (*migrate.Migrator, error)
+}
+
+// sqlStores are the stores whose schema is managed by migrations. gorm shares the schema of mysql.
+var sqlStores = map[string]sqlStore{
+	"mysql":    {mysql.Connect, mysql.NewMigrator},
+	"gorm":     {mysql.Connect, mysql.NewMigrator},
+	"postgres": {postgres.Connect, postgres.NewMigrator},
+	"sqlite":   {sqlite.Connect, sqlite.NewMigrator},
+}
+
+// runMigrate serves the migrate subcommand against the database of store and reports to w.
+func runMigrate(ctx context.Context, store string, args []string, w io.Writer) error {
+	s, ok := sqlStores[store]
+	if !ok {
+		return fmt.Errorf("store %q has no migrations, must be one of %s", store, strings.Join(sortedKeys(sqlStores), ", "))
+	}
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := s.newMigrator(db)
+	if err != nil {
+		return err
+	}
+	return runMigrator(ctx, m, args, w)
+}
+
+func runMigrator(ctx context.Context, m *migrate.Migrator, args []string, w io.Writer) error {
+	var count int
+	var err error
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		count, err = m.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		count, err = m.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return fmt.Errorf("invalid version %q: %s", args[1], migrateUsage)
+		}
+		count, err = m.To(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		return writeMigrationStatus(ctx, m, w)
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%d migration(s) run\n", count)
+	return nil
+}
+
+func writeMigrationStatus(ctx context.Context, m *migrate.Migrator, w io.Writer) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS")
+	for _, s := range statuses {
+		state := "pending"
+		switch {
+		case s.Unknown:
+			state = "applied " + s.AppliedAt.Format(time.RFC3339) + ", unknown to this build"
+		case s.Modified:
+			state = "applied " + s.AppliedAt.Format(time.RFC3339) + ", modified since"
+		case s.AppliedAt != nil:
+			state = "applied " + s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", s.Version, s.Name, state)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_runMigrate(t *testing.T) {
+	t.Setenv("SQLITE_PATH", filepath.Join(t.TempDir(), "migrate.db"))
+	ctx := context.Background()
+
+	patterns := []struct {
+		name    string
+		store   string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Fail: store without migrations",
+			store:   "memory",
+			args:    []string{"up"},
+			wantErr: true,
+		},
+		{
+			name:    "Fail: missing command",
+			store:   "sqlite",
+			wantErr: true,
+		},
+		{
+			name:    "Fail: invalid version",
+			store:   "sqlite",
+			args:    []string{"to", "latest"},
+			wantErr: true,
+		},
+		{
+			name:  "success: status of a new database",
+			store: "sqlite",
+			args:  []string{"status"},
+			want:  []string{"create_tasks             pending"},
+		},
+		{
+			name:  "success: up",
+			store: "sqlite",
+			args:  []string{"up"},
+			want:  []string{"7 migration(s) run"},
+		},
+		{
+			name:  "success: down",
+			store: "sqlite",
+			args:  []string{"down"},
+			want:  []string{"1 migration(s) run"},
+		},
+		{
+			name:  "success: to",
+			store: "sqlite",
+			args:  []string{"to", "1"},
+			want:  []string{"5 migration(s) run"},
+		},
+		{
+			name:  "success: status",
+			store: "sqlite",
+			args:  []string{"status"},
+			want:  []string{"create_tasks             applied", "create_users             pending"},
+		},
+	}
+
+	// the patterns share one database and run in order
+	for _, tt := range patterns {
+		var out bytes.Buffer
+		err := runMigrate(ctx, tt.store, tt.args, &out)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: runMigrate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("%s: want output containing %q, got: %q", tt.name, want, out.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/memory"
+	"github.com/tusmasoma/go-clean-arch/repository/redis"
+)
+
+// newRevocationRepository shares revoked tokens through Redis so every instance sees them,
+// and falls back to a process-local store when Redis is unreachable.
+func newRevocationRepository(
+	ctx context.Context,
+	conf *config.AuthConfig,
+	clock entity.Clock,
+) repository.RevocationRepository {
+	if client := redis.NewRedisClient(ctx); client != nil {
+		return redis.NewRevocationRepository(client, conf, clock)
+	}
+	log.Warn("Redis is unavailable, revoked tokens are kept in memory")
+	return memory.NewRevocationRepository(conf, clock)
+}
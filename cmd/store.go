@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/gorm"
+	"github.com/tusmasoma/go-clean-arch/repository/memory"
+	"github.com/tusmasoma/go-clean-arch/repository/mongodb"
+	"github.com/tusmasoma/go-clean-arch/repository/mysql"
+	"github.com/tusmasoma/go-clean-arch/repository/postgres"
+	"github.com/tusmasoma/go-clean-arch/repository/redis"
+	"github.com/tusmasoma/go-clean-arch/repository/sqlite"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+// stores maps the values of --store to the providers of the repositories each backend implements.
+var stores = map[string][]interface{}{
+	"mysql": {
+		mysql.NewMySQLDB,
+		mysql.NewTransactionRepository,
+		mysql.NewTaskRepository,
+		mysql.NewUserRepository,
+		mysql.NewTagRepository,
+		mysql.NewRefreshTokenRepository,
+	},
+	"postgres": {
+		postgres.NewPostgresDB,
+		postgres.NewTransactionRepository,
+		postgres.NewTaskRepository,
+		postgres.NewUserRepository,
+		postgres.NewRefreshTokenRepository,
+		postgres.NewTagRepository,
+	},
+	"gorm": {
+		gorm.NewMySQLDB,
+		gorm.NewTransactionRepository,
+		gorm.NewTaskRepository,
+		gorm.NewUserRepository,
+		gorm.NewRefreshTokenRepository,
+		gorm.NewTagRepository,
+	},
+	"mongodb": {
+		mongodb.NewMongoDB,
+		mongodb.NewTransactionRepository,
+		mongodb.NewTaskRepository,
+		mongodb.NewUserRepository,
+		mongodb.NewRefreshTokenRepository,
+		mongodb.NewTagRepository,
+	},
+	"redis": {
+		newRedisClient,
+		redis.NewTaskRepository,
+		redis.NewRefreshTokenRepository,
+	},
+	"sqlite": {
+		sqlite.NewSQLiteDB,
+		sqlite.NewTransactionRepository,
+		sqlite.NewTaskRepository,
+		sqlite.NewUserRepository,
+		sqlite.NewTagRepository,
+		sqlite.NewRefreshTokenRepository,
+	},
+	"memory": {
+		memory.NewStore,
+		memory.NewTransactionRepository,
+		memory.NewTaskRepository,
+		memory.NewUserRepository,
+		memory.NewTagRepository,
+		memory.NewRefreshTokenRepository,
+	},
+}
+
+// requiredRepositories are the repositories the use cases depend on, which a store has to implement all of.
+var requiredRepositories = []reflect.Type{
+	reflect.TypeOf((*repository.TransactionRepository)(nil)).Elem(),
+	reflect.TypeOf((*repository.TaskRepository)(nil)).Elem(),
+	reflect.TypeOf((*repository.UserRepository)(nil)).Elem(),
+	reflect.TypeOf((*repository.RefreshTokenRepository)(nil)).Elem(),
+	reflect.TypeOf((*repository.TagRepository)(nil)).Elem(),
+}
+
+// storeProviders returns the providers of store, and fails before anything is connected
+// when the store is unknown or lacks one of the required repositories.
+func storeProviders(store string) ([]interface{}, error) {
+	providers, ok := stores[store]
+	if !ok {
+		return nil, fmt.Errorf("unsupported store %q, must be one of %s", store, strings.Join(sortedKeys(stores), ", "))
+	}
+
+	provided := make(map[reflect.Type]bool)
+	for _, provider := range providers {
+		t := reflect.TypeOf(provider)
+		for i := 0; i < t.NumOut(); i++ {
+			provided[t.Out(i)] = true
+		}
+	}
+
+	var missing []string
+	for _, required := range requiredRepositories {
+		if !provided[required] {
+			missing = append(missing, required.String())
+		}
+	}
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("store %q does not implement %s", store, strings.Join(missing, ", "))
+	}
+	return providers, nil
+}
+
+// newRedisClient fails the container instead of handing a nil client to the repositories,
+// and moves tasks written in the layout of earlier versions before they are served.
+func newRedisClient(ctx context.Context) (*goredis.Client, error) {
+	client := redis.NewRedisClient(ctx)
+	if client == nil {
+		return nil, errors.New("failed to connect to Redis")
+	}
+	if _, err := redis.MigrateTaskKeys(ctx, client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -11,11 +11,17 @@ import (
 
 type ContextKey string
 
-const ContextUserIDKey ContextKey = "userID"
+const (
+	ContextUserIDKey ContextKey = "userID"
+	// ContextTokenClaimsKey holds the *repository.TokenClaims of the access token of the request.
+	ContextTokenClaimsKey ContextKey = "tokenClaims"
+)
 
 const (
-	mongoDBPrefix = "MONGO_DB_"
-	serverPrefix  = "SERVER_"
+	mongoDBPrefix   = "MONGO_DB_"
+	serverPrefix    = "SERVER_"
+	authPrefix      = "AUTH_"
+	taskCachePrefix = "TASK_CACHE_"
 )
 
 type DBConfig struct {
@@ -24,6 +30,8 @@ type DBConfig struct {
 	User     string `env:"USER, required"`
 	Password string `env:"PASSWORD, required"`
 	DBName   string `env:"DB_NAME, required"`
+	// AutoMigrate applies the pending schema migrations when the database is opened.
+	AutoMigrate bool `env:"AUTO_MIGRATE,default=false"`
 }
 
 type MongoDBConfig struct {
@@ -34,12 +42,29 @@ type MongoDBConfig struct {
 	Collection string `env:"COLLECTION, required"`
 }
 
+type SQLiteConfig struct {
+	// Path is the database file, which is created when it does not exist.
+	Path string `env:"PATH,default=go-clean-arch.db"`
+}
+
 type CacheConfig struct {
 	Addr     string `env:"ADDR, required"`
 	Password string `env:"PASSWORD, required"`
 	DB       int    `env:"DB, required"`
 }
 
+// TaskCacheConfig controls the Redis cache in front of the task store.
+type TaskCacheConfig struct {
+	Enabled bool `env:"ENABLED,default=false"`
+	// TTL is how long a single task stays cached.
+	TTL time.Duration `env:"TTL,default=5m"`
+	// ListTTL is how long a page of tasks stays cached. Pages are shorter lived than single tasks,
+	// since they also go stale when a task is created.
+	ListTTL time.Duration `env:"LIST_TTL,default=30s"`
+	// StatsInterval is how often the hit and miss counts of the cache are logged, 0 disables it.
+	StatsInterval time.Duration `env:"STATS_INTERVAL,default=1m"`
+}
+
 type ServerConfig struct {
 	ReadTimeout               time.Duration `env:"READ_TIMEOUT,default=5s"`
 	WriteTimeout              time.Duration `env:"WRITE_TIMEOUT,default=10s"`
@@ -48,6 +73,29 @@ type ServerConfig struct {
 	PreflightCacheDurationSec int           `env:"PREFLIGHT_CACHE_DURATION_SEC,default=300"`
 }
 
+type AuthConfig struct {
+	// BcryptCost is the work factor of password hashes.
+	// Stored hashes with another cost are rehashed on the next successful login.
+	BcryptCost int `env:"BCRYPT_COST,default=12"`
+	// AccessTokenTTL is how long an access token is accepted after it is issued.
+	AccessTokenTTL time.Duration `env:"ACCESS_TOKEN_TTL,default=15m"`
+	// RefreshTokenTTL is how long a refresh token can be exchanged. Every rotation starts a new period.
+	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL,default=720h"`
+	Issuer          string        `env:"ISSUER,default=go-clean-arch"`
+	Audience        string        `env:"AUDIENCE,default=go-clean-arch"`
+	// Leeway absorbs clock drift between the issuer and the verifiers when checking exp and nbf.
+	Leeway time.Duration `env:"LEEWAY,default=30s"`
+	// SigningKey is the PEM encoded RSA private key that signs new access tokens.
+	// SigningKeyFile is read instead when SigningKey is empty.
+	SigningKey     string `env:"SIGNING_KEY"`
+	SigningKeyFile string `env:"SIGNING_KEY_FILE"`
+	// VerificationKeys are PEM encoded RSA public keys of retired signing keys that are still accepted,
+	// so that tokens issued before a rotation stay valid until they expire.
+	// VerificationKeysFile is read instead when VerificationKeys is empty.
+	VerificationKeys     string `env:"VERIFICATION_KEYS"`
+	VerificationKeysFile string `env:"VERIFICATION_KEYS_FILE"`
+}
+
 func NewDBConfig(ctx context.Context, dbPrefix string) (*DBConfig, error) {
 	conf := &DBConfig{}
 	pl := envconfig.PrefixLookuper(dbPrefix, envconfig.OsLookuper())
@@ -69,6 +117,16 @@ func NewMongoDBConfig(ctx context.Context) (*MongoDBConfig, error) {
 	return conf, nil
 }
 
+func NewSQLiteConfig(ctx context.Context, dbPrefix string) (*SQLiteConfig, error) {
+	conf := &SQLiteConfig{}
+	pl := envconfig.PrefixLookuper(dbPrefix, envconfig.OsLookuper())
+	if err := envconfig.ProcessWith(ctx, conf, pl); err != nil {
+		log.Error("Failed to load sqlite config", log.Ferror(err))
+		return nil, err
+	}
+	return conf, nil
+}
+
 func NewCacheConfig(ctx context.Context, cachePrefix string) (*CacheConfig, error) {
 	conf := &CacheConfig{}
 	pl := envconfig.PrefixLookuper(cachePrefix, envconfig.OsLookuper())
@@ -79,6 +137,16 @@ func NewCacheConfig(ctx context.Context, cachePrefix string) (*CacheConfig, erro
 	return conf, nil
 }
 
+func NewTaskCacheConfig(ctx context.Context) (*TaskCacheConfig, error) {
+	conf := &TaskCacheConfig{}
+	pl := envconfig.PrefixLookuper(taskCachePrefix, envconfig.OsLookuper())
+	if err := envconfig.ProcessWith(ctx, conf, pl); err != nil {
+		log.Error("Failed to load task cache config", log.Ferror(err))
+		return nil, err
+	}
+	return conf, nil
+}
+
 func NewServerConfig(ctx context.Context) (*ServerConfig, error) {
 	conf := &ServerConfig{}
 	pl := envconfig.PrefixLookuper(serverPrefix, envconfig.OsLookuper())
@@ -88,3 +156,13 @@ func NewServerConfig(ctx context.Context) (*ServerConfig, error) {
 	}
 	return conf, nil
 }
+
+func NewAuthConfig(ctx context.Context) (*AuthConfig, error) {
+	conf := &AuthConfig{}
+	pl := envconfig.PrefixLookuper(authPrefix, envconfig.OsLookuper())
+	if err := envconfig.ProcessWith(ctx, conf, pl); err != nil {
+		log.Error("Failed to load auth config", log.Ferror(err))
+		return nil, err
+	}
+	return conf, nil
+}
@@ -35,13 +35,15 @@ func Test_NewDBConfig(t *testing.T) {
 				t.Setenv("MYSQL_HOST", "mysql")
 				t.Setenv("MYSQL_PORT", "3306")
 				t.Setenv("MYSQL_DB_NAME", "campfinderdb")
+				t.Setenv("MYSQL_AUTO_MIGRATE", "true")
 			},
 			want: &DBConfig{
-				Host:     "mysql",
-				Port:     "3306",
-				User:     "root",
-				Password: "campfinder",
-				DBName:   "campfinderdb",
+				Host:        "mysql",
+				Port:        "3306",
+				User:        "root",
+				Password:    "campfinder",
+				DBName:      "campfinderdb",
+				AutoMigrate: true,
 			},
 		},
 	}
@@ -111,6 +113,43 @@ func Test_NewMongoDB(t *testing.T) {
 	}
 }
 
+func Test_NewSQLiteConfig(t *testing.T) {
+	ctx := context.Background()
+
+	patterns := []struct {
+		name  string
+		setup func(t *testing.T)
+		want  *SQLiteConfig
+	}{
+		{
+			name: "default",
+			setup: func(t *testing.T) {
+				t.Helper()
+			},
+			want: &SQLiteConfig{Path: "go-clean-arch.db"},
+		},
+		{
+			name: "set env",
+			setup: func(t *testing.T) {
+				t.Helper()
+				t.Setenv("SQLITE_PATH", "/var/lib/app/tasks.db")
+			},
+			want: &SQLiteConfig{Path: "/var/lib/app/tasks.db"},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(t)
+
+			got, err := NewSQLiteConfig(ctx, "SQLITE_")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_NewCacheConfig(t *testing.T) {
 	ctx := context.Background()
 
@@ -212,3 +251,114 @@ func Test_NewServerConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewTaskCacheConfig(t *testing.T) {
+	ctx := context.Background()
+
+	patterns := []struct {
+		name  string
+		setup func(t *testing.T)
+		want  *TaskCacheConfig
+	}{
+		{
+			name: "default",
+			setup: func(t *testing.T) {
+				t.Helper()
+			},
+			want: &TaskCacheConfig{
+				Enabled: false,
+				TTL:           5 * time.Minute,
+				ListTTL:       30 * time.Second,
+				StatsInterval: time.Minute,
+			},
+		},
+		{
+			name: "set env",
+			setup: func(t *testing.T) {
+				t.Helper()
+				t.Setenv("TASK_CACHE_ENABLED", "true")
+				t.Setenv("TASK_CACHE_TTL", "1m")
+				t.Setenv("TASK_CACHE_LIST_TTL", "10s")
+				t.Setenv("TASK_CACHE_STATS_INTERVAL", "0")
+			},
+			want: &TaskCacheConfig{
+				Enabled:       true,
+				TTL:           time.Minute,
+				ListTTL:       10 * time.Second,
+				StatsInterval: 0,
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(t)
+
+			got, err := NewTaskCacheConfig(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_NewAuthConfig(t *testing.T) {
+	ctx := context.Background()
+
+	patterns := []struct {
+		name  string
+		setup func(t *testing.T)
+		want  *AuthConfig
+	}{
+		{
+			name: "default",
+			setup: func(t *testing.T) {
+				t.Helper()
+			},
+			want: &AuthConfig{
+				BcryptCost:      12,
+				AccessTokenTTL:  15 * time.Minute,
+				RefreshTokenTTL: 720 * time.Hour,
+				Issuer:          "go-clean-arch",
+				Audience:        "go-clean-arch",
+				Leeway:          30 * time.Second,
+			},
+		},
+		{
+			name: "set env",
+			setup: func(t *testing.T) {
+				t.Helper()
+				t.Setenv("AUTH_BCRYPT_COST", "10")
+				t.Setenv("AUTH_ACCESS_TOKEN_TTL", "5m")
+				t.Setenv("AUTH_REFRESH_TOKEN_TTL", "24h")
+				t.Setenv("AUTH_ISSUER", "issuer")
+				t.Setenv("AUTH_AUDIENCE", "audience")
+				t.Setenv("AUTH_LEEWAY", "0s")
+				t.Setenv("AUTH_SIGNING_KEY_FILE", "/run/secrets/signing_key.pem")
+				t.Setenv("AUTH_VERIFICATION_KEYS_FILE", "/run/secrets/verification_keys.pem")
+			},
+			want: &AuthConfig{
+				BcryptCost:      10,
+				AccessTokenTTL:  5 * time.Minute,
+				RefreshTokenTTL: 24 * time.Hour,
+				Issuer:          "issuer",
+				Audience:        "audience",
+				Leeway:          0,
+
+				SigningKeyFile:       "/run/secrets/signing_key.pem",
+				VerificationKeysFile: "/run/secrets/verification_keys.pem",
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(t)
+
+			got, err := NewAuthConfig(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+)
+
+const ephemeralKeyBits = 2048
+
+// SigningKey is the private key that signs new access tokens.
+type SigningKey struct {
+	ID         string
+	PrivateKey *rsa.PrivateKey
+}
+
+// VerificationKey is a public key that access tokens are accepted from.
+type VerificationKey struct {
+	ID        string
+	PublicKey *rsa.PublicKey
+}
+
+// KeySet holds the parsed token keys. Verification always contains the public half of Signing.
+// Key IDs are the RFC 7638 thumbprints of the keys, so a key keeps its ID when it is retired.
+type KeySet struct {
+	Signing      SigningKey
+	Verification []VerificationKey
+}
+
+func NewKeySet(conf *AuthConfig) (*KeySet, error) {
+	signingPEM, err := readKeyMaterial(conf.SigningKey, conf.SigningKeyFile)
+	if err != nil {
+		log.Error("Failed to read signing key", log.Ferror(err))
+		return nil, err
+	}
+
+	var privKey *rsa.PrivateKey
+	if len(signingPEM) == 0 {
+		log.Warn("No signing key configured, using an ephemeral key. Issued tokens do not survive a restart")
+		if privKey, err = rsa.GenerateKey(rand.Reader, ephemeralKeyBits); err != nil {
+			log.Error("Failed to generate ephemeral signing key", log.Ferror(err))
+			return nil, err
+		}
+	} else if privKey, err = parsePrivateKey(signingPEM); err != nil {
+		log.Error("Failed to parse signing key", log.Ferror(err))
+		return nil, err
+	}
+
+	signingID := Thumbprint(&privKey.PublicKey)
+	keys := &KeySet{
+		Signing:      SigningKey{ID: signingID, PrivateKey: privKey},
+		Verification: []VerificationKey{{ID: signingID, PublicKey: &privKey.PublicKey}},
+	}
+
+	verificationPEM, err := readKeyMaterial(conf.VerificationKeys, conf.VerificationKeysFile)
+	if err != nil {
+		log.Error("Failed to read verification keys", log.Ferror(err))
+		return nil, err
+	}
+	pubKeys, err := parsePublicKeys(verificationPEM)
+	if err != nil {
+		log.Error("Failed to parse verification keys", log.Ferror(err))
+		return nil, err
+	}
+	for _, pubKey := range pubKeys {
+		id := Thumbprint(pubKey)
+		if keys.hasVerificationKey(id) {
+			continue
+		}
+		keys.Verification = append(keys.Verification, VerificationKey{ID: id, PublicKey: pubKey})
+	}
+
+	return keys, nil
+}
+
+// VerificationKey returns the public key with the given ID.
+func (ks *KeySet) VerificationKey(id string) (*rsa.PublicKey, bool) {
+	for _, key := range ks.Verification {
+		if key.ID == id {
+			return key.PublicKey, true
+		}
+	}
+	return nil, false
+}
+
+func (ks *KeySet) hasVerificationKey(id string) bool {
+	_, ok := ks.VerificationKey(id)
+	return ok
+}
+
+// Thumbprint returns the RFC 7638 JWK thumbprint of the key, base64url encoded.
+func Thumbprint(pubKey *rsa.PublicKey) string {
+	// The members are required to be in lexicographic order, which encoding/json keeps for struct fields declared in order.
+	members, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pubKey.E)).Bytes()),
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(pubKey.N.Bytes()),
+	})
+	sum := sha256.Sum256(members)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func readKeyMaterial(value, path string) ([]byte, error) {
+	if value != "" {
+		return []byte(value), nil
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(path)
+}
+
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block containing the private key")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		privKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("not RSA private key")
+		}
+		return privKey, nil
+	default:
+		return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+	}
+}
+
+// parsePublicKeys parses every PEM block of a bundle of public keys.
+func parsePublicKeys(data []byte) ([]*rsa.PublicKey, error) {
+	var pubKeys []*rsa.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "RSA PUBLIC KEY":
+			pubKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			pubKeys = append(pubKeys, pubKey)
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			pubKey, ok := key.(*rsa.PublicKey)
+			if !ok {
+				return nil, errors.New("not RSA public key")
+			}
+			pubKeys = append(pubKeys, pubKey)
+		default:
+			return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+		}
+	}
+	if len(bytes.TrimSpace(data)) != 0 {
+		return nil, errors.New("failed to decode PEM block containing the public key")
+	}
+	return pubKeys, nil
+}
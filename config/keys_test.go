@@ -0,0 +1,179 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func encodePKCS8PrivateKey(t *testing.T, key *rsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func encodePKIXPublicKey(t *testing.T, key *rsa.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func Test_NewKeySet(t *testing.T) {
+	t.Parallel()
+
+	current := generateTestKey(t)
+	retired := generateTestKey(t)
+	other := generateTestKey(t)
+	pkcs1 := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(current)}))
+	pkcs1Public := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&other.PublicKey)}))
+
+	patterns := []struct {
+		name      string
+		conf      func(t *testing.T) *AuthConfig
+		want      []*rsa.PublicKey
+		ephemeral bool
+		wantErr   bool
+	}{
+		{
+			name:      "no keys configured",
+			conf:      func(t *testing.T) *AuthConfig { return &AuthConfig{} },
+			ephemeral: true,
+		},
+		{
+			name: "signing key from env",
+			conf: func(t *testing.T) *AuthConfig {
+				return &AuthConfig{SigningKey: encodePKCS8PrivateKey(t, current)}
+			},
+			want: []*rsa.PublicKey{&current.PublicKey},
+		},
+		{
+			name: "PKCS #1 signing key",
+			conf: func(t *testing.T) *AuthConfig { return &AuthConfig{SigningKey: pkcs1} },
+			want: []*rsa.PublicKey{&current.PublicKey},
+		},
+		{
+			name: "keys from files",
+			conf: func(t *testing.T) *AuthConfig {
+				return &AuthConfig{
+					SigningKeyFile:       writeTestFile(t, encodePKCS8PrivateKey(t, current)),
+					VerificationKeysFile: writeTestFile(t, encodePKIXPublicKey(t, &retired.PublicKey)+pkcs1Public),
+				}
+			},
+			want: []*rsa.PublicKey{&current.PublicKey, &retired.PublicKey, &other.PublicKey},
+		},
+		{
+			name: "env takes precedence over file",
+			conf: func(t *testing.T) *AuthConfig {
+				return &AuthConfig{
+					SigningKey:     encodePKCS8PrivateKey(t, current),
+					SigningKeyFile: writeTestFile(t, encodePKCS8PrivateKey(t, other)),
+				}
+			},
+			want: []*rsa.PublicKey{&current.PublicKey},
+		},
+		{
+			name: "verification key duplicating the signing key",
+			conf: func(t *testing.T) *AuthConfig {
+				return &AuthConfig{
+					SigningKey:       encodePKCS8PrivateKey(t, current),
+					VerificationKeys: encodePKIXPublicKey(t, &current.PublicKey) + encodePKIXPublicKey(t, &retired.PublicKey),
+				}
+			},
+			want: []*rsa.PublicKey{&current.PublicKey, &retired.PublicKey},
+		},
+		{
+			name:    "Fail: missing signing key file",
+			conf:    func(t *testing.T) *AuthConfig { return &AuthConfig{SigningKeyFile: "/nonexistent/key.pem"} },
+			wantErr: true,
+		},
+		{
+			name:    "Fail: invalid signing key",
+			conf:    func(t *testing.T) *AuthConfig { return &AuthConfig{SigningKey: "invalid"} },
+			wantErr: true,
+		},
+		{
+			name: "Fail: private key as verification key",
+			conf: func(t *testing.T) *AuthConfig {
+				return &AuthConfig{
+					SigningKey:       encodePKCS8PrivateKey(t, current),
+					VerificationKeys: encodePKCS8PrivateKey(t, retired),
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fail: invalid verification keys",
+			conf: func(t *testing.T) *AuthConfig {
+				return &AuthConfig{
+					SigningKey:       encodePKCS8PrivateKey(t, current),
+					VerificationKeys: "invalid",
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewKeySet(tt.conf(t))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.ephemeral {
+				require.NotNil(t, got.Signing.PrivateKey)
+				tt.want = []*rsa.PublicKey{&got.Signing.PrivateKey.PublicKey}
+			}
+			require.Equal(t, Thumbprint(tt.want[0]), got.Signing.ID)
+			require.Len(t, got.Verification, len(tt.want))
+			for i, key := range tt.want {
+				require.Equal(t, Thumbprint(key), got.Verification[i].ID)
+				require.True(t, key.Equal(got.Verification[i].PublicKey))
+
+				pubKey, ok := got.VerificationKey(Thumbprint(key))
+				require.True(t, ok)
+				require.True(t, key.Equal(pubKey))
+			}
+		})
+	}
+}
+
+func Test_Thumbprint(t *testing.T) {
+	t.Parallel()
+
+	// The example key of RFC 7638 section 3.1.
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	require.NoError(t, err)
+
+	got := Thumbprint(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537})
+	require.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", got)
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Clock is the source of the current time.
+// It is injected so that time dependent rules such as overdue and due-soon can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func NewClock() Clock {
+	return &systemClock{}
+}
+
+func (c *systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "errors"
+
+// Kinds of domain errors. Every error returned across the layers that callers are expected to react to
+// matches one of them with errors.Is, so that the transports can translate it into a status code.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrForbidden       = errors.New("forbidden")
+	ErrValidation      = errors.New("validation failed")
+	ErrConflict        = errors.New("conflict")
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+// Error is a domain error of a given kind. It reads as its message alone.
+// Field names the invalid input of a validation error, when there is a single one.
+type Error struct {
+	Kind    error
+	Field   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+func NewNotFoundError(message string) error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+func NewForbiddenError(message string) error {
+	return &Error{Kind: ErrForbidden, Message: message}
+}
+
+func NewValidationError(message string) error {
+	return &Error{Kind: ErrValidation, Message: message}
+}
+
+func NewFieldValidationError(field, message string) error {
+	return &Error{Kind: ErrValidation, Field: field, Message: message}
+}
+
+func NewConflictError(message string) error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+func NewUnauthenticatedError(message string) error {
+	return &Error{Kind: ErrUnauthenticated, Message: message}
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	task := &Task{Status: StatusBlocked}
+
+	patterns := []struct {
+		name    string
+		err     error
+		kind    error
+		message string
+	}{
+		{
+			name:    "validation",
+			err:     task.SetPriority(0),
+			kind:    ErrValidation,
+			message: "priority must be between 1 and 5",
+		},
+		{
+			name:    "conflict",
+			err:     task.SetStatus(StatusDone, time.Now()),
+			kind:    ErrConflict,
+			message: "cannot change status from blocked to done",
+		},
+		{
+			name:    "wrapped",
+			err:     fmt.Errorf("failed to get task: %w", NewNotFoundError("task not found")),
+			kind:    ErrNotFound,
+			message: "failed to get task: task not found",
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if !errors.Is(tt.err, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", tt.err, tt.kind)
+			}
+			if tt.err.Error() != tt.message {
+				t.Errorf("Error() = %v, want %v", tt.err.Error(), tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshToken is the server side record of an opaque refresh token.
+// Only the hash of the token is stored, so a leaked store cannot be used to refresh sessions.
+//
+// Every refresh rotates the token: the presented token is marked as used and a new one is issued
+// in the same family. Presenting a used token again means it was stolen, and the whole family is revoked.
+type RefreshToken struct {
+	TokenHash string    `json:"token_hash"`
+	FamilyID  string    `json:"family_id"`
+	UserID    string    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	Used      bool      `json:"used"`
+	Revoked   bool      `json:"revoked"`
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (rt *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(rt.ExpiresAt)
+}
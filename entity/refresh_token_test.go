@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntity_HashRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	hash := HashRefreshToken("token")
+	if hash == "token" || len(hash) != 64 {
+		t.Errorf("HashRefreshToken() = %v, want a hex encoded sha256 digest", hash)
+	}
+	if HashRefreshToken("token") != hash {
+		t.Errorf("HashRefreshToken() is not deterministic")
+	}
+	if HashRefreshToken("other") == hash {
+		t.Errorf("HashRefreshToken() returned the same hash for different tokens")
+	}
+}
+
+func TestEntity_RefreshToken_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	patterns := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{
+			name:      "not expired",
+			expiresAt: now.Add(time.Second),
+			want:      false,
+		},
+		{
+			name:      "expired at the boundary",
+			expiresAt: now,
+			want:      true,
+		},
+		{
+			name:      "expired",
+			expiresAt: now.Add(-time.Second),
+			want:      true,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := RefreshToken{ExpiresAt: tt.expiresAt}
+			if got := rt.IsExpired(now); got != tt.want {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
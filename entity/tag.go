@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+)
+
+const MaxTagNameLength = 50
+
+// Tag is a label of the tasks of a user, like "backend" or "urgent".
+// A tag can be attached to any number of tasks and its name is unique among the tags of its user.
+type Tag struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SetName renames the tag. The name is trimmed, and must not contain commas,
+// which separate the tags of a query.
+func (t *Tag) SetName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		log.Error("tag name is required")
+		return NewFieldValidationError("name", "name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxTagNameLength {
+		log.Error("tag name is too long", log.Fstring("name", name))
+		return NewFieldValidationError("name", fmt.Sprintf("name must be at most %d characters", MaxTagNameLength))
+	}
+	if strings.Contains(name, ",") {
+		log.Error("tag name contains a comma", log.Fstring("name", name))
+		return NewFieldValidationError("name", "name must not contain commas")
+	}
+	t.Name = name
+	return nil
+}
+
+func sortTagsByName(tags []Tag) {
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+}
+
+func NewTag(userID, name string, now time.Time) (*Tag, error) {
+	if userID == "" {
+		log.Error("userID is required")
+		return nil, NewFieldValidationError("user_id", "userID is required")
+	}
+	tag := &Tag{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	if err := tag.SetName(name); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEntity_NewTag(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	patterns := []struct {
+		name string
+		arg  struct {
+			userID string
+			name   string
+		}
+		want struct {
+			name string
+			err  error
+		}
+	}{
+		{
+			name: "success",
+			arg: struct {
+				userID string
+				name   string
+			}{userID: "user", name: "  backend "},
+			want: struct {
+				name string
+				err  error
+			}{name: "backend"},
+		},
+		{
+			name: "Fail: userID is empty",
+			arg: struct {
+				userID string
+				name   string
+			}{name: "backend"},
+			want: struct {
+				name string
+				err  error
+			}{err: errors.New("userID is required")},
+		},
+		{
+			name: "Fail: name is blank",
+			arg: struct {
+				userID string
+				name   string
+			}{userID: "user", name: " "},
+			want: struct {
+				name string
+				err  error
+			}{err: errors.New("name is required")},
+		},
+		{
+			name: "Fail: name is too long",
+			arg: struct {
+				userID string
+				name   string
+			}{userID: "user", name: strings.Repeat("あ", MaxTagNameLength+1)},
+			want: struct {
+				name string
+				err  error
+			}{err: errors.New("name must be at most 50 characters")},
+		},
+		{
+			name: "Fail: name contains a comma",
+			arg: struct {
+				userID string
+				name   string
+			}{userID: "user", name: "backend,urgent"},
+			want: struct {
+				name string
+				err  error
+			}{err: errors.New("name must not contain commas")},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tag, err := NewTag(tt.arg.userID, tt.arg.name, now)
+
+			if (err != nil) != (tt.want.err != nil) {
+				t.Fatalf("NewTag() error = %v, wantErr %v", err, tt.want.err)
+			} else if err != nil {
+				if err.Error() != tt.want.err.Error() {
+					t.Errorf("NewTag() error = %v, wantErr %v", err, tt.want.err)
+				}
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("NewTag() error = %v, want a validation error", err)
+				}
+				return
+			}
+
+			if tag.ID == "" || !tag.CreatedAt.Equal(now) {
+				t.Errorf("NewTag() = %+v, want an ID and creation time %v", tag, now)
+			}
+			if tag.UserID != tt.arg.userID || tag.Name != tt.want.name {
+				t.Errorf("NewTag() = %+v, want user %q and name %q", tag, tt.arg.userID, tt.want.name)
+			}
+		})
+	}
+}
+
+func TestEntity_Task_SetTags(t *testing.T) {
+	t.Parallel()
+
+	urgent := Tag{ID: "a", Name: "urgent"}
+	backend := Tag{ID: "b", Name: "backend"}
+
+	task := &Task{TagIDs: []string{"c"}}
+	task.SetTags([]Tag{urgent, backend, urgent})
+
+	if d := cmp.Diff([]string{"a", "b"}, task.TagIDs); d != "" {
+		t.Errorf("SetTags() TagIDs differ: (-want +got)\n%s", d)
+	}
+	if d := cmp.Diff([]Tag{backend, urgent}, task.Tags); d != "" {
+		t.Errorf("SetTags() Tags differ: (-want +got)\n%s", d)
+	}
+
+	task.ResolveTags(map[string]Tag{"a": urgent})
+	if d := cmp.Diff([]Tag{urgent}, task.Tags); d != "" {
+		t.Errorf("ResolveTags() Tags differ: (-want +got)\n%s", d)
+	}
+	if d := cmp.Diff([]string{"a", "b"}, task.TagIDs); d != "" {
+		t.Errorf("ResolveTags() TagIDs differ: (-want +got)\n%s", d)
+	}
+
+	task.SetTags(nil)
+	if task.TagIDs != nil || task.Tags != nil {
+		t.Errorf("SetTags(nil) = %v, %v, want no tags", task.TagIDs, task.Tags)
+	}
+}
+
+func TestEntity_Task_HasTags(t *testing.T) {
+	t.Parallel()
+
+	task := &Task{TagIDs: []string{"a", "b"}}
+
+	patterns := []struct {
+		name string
+		ids  []string
+		all  bool
+		want bool
+	}{
+		{name: "any: no tags", want: true},
+		{name: "any: one of them", ids: []string{"b", "c"}, want: true},
+		{name: "any: none of them", ids: []string{"c", "d"}, want: false},
+		{name: "all: no tags", all: true, want: true},
+		{name: "all: every one", ids: []string{"a", "b"}, all: true, want: true},
+		{name: "all: one missing", ids: []string{"a", "c"}, all: true, want: false},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := task.HasTags(tt.ids, tt.all); got != tt.want {
+				t.Errorf("HasTags(%v, %v) = %v, want %v", tt.ids, tt.all, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package entity
 
 import (
-	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -48,53 +49,192 @@ var ValidPriorities = map[int]bool{
 	High:       true,
 }
 
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusDone       = "done"
+	StatusArchived   = "archived"
+)
+
+var ValidStatuses = map[string]bool{
+	StatusTodo:       true,
+	StatusInProgress: true,
+	StatusBlocked:    true,
+	StatusDone:       true,
+	StatusArchived:   true,
+}
+
+// statusTransitions lists, for each status, the statuses a task may move to next.
+// A done task can be reopened, and an archived task can only be restored to todo.
+var statusTransitions = map[string]map[string]bool{
+	StatusTodo: {
+		StatusInProgress: true,
+		StatusBlocked:    true,
+		StatusDone:       true,
+		StatusArchived:   true,
+	},
+	StatusInProgress: {
+		StatusTodo:     true,
+		StatusBlocked:  true,
+		StatusDone:     true,
+		StatusArchived: true,
+	},
+	StatusBlocked: {
+		StatusTodo:       true,
+		StatusInProgress: true,
+		StatusArchived:   true,
+	},
+	StatusDone: {
+		StatusTodo:     true,
+		StatusArchived: true,
+	},
+	StatusArchived: {
+		StatusTodo: true,
+	},
+}
+
 type Task struct {
-	ID          string    `json:"id"`
-	UserID      string    `json:"user_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	DueDate     time.Time `json:"due_date"`
-	Priority    int       `json:"priority"`
-	CreatedAt   time.Time `json:"created_at"`
-	IsOverdue   bool      `json:"is_overdue"`
-	IsDueSoon   bool      `json:"is_due_soon"`
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueDate     time.Time  `json:"due_date"`
+	Priority    int        `json:"priority"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	IsOverdue   bool       `json:"is_overdue"`
+	IsDueSoon   bool       `json:"is_due_soon"`
+	// TagIDs are the IDs of the tags of the task in ascending order, which is what the repositories store.
+	TagIDs []string `json:"tag_ids,omitempty"`
+	// Tags are the tags of TagIDs ordered by name. Like IsOverdue, they are filled in when the task is read.
+	Tags []Tag `json:"tags,omitempty"`
+}
+
+// IsClosed reports whether the task no longer needs any work,
+// in which case it is never considered overdue.
+func (t *Task) IsClosed() bool {
+	return t.Status == StatusDone || t.Status == StatusArchived
+}
+
+func (t *Task) CheckOverdue(now time.Time) bool {
+	if t.IsClosed() {
+		return false
+	}
+	return now.After(t.DueDate)
+}
+
+// CheckDueSoon reports whether the task is due within the given window from now.
+func (t *Task) CheckDueSoon(now time.Time, window time.Duration) bool {
+	if t.IsClosed() {
+		return false
+	}
+	return now.Before(t.DueDate) && now.After(t.DueDate.Add(-window))
 }
 
-func (t *Task) CheckOverdue() bool {
-	return time.Now().After(t.DueDate)
+// SetDeadlineFlags populates IsOverdue and IsDueSoon as of now.
+func (t *Task) SetDeadlineFlags(now time.Time, window time.Duration) {
+	t.IsOverdue = t.CheckOverdue(now)
+	t.IsDueSoon = t.CheckDueSoon(now, window)
 }
 
-func (t *Task) CheckDueSoon() bool {
-	now := time.Now()
-	return now.Before(t.DueDate) && time.Now().After(t.DueDate.AddDate(0, 0, -1))
+// SetTags replaces the tags of the task.
+func (t *Task) SetTags(tags []Tag) {
+	t.TagIDs, t.Tags = nil, nil
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if seen[tag.ID] {
+			continue
+		}
+		seen[tag.ID] = true
+		t.TagIDs = append(t.TagIDs, tag.ID)
+		t.Tags = append(t.Tags, tag)
+	}
+	sort.Strings(t.TagIDs)
+	sortTagsByName(t.Tags)
+}
+
+// ResolveTags fills in Tags from TagIDs. IDs missing from tags are left out of Tags.
+func (t *Task) ResolveTags(tags map[string]Tag) {
+	t.Tags = nil
+	for _, id := range t.TagIDs {
+		if tag, ok := tags[id]; ok {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+	sortTagsByName(t.Tags)
+}
+
+// HasTags reports whether the task has all of the tags when all is true, or any of them otherwise.
+// Every task matches when ids is empty.
+func (t *Task) HasTags(ids []string, all bool) bool {
+	has := make(map[string]bool, len(t.TagIDs))
+	for _, id := range t.TagIDs {
+		has[id] = true
+	}
+	for _, id := range ids {
+		switch {
+		case all && !has[id]:
+			return false
+		case !all && has[id]:
+			return true
+		}
+	}
+	return all || len(ids) == 0
 }
 
 func (t *Task) SetPriority(priority int) error {
 	if !ValidPriorities[priority] {
 		log.Error("priority must be between 1 and 5")
-		return errors.New("priority must be between 1 and 5")
+		return NewFieldValidationError("priority", "priority must be between 1 and 5")
 	}
 	t.Priority = priority
 	return nil
 }
 
-func NewTask(userID, title, description string, dueDate time.Time, priority int) (*Task, error) {
+// SetStatus moves the task to the given status if the transition is allowed.
+// Entering done records now as the completion time, and leaving done clears it.
+func (t *Task) SetStatus(status string, now time.Time) error {
+	if !ValidStatuses[status] {
+		log.Error("invalid status", log.Fstring("status", status))
+		return NewFieldValidationError("status", "invalid status")
+	}
+	if t.Status == status {
+		return nil
+	}
+	if !statusTransitions[t.Status][status] {
+		log.Error("invalid status transition", log.Fstring("from", t.Status), log.Fstring("to", status))
+		return NewConflictError(fmt.Sprintf("cannot change status from %s to %s", t.Status, status))
+	}
+	t.Status = status
+	switch status {
+	case StatusDone:
+		completedAt := now
+		t.CompletedAt = &completedAt
+	case StatusTodo, StatusInProgress, StatusBlocked:
+		t.CompletedAt = nil
+	}
+	return nil
+}
+
+func NewTask(userID, title, description string, dueDate time.Time, priority int, now time.Time) (*Task, error) {
 	if userID == "" {
 		log.Error("userID is required")
-		return nil, errors.New("userID is required")
+		return nil, NewFieldValidationError("user_id", "userID is required")
 	}
 	if title == "" {
 		log.Error("title is required")
-		return nil, errors.New("title is required")
+		return nil, NewFieldValidationError("title", "title is required")
 	}
 	if description == "" {
 		log.Error("description is required")
-		return nil, errors.New("description is required")
+		return nil, NewFieldValidationError("description", "description is required")
 	}
 	// TODO: Check if dueDate is in the future
 	if !ValidPriorities[priority] {
 		log.Error("priority must be between 1 and 5")
-		return nil, errors.New("priority must be between 1 and 5")
+		return nil, NewFieldValidationError("priority", "priority must be between 1 and 5")
 	}
 	return &Task{
 		ID:          uuid.New().String(),
@@ -103,6 +243,7 @@ func NewTask(userID, title, description string, dueDate time.Time, priority int)
 		Description: description,
 		DueDate:     dueDate,
 		Priority:    priority,
-		CreatedAt:   time.Now(),
+		Status:      StatusTodo,
+		CreatedAt:   now,
 	}, nil
 }
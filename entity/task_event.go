@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	TaskEventCreated = "created"
+	TaskEventUpdated = "updated"
+	TaskEventDeleted = "deleted"
+)
+
+// TaskEvent notifies a change of a task to its owner.
+// Task is the state after the change, or the last state before it for a deleted task.
+type TaskEvent struct {
+	Type       string    `json:"type"`
+	Task       Task      `json:"task"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func NewTaskEvent(eventType string, task Task, now time.Time) TaskEvent {
+	return TaskEvent{
+		Type:       eventType,
+		Task:       task,
+		OccurredAt: now,
+	}
+}
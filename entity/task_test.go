@@ -14,7 +14,8 @@ func TestEntity_NewTask(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New().String()
-	dueDate := time.Now().AddDate(0, 0, 1)
+	now := time.Now()
+	dueDate := now.AddDate(0, 0, 1)
 
 	patterns := []struct {
 		name string
@@ -55,6 +56,8 @@ func TestEntity_NewTask(t *testing.T) {
 					Description: "description",
 					DueDate:     dueDate,
 					Priority:    Medium,
+					Status:      StatusTodo,
+					CreatedAt:   now,
 				},
 				err: nil,
 			},
@@ -181,7 +184,7 @@ func TestEntity_NewTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			task, err := NewTask(tt.arg.userID, tt.arg.title, tt.arg.description, tt.arg.dueDate, tt.arg.priority)
+			task, err := NewTask(tt.arg.userID, tt.arg.title, tt.arg.description, tt.arg.dueDate, tt.arg.priority, now)
 
 			if (err != nil) != (tt.want.err != nil) {
 				t.Errorf("NewTask() error = %v, wantErr %v", err, tt.want.err)
@@ -189,7 +192,7 @@ func TestEntity_NewTask(t *testing.T) {
 				t.Errorf("NewTask() error = %v, wantErr %v", err, tt.want.err)
 			}
 
-			if d := cmp.Diff(task, tt.want.task, cmpopts.IgnoreFields(Task{}, "ID", "CreatedAt")); len(d) != 0 {
+			if d := cmp.Diff(task, tt.want.task, cmpopts.IgnoreFields(Task{}, "ID")); len(d) != 0 {
 				t.Errorf("NewTask() mismatch (-got +want):\n%s", d)
 			}
 		})
@@ -203,19 +206,56 @@ func TestEntity_Task_CheckOverdue(t *testing.T) {
 
 	patterns := []struct {
 		name string
-		arg  time.Time
+		arg  struct {
+			dueDate time.Time
+			status  string
+		}
 		want bool
 	}{
 		{
 			name: "Success: Not overdue, due tomorrow",
-			arg:  now.AddDate(0, 0, 1),
+			arg: struct {
+				dueDate time.Time
+				status  string
+			}{
+				dueDate: now.AddDate(0, 0, 1),
+				status:  StatusTodo,
+			},
 			want: false,
 		},
 		{
 			name: "Success: Already overdue",
-			arg:  now.AddDate(0, 0, -1),
+			arg: struct {
+				dueDate time.Time
+				status  string
+			}{
+				dueDate: now.AddDate(0, 0, -1),
+				status:  StatusTodo,
+			},
 			want: true,
 		},
+		{
+			name: "Success: Past due but done",
+			arg: struct {
+				dueDate time.Time
+				status  string
+			}{
+				dueDate: now.AddDate(0, 0, -1),
+				status:  StatusDone,
+			},
+			want: false,
+		},
+		{
+			name: "Success: Past due but archived",
+			arg: struct {
+				dueDate time.Time
+				status  string
+			}{
+				dueDate: now.AddDate(0, 0, -1),
+				status:  StatusArchived,
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range patterns {
@@ -224,10 +264,11 @@ func TestEntity_Task_CheckOverdue(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				DueDate: tt.arg,
+				DueDate: tt.arg.dueDate,
+				Status:  tt.arg.status,
 			}
 
-			if got := task.CheckOverdue(); got != tt.want {
+			if got := task.CheckOverdue(now); got != tt.want {
 				t.Errorf("IsOverdue() = %v, want %v", got, tt.want)
 			}
 		})
@@ -241,22 +282,76 @@ func TestEntity_Task_CheckDueSoon(t *testing.T) {
 
 	patterns := []struct {
 		name string
-		arg  time.Time
+		arg  struct {
+			dueDate time.Time
+			window  time.Duration
+			status  string
+		}
 		want bool
 	}{
 		{
 			name: "Success: Due soon within 1 day",
-			arg:  now.AddDate(0, 0, 1),
+			arg: struct {
+				dueDate time.Time
+				window  time.Duration
+				status  string
+			}{
+				dueDate: now.Add(23 * time.Hour),
+				window:  24 * time.Hour,
+				status:  StatusTodo,
+			},
 			want: true,
 		},
 		{
 			name: "Success: Not due soon, 2 days left",
-			arg:  now.AddDate(0, 0, 2),
+			arg: struct {
+				dueDate time.Time
+				window  time.Duration
+				status  string
+			}{
+				dueDate: now.AddDate(0, 0, 2),
+				window:  24 * time.Hour,
+				status:  StatusTodo,
+			},
 			want: false,
 		},
+		{
+			name: "Success: Due soon with a wider window",
+			arg: struct {
+				dueDate time.Time
+				window  time.Duration
+				status  string
+			}{
+				dueDate: now.AddDate(0, 0, 2),
+				window:  72 * time.Hour,
+				status:  StatusTodo,
+			},
+			want: true,
+		},
 		{
 			name: "Success: Already overdue",
-			arg:  now.AddDate(0, 0, -1),
+			arg: struct {
+				dueDate time.Time
+				window  time.Duration
+				status  string
+			}{
+				dueDate: now.AddDate(0, 0, -1),
+				window:  24 * time.Hour,
+				status:  StatusTodo,
+			},
+			want: false,
+		},
+		{
+			name: "Success: Due soon but done",
+			arg: struct {
+				dueDate time.Time
+				window  time.Duration
+				status  string
+			}{
+				dueDate: now.Add(time.Hour),
+				window:  24 * time.Hour,
+				status:  StatusDone,
+			},
 			want: false,
 		},
 	}
@@ -267,10 +362,11 @@ func TestEntity_Task_CheckDueSoon(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				DueDate: tt.arg,
+				DueDate: tt.arg.dueDate,
+				Status:  tt.arg.status,
 			}
 
-			if got := task.CheckDueSoon(); got != tt.want {
+			if got := task.CheckDueSoon(now, tt.arg.window); got != tt.want {
 				t.Errorf("CheckDueSoon() = %v, want %v", got, tt.want)
 			}
 		})
@@ -344,3 +440,136 @@ func TestEntity_Task_SetPriority(t *testing.T) {
 		})
 	}
 }
+
+func TestEntity_Task_SetStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	completedAt := now.AddDate(0, 0, -1)
+
+	patterns := []struct {
+		name string
+		task *Task
+		arg  string
+		want struct {
+			task *Task
+			err  error
+		}
+	}{
+		{
+			name: "success: todo to in_progress",
+			task: &Task{Status: StatusTodo},
+			arg:  StatusInProgress,
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusInProgress},
+				err:  nil,
+			},
+		},
+		{
+			name: "success: in_progress to done records completed_at",
+			task: &Task{Status: StatusInProgress},
+			arg:  StatusDone,
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusDone, CompletedAt: &now},
+				err:  nil,
+			},
+		},
+		{
+			name: "success: reopening done task clears completed_at",
+			task: &Task{Status: StatusDone, CompletedAt: &completedAt},
+			arg:  StatusTodo,
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusTodo},
+				err:  nil,
+			},
+		},
+		{
+			name: "success: archiving done task keeps completed_at",
+			task: &Task{Status: StatusDone, CompletedAt: &completedAt},
+			arg:  StatusArchived,
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusArchived, CompletedAt: &completedAt},
+				err:  nil,
+			},
+		},
+		{
+			name: "success: same status is a no-op",
+			task: &Task{Status: StatusDone, CompletedAt: &completedAt},
+			arg:  StatusDone,
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusDone, CompletedAt: &completedAt},
+				err:  nil,
+			},
+		},
+		{
+			name: "Fail: invalid status",
+			task: &Task{Status: StatusTodo},
+			arg:  "unknown",
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusTodo},
+				err:  errors.New("invalid status"),
+			},
+		},
+		{
+			name: "Fail: blocked to done is not allowed",
+			task: &Task{Status: StatusBlocked},
+			arg:  StatusDone,
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusBlocked},
+				err:  errors.New("cannot change status from blocked to done"),
+			},
+		},
+		{
+			name: "Fail: archived to in_progress is not allowed",
+			task: &Task{Status: StatusArchived},
+			arg:  StatusInProgress,
+			want: struct {
+				task *Task
+				err  error
+			}{
+				task: &Task{Status: StatusArchived},
+				err:  errors.New("cannot change status from archived to in_progress"),
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.task.SetStatus(tt.arg, now)
+
+			if (err != nil) != (tt.want.err != nil) {
+				t.Errorf("SetStatus() error = %v, wantErr %v", err, tt.want.err)
+			} else if err != nil && tt.want.err != nil && err.Error() != tt.want.err.Error() {
+				t.Errorf("SetStatus() error = %v, wantErr %v", err, tt.want.err)
+			}
+
+			if d := cmp.Diff(tt.task, tt.want.task); len(d) != 0 {
+				t.Errorf("SetStatus() mismatch (-got +want):\n%s", d)
+			}
+		})
+	}
+}
@@ -1,35 +1,59 @@
 package entity
 
 import (
-	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 )
 
+const (
+	DefaultDueSoonHours = 24
+	MaxDueSoonHours     = 24 * 30
+)
+
 type User struct {
-	ID       string `json:"id" bson:"_id,omitempty"`
-	Name     string `json:"name" bson:"name"`
-	Email    string `json:"email" bson:"email"`
-	Password string `json:"password" bson:"password"`
+	ID           string `json:"id" bson:"_id,omitempty"`
+	Name         string `json:"name" bson:"name"`
+	Email        string `json:"email" bson:"email"`
+	Password     string `json:"password" bson:"password"`
+	DueSoonHours int    `json:"due_soon_hours" bson:"due_soon_hours"`
+}
+
+// DueSoonWindow returns how long before the due date a task of this user is considered due soon.
+func (u *User) DueSoonWindow() time.Duration {
+	if u.DueSoonHours <= 0 {
+		return DefaultDueSoonHours * time.Hour
+	}
+	return time.Duration(u.DueSoonHours) * time.Hour
+}
+
+func (u *User) SetDueSoonHours(hours int) error {
+	if hours < 1 || hours > MaxDueSoonHours {
+		log.Error("due soon hours out of range", log.Fint("due_soon_hours", hours))
+		return NewFieldValidationError("due_soon_hours", "due soon hours must be between 1 and 720")
+	}
+	u.DueSoonHours = hours
+	return nil
 }
 
 func NewUser(email, password string) (*User, error) {
 	if email == "" {
 		log.Error("email is required")
-		return nil, errors.New("email is required")
+		return nil, NewFieldValidationError("email", "email is required")
 	}
 	if password == "" {
 		log.Error("password is required")
-		return nil, errors.New("password is required")
+		return nil, NewFieldValidationError("password", "password is required")
 	}
 	name := extractNameFromEmail(email)
 	return &User{
-		ID:       uuid.New().String(),
-		Name:     name,
-		Email:    email,
-		Password: password,
+		ID:           uuid.New().String(),
+		Name:         name,
+		Email:        email,
+		Password:     password,
+		DueSoonHours: DefaultDueSoonHours,
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package entity
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -36,9 +37,10 @@ func TestEntity_NewUser(t *testing.T) {
 				err  error
 			}{
 				user: &User{
-					Name:     "test",
-					Email:    "test@gmail.com",
-					Password: "password123",
+					Name:         "test",
+					Email:        "test@gmail.com",
+					Password:     "password123",
+					DueSoonHours: DefaultDueSoonHours,
 				},
 				err: nil,
 			},
@@ -98,3 +100,70 @@ func TestEntity_NewUser(t *testing.T) {
 		})
 	}
 }
+
+func TestEntity_User_SetDueSoonHours(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name string
+		arg  int
+		want struct {
+			window time.Duration
+			err    error
+		}
+	}{
+		{
+			name: "success",
+			arg:  48,
+			want: struct {
+				window time.Duration
+				err    error
+			}{
+				window: 48 * time.Hour,
+				err:    nil,
+			},
+		},
+		{
+			name: "Fail: hours is less than 1",
+			arg:  0,
+			want: struct {
+				window time.Duration
+				err    error
+			}{
+				window: DefaultDueSoonHours * time.Hour,
+				err:    errors.New("due soon hours must be between 1 and 720"),
+			},
+		},
+		{
+			name: "Fail: hours is greater than 720",
+			arg:  721,
+			want: struct {
+				window time.Duration
+				err    error
+			}{
+				window: DefaultDueSoonHours * time.Hour,
+				err:    errors.New("due soon hours must be between 1 and 720"),
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			user := &User{}
+			err := user.SetDueSoonHours(tt.arg)
+
+			if (err != nil) != (tt.want.err != nil) {
+				t.Errorf("SetDueSoonHours() error = %v, wantErr %v", err, tt.want.err)
+			} else if err != nil && tt.want.err != nil && err.Error() != tt.want.err.Error() {
+				t.Errorf("SetDueSoonHours() error = %v, wantErr %v", err, tt.want.err)
+			}
+
+			if got := user.DueSoonWindow(); got != tt.want.window {
+				t.Errorf("DueSoonWindow() = %v, want %v", got, tt.want.window)
+			}
+		})
+	}
+}
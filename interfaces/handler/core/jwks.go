@@ -0,0 +1,32 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+// JWKSCacheControl lets verifiers cache the keys, but not so long that they miss a rotation.
+const JWKSCacheControl = "public, max-age=300"
+
+type JWKSHandler interface {
+	GetJWKS(r *http.Request) *Response
+}
+
+type jwksHandler struct {
+	juc usecase.JWKSUseCase
+}
+
+func NewJWKSHandler(juc usecase.JWKSUseCase) JWKSHandler {
+	return &jwksHandler{
+		juc: juc,
+	}
+}
+
+func (jh *jwksHandler) GetJWKS(r *http.Request) *Response {
+	ctx := r.Context()
+
+	res := JSONResponse(http.StatusOK, jh.juc.GetJWKS(ctx))
+	res.Header.Set("Cache-Control", JWKSCacheControl)
+	return res
+}
@@ -0,0 +1,65 @@
+// Package core implements the HTTP API independently of the router framework. It decodes and validates
+// requests, calls the use cases and shapes the responses, so that the chi, echo and gin handlers only
+// pass the *http.Request in and write the Response out.
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
+)
+
+const JSONContentType = "application/json"
+
+// Response is the answer to a request, ready to be written by any framework.
+type Response struct {
+	Status int
+	// Header holds the headers to send besides Content-Type.
+	Header      http.Header
+	ContentType string
+	// Body is nil when the response has none.
+	Body []byte
+}
+
+// StatusResponse returns a response without a body.
+func StatusResponse(status int) *Response {
+	return &Response{Status: status, Header: http.Header{}}
+}
+
+// JSONResponse returns a response with v encoded as JSON.
+func JSONResponse(status int, v interface{}) *Response {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(v); err != nil {
+		log.Error("Failed to encode response to JSON", log.Ferror(err))
+		return StatusResponse(http.StatusInternalServerError)
+	}
+	return &Response{Status: status, Header: http.Header{}, ContentType: JSONContentType, Body: body.Bytes()}
+}
+
+// ProblemResponse returns a response describing p.
+func ProblemResponse(p *problem.Problem) *Response {
+	res := JSONResponse(p.Status, p)
+	if res.Body != nil {
+		res.ContentType = problem.ContentType
+	}
+	return res
+}
+
+// ErrorResponse returns a response with the problem describing err, see problem.FromError.
+func ErrorResponse(err error, instance string) *Response {
+	return ProblemResponse(problem.FromError(err, instance))
+}
+
+// decodeJSON decodes the body of r into v. It returns io.EOF when the body is empty.
+func decodeJSON(r *http.Request, v interface{}) error {
+	if r.Body == nil {
+		return io.EOF
+	}
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
+)
+
+func TestJSONResponse(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name            string
+		value           interface{}
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "Success",
+			value:           AuthTokensResponse{RefreshToken: "token"},
+			wantStatus:      http.StatusCreated,
+			wantContentType: JSONContentType,
+			wantBody:        "{\"refresh_token\":\"token\"}\n",
+		},
+		{
+			name:       "Fail: value cannot be encoded",
+			value:      make(chan int),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := JSONResponse(http.StatusCreated, tt.value)
+
+			if res.Status != tt.wantStatus {
+				t.Errorf("JSONResponse() status = %v, want %v", res.Status, tt.wantStatus)
+			}
+			if res.ContentType != tt.wantContentType {
+				t.Errorf("JSONResponse() Content-Type = %q, want %q", res.ContentType, tt.wantContentType)
+			}
+			if string(res.Body) != tt.wantBody {
+				t.Errorf("JSONResponse() body = %q, want %q", res.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestProblemResponse(t *testing.T) {
+	t.Parallel()
+
+	res := ProblemResponse(problem.MalformedBody("/api/task/create"))
+
+	if res.Status != http.StatusBadRequest {
+		t.Errorf("ProblemResponse() status = %v, want %v", res.Status, http.StatusBadRequest)
+	}
+	if res.ContentType != problem.ContentType {
+		t.Errorf("ProblemResponse() Content-Type = %q, want %q", res.ContentType, problem.ContentType)
+	}
+	want := `{"type":"about:blank","title":"Bad Request","status":400,` +
+		`"detail":"The request body is not valid JSON.","instance":"/api/task/create"}` + "\n"
+	if string(res.Body) != want {
+		t.Errorf("ProblemResponse() body = %q, want %q", res.Body, want)
+	}
+}
+
+func Test_decodeJSON(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name    string
+		body    io.Reader
+		want    string
+		wantErr error
+	}{
+		{
+			name: "Success",
+			body: strings.NewReader(`{"refresh_token":"token"}`),
+			want: "token",
+		},
+		{
+			name:    "Fail: empty body",
+			body:    http.NoBody,
+			wantErr: io.EOF,
+		},
+		{
+			name:    "Fail: no body",
+			wantErr: io.EOF,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", tt.body)
+			if tt.body == nil {
+				req.Body = nil
+			}
+
+			var got LogoutRequest
+			err := decodeJSON(req, &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("decodeJSON() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.RefreshToken != tt.want {
+				t.Errorf("decodeJSON() RefreshToken = %q, want %q", got.RefreshToken, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type TagHandler interface {
+	ListTags(r *http.Request) *Response
+	CreateTag(r *http.Request) *Response
+	UpdateTag(r *http.Request) *Response
+	DeleteTag(r *http.Request) *Response
+}
+
+type tagHandler struct {
+	tguc usecase.TagUseCase
+}
+
+func NewTagHandler(tguc usecase.TagUseCase) TagHandler {
+	return &tagHandler{
+		tguc: tguc,
+	}
+}
+
+type GetTagResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListTagsResponse struct {
+	Tags []GetTagResponse `json:"tags"`
+}
+
+func (tgh *tagHandler) ListTags(r *http.Request) *Response {
+	ctx := r.Context()
+
+	tags, err := tgh.tguc.ListTags(ctx)
+	if err != nil {
+		log.Error("Failed to list tags", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	tagsResponse := make([]GetTagResponse, 0, len(tags))
+	for _, tag := range tags {
+		tagsResponse = append(tagsResponse, tgh.convertTagToGetTagResponse(tag))
+	}
+	return JSONResponse(http.StatusOK, ListTagsResponse{Tags: tagsResponse})
+}
+
+func (tgh *tagHandler) convertTagToGetTagResponse(tag entity.Tag) GetTagResponse {
+	return GetTagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+	}
+}
+
+type CreateTagRequest struct {
+	Name string `json:"name"`
+}
+
+func (tgh *tagHandler) CreateTag(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody CreateTagRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if requestBody.Name == "" {
+		log.Warn("Invalid request body: %v", requestBody)
+		return ProblemResponse(problem.Validation(r.URL.Path, []problem.FieldError{{Field: "name", Reason: "is required"}}))
+	}
+
+	tag, err := tgh.tguc.CreateTag(ctx, &usecase.CreateTagParams{Name: requestBody.Name})
+	if err != nil {
+		log.Error("Failed to create tag", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return JSONResponse(http.StatusOK, tgh.convertTagToGetTagResponse(*tag))
+}
+
+type UpdateTagRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (tgh *tagHandler) UpdateTag(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody UpdateTagRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := tgh.validateUpdateTagRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	if err := tgh.tguc.UpdateTag(ctx, &usecase.UpdateTagParams{
+		ID:   requestBody.ID,
+		Name: requestBody.Name,
+	}); err != nil {
+		log.Error("Failed to update tag", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return StatusResponse(http.StatusOK)
+}
+
+func (tgh *tagHandler) validateUpdateTagRequest(requestBody *UpdateTagRequest) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if requestBody.ID == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "id", Reason: "is required"})
+	}
+	if requestBody.Name == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "name", Reason: "is required"})
+	}
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid request body: %v", requestBody)
+	}
+	return fieldErrors
+}
+
+func (tgh *tagHandler) DeleteTag(r *http.Request) *Response {
+	ctx := r.Context()
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Warn("ID is required")
+		return ProblemResponse(problem.Validation(r.URL.Path, []problem.FieldError{{Field: "id", Reason: "is required"}}))
+	}
+
+	if err := tgh.tguc.DeleteTag(ctx, id); err != nil {
+		log.Error("Failed to delete tag", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return StatusResponse(http.StatusOK)
+}
@@ -0,0 +1,332 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+	"github.com/tusmasoma/go-clean-arch/usecase/mock"
+)
+
+func TestTagHandler_ListTags(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tags := []entity.Tag{
+		{ID: "1", Name: "backend", CreatedAt: createdAt},
+		{ID: "2", Name: "urgent", CreatedAt: createdAt},
+	}
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTagUseCase,
+		)
+		in         func() *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().ListTags(gomock.Any()).Return(tags, nil)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/tag/list", nil)
+				return req
+			},
+			wantStatus: http.StatusOK,
+			wantBody: "{\"tags\":[{\"id\":\"1\",\"name\":\"backend\",\"created_at\":\"2024-01-01T00:00:00Z\"}," +
+				"{\"id\":\"2\",\"name\":\"urgent\",\"created_at\":\"2024-01-01T00:00:00Z\"}]}\n",
+		},
+		{
+			name: "Fail: internal error",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().ListTags(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/tag/list", nil)
+				return req
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tguc := mock.NewMockTagUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tguc)
+			}
+
+			handler := NewTagHandler(tguc)
+			res := handler.ListTags(tt.in())
+
+			if res.Status != tt.wantStatus {
+				t.Fatalf("ListTags() status = %v, want %v", res.Status, tt.wantStatus)
+			}
+			if tt.wantBody != "" && string(res.Body) != tt.wantBody {
+				t.Errorf("ListTags() body = %q, want %q", res.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestTagHandler_CreateTag(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTagUseCase,
+		)
+		in         func() *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().CreateTag(
+					gomock.Any(),
+					&usecase.CreateTagParams{Name: "backend"},
+				).Return(&entity.Tag{ID: "1", Name: "backend", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, nil)
+			},
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(CreateTagRequest{Name: "backend"})
+				req, _ := http.NewRequest(http.MethodPost, "/api/tag/create", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"id\":\"1\",\"name\":\"backend\",\"created_at\":\"2024-01-01T00:00:00Z\"}\n",
+		},
+		{
+			name: "Fail: malformed body",
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, "/api/tag/create", strings.NewReader(`{"name":`))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: invalid request of name is empty",
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(CreateTagRequest{Name: ""})
+				req, _ := http.NewRequest(http.MethodPost, "/api/tag/create", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: name is taken",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().CreateTag(
+					gomock.Any(),
+					gomock.Any(),
+				).Return(nil, repository.ErrDuplicateTagName)
+			},
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(CreateTagRequest{Name: "backend"})
+				req, _ := http.NewRequest(http.MethodPost, "/api/tag/create", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tguc := mock.NewMockTagUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tguc)
+			}
+
+			handler := NewTagHandler(tguc)
+			res := handler.CreateTag(tt.in())
+
+			if res.Status != tt.wantStatus {
+				t.Fatalf("CreateTag() status = %v, want %v", res.Status, tt.wantStatus)
+			}
+			if tt.wantBody != "" && string(res.Body) != tt.wantBody {
+				t.Errorf("CreateTag() body = %q, want %q", res.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestTagHandler_UpdateTag(t *testing.T) {
+	t.Parallel()
+
+	tagID := uuid.New().String()
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTagUseCase,
+		)
+		in         func() *http.Request
+		wantStatus int
+	}{
+		{
+			name: "success",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().UpdateTag(
+					gomock.Any(),
+					&usecase.UpdateTagParams{ID: tagID, Name: "server"},
+				).Return(nil)
+			},
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(UpdateTagRequest{ID: tagID, Name: "server"})
+				req, _ := http.NewRequest(http.MethodPut, "/api/tag/update", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Fail: invalid request of id is empty",
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(UpdateTagRequest{Name: "server"})
+				req, _ := http.NewRequest(http.MethodPut, "/api/tag/update", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: tag of another user",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().UpdateTag(
+					gomock.Any(),
+					gomock.Any(),
+				).Return(usecase.ErrTagNotOwned)
+			},
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(UpdateTagRequest{ID: tagID, Name: "server"})
+				req, _ := http.NewRequest(http.MethodPut, "/api/tag/update", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tguc := mock.NewMockTagUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tguc)
+			}
+
+			handler := NewTagHandler(tguc)
+			res := handler.UpdateTag(tt.in())
+
+			if res.Status != tt.wantStatus {
+				t.Fatalf("UpdateTag() status = %v, want %v", res.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestTagHandler_DeleteTag(t *testing.T) {
+	t.Parallel()
+
+	tagID := uuid.New().String()
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTagUseCase,
+		)
+		in         func() *http.Request
+		wantStatus int
+	}{
+		{
+			name: "success",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().DeleteTag(
+					gomock.Any(),
+					tagID,
+				).Return(nil)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/tag/delete?id=%s", tagID), nil)
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Fail: invalid request of id is empty",
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodDelete, "/api/tag/delete", nil)
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: tag not found",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().DeleteTag(
+					gomock.Any(),
+					tagID,
+				).Return(repository.ErrTagNotFound)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/tag/delete?id=%s", tagID), nil)
+				return req
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tguc := mock.NewMockTagUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tguc)
+			}
+
+			handler := NewTagHandler(tguc)
+			res := handler.DeleteTag(tt.in())
+
+			if res.Status != tt.wantStatus {
+				t.Fatalf("DeleteTag() status = %v, want %v", res.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,455 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type TaskHandler interface {
+	GetTask(r *http.Request) *Response
+	ListTasks(r *http.Request) *Response
+	CreateTask(r *http.Request) *Response
+	UpdateTask(r *http.Request) *Response
+	UpdateTaskStatus(r *http.Request) *Response
+	DeleteTask(r *http.Request) *Response
+	// WatchTasks returns the task events to stream, or the response to send instead when watching fails.
+	WatchTasks(r *http.Request) (<-chan entity.TaskEvent, *Response)
+}
+
+type taskHandler struct {
+	tuc usecase.TaskUseCase
+}
+
+func NewTaskHandler(tuc usecase.TaskUseCase) TaskHandler {
+	return &taskHandler{
+		tuc: tuc,
+	}
+}
+
+type GetTaskResponse struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueDate     time.Time  `json:"due_date"`
+	Priority    int        `json:"priority"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	IsOverdue   bool       `json:"is_overdue"`
+	IsDueSoon   bool       `json:"is_due_soon"`
+	Tags        []string   `json:"tags"`
+}
+
+func (th *taskHandler) GetTask(r *http.Request) *Response {
+	ctx := r.Context()
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Warn("ID is required")
+		return ProblemResponse(problem.Validation(r.URL.Path, []problem.FieldError{{Field: "id", Reason: "is required"}}))
+	}
+
+	task, err := th.tuc.GetTask(ctx, id)
+	if err != nil {
+		log.Error("Failed to get task", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return JSONResponse(http.StatusOK, NewGetTaskResponse(*task))
+}
+
+// NewGetTaskResponse converts a task to the representation that every transport sends.
+func NewGetTaskResponse(task entity.Task) GetTaskResponse {
+	return GetTaskResponse{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		DueDate:     task.DueDate,
+		Priority:    task.Priority,
+		Status:      task.Status,
+		CompletedAt: task.CompletedAt,
+		CreatedAt:   task.CreatedAt,
+		IsOverdue:   task.IsOverdue,
+		IsDueSoon:   task.IsDueSoon,
+		Tags:        tagNames(task.Tags),
+	}
+}
+
+// tagNames returns the names of the tags, or an empty slice so that untagged tasks have "tags": [].
+func tagNames(tags []entity.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+type ListTasksResponse struct {
+	Tasks      []GetTaskResponse `json:"tasks"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+func (th *taskHandler) ListTasks(r *http.Request) *Response {
+	ctx := r.Context()
+
+	req, fieldErrors := th.parseListTasksRequest(r.URL.Query())
+	if len(fieldErrors) == 0 {
+		fieldErrors = th.validateListTasksRequest(req)
+	}
+	if len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	tasks, next, err := th.tuc.ListTasks(ctx, th.convertListTasksRequestToParams(req))
+	if err != nil {
+		log.Error("Failed to list tasks", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return JSONResponse(http.StatusOK, th.convertTasksToListTasksResponse(tasks, next))
+}
+
+func (th *taskHandler) convertTasksToListTasksResponse(tasks []entity.Task, next string) ListTasksResponse {
+	var tasksResponse []GetTaskResponse
+	for _, task := range tasks {
+		tasksResponse = append(tasksResponse, NewGetTaskResponse(task))
+	}
+	return ListTasksResponse{
+		Tasks:      tasksResponse,
+		NextCursor: next,
+	}
+}
+
+type ListTasksRequest struct {
+	MinPriority int
+	MaxPriority int
+	DueAfter    time.Time
+	DueBefore   time.Time
+	Overdue     *bool
+	TitlePrefix string
+	Tags        []string
+	TagMatch    string
+	SortBy      string
+	Order       string
+	Limit       int
+	Cursor      string
+}
+
+// parseListTasksRequest reads the query parameters and reports every one that cannot be parsed.
+func (th *taskHandler) parseListTasksRequest(query url.Values) (*ListTasksRequest, []problem.FieldError) {
+	var (
+		req         ListTasksRequest
+		fieldErrors []problem.FieldError
+	)
+	parseInt := func(field string, dst *int) {
+		if v := query.Get(field); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				fieldErrors = append(fieldErrors, problem.FieldError{Field: field, Reason: "must be an integer"})
+				return
+			}
+			*dst = n
+		}
+	}
+	parseTime := func(field string, dst *time.Time) {
+		if v := query.Get(field); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				fieldErrors = append(fieldErrors, problem.FieldError{Field: field, Reason: "must be an RFC 3339 timestamp"})
+				return
+			}
+			*dst = t
+		}
+	}
+
+	parseInt("min_priority", &req.MinPriority)
+	parseInt("max_priority", &req.MaxPriority)
+	parseTime("due_after", &req.DueAfter)
+	parseTime("due_before", &req.DueBefore)
+	if v := query.Get("overdue"); v != "" {
+		overdue, err := strconv.ParseBool(v)
+		if err != nil {
+			fieldErrors = append(fieldErrors, problem.FieldError{Field: "overdue", Reason: "must be a boolean"})
+		} else {
+			req.Overdue = &overdue
+		}
+	}
+	parseInt("limit", &req.Limit)
+	req.TitlePrefix = query.Get("title_prefix")
+	if v := query.Get("tags"); v != "" {
+		req.Tags = strings.Split(v, ",")
+	}
+	req.TagMatch = query.Get("tag_match")
+	req.SortBy = query.Get("sort_by")
+	req.Order = query.Get("order")
+	req.Cursor = query.Get("cursor")
+	return &req, fieldErrors
+}
+
+func (th *taskHandler) validateListTasksRequest(req *ListTasksRequest) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if req.MinPriority != 0 && !entity.ValidPriorities[req.MinPriority] {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "min_priority", Reason: "must be between 1 and 5"})
+	}
+	if req.MaxPriority != 0 && !entity.ValidPriorities[req.MaxPriority] {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "max_priority", Reason: "must be between 1 and 5"})
+	}
+	if req.MinPriority != 0 && req.MaxPriority != 0 && req.MinPriority > req.MaxPriority {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "min_priority", Reason: "must not be greater than max_priority"})
+	}
+	if !req.DueAfter.IsZero() && !req.DueBefore.IsZero() && !req.DueAfter.Before(req.DueBefore) {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "due_after", Reason: "must be before due_before"})
+	}
+	for _, name := range req.Tags {
+		if strings.TrimSpace(name) == "" {
+			fieldErrors = append(fieldErrors, problem.FieldError{Field: "tags", Reason: "must not contain an empty name"})
+			break
+		}
+	}
+	if req.TagMatch != "" && req.TagMatch != "any" && req.TagMatch != "all" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "tag_match", Reason: "must be any or all"})
+	}
+	if req.SortBy != "" && !repository.ValidTaskSortKeys[req.SortBy] {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "sort_by", Reason: "is not a sortable field"})
+	}
+	if req.Order != "" && req.Order != "asc" && req.Order != "desc" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "order", Reason: "must be asc or desc"})
+	}
+	if req.Limit < 0 || req.Limit > usecase.MaxListTasksLimit {
+		fieldErrors = append(fieldErrors, problem.FieldError{
+			Field:  "limit",
+			Reason: fmt.Sprintf("must be between 0 and %d", usecase.MaxListTasksLimit),
+		})
+	}
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid request: %v", req)
+	}
+	return fieldErrors
+}
+
+func (th *taskHandler) convertListTasksRequestToParams(req *ListTasksRequest) *usecase.ListTasksParams {
+	return &usecase.ListTasksParams{
+		MinPriority:  req.MinPriority,
+		MaxPriority:  req.MaxPriority,
+		DueAfter:     req.DueAfter,
+		DueBefore:    req.DueBefore,
+		Overdue:      req.Overdue,
+		TitlePrefix:  req.TitlePrefix,
+		Tags:         req.Tags,
+		MatchAllTags: req.TagMatch == "all",
+		SortBy:       req.SortBy,
+		SortDesc:     req.Order == "desc",
+		Limit:        req.Limit,
+		Cursor:       req.Cursor,
+	}
+}
+
+type CreateTaskRequest struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	Priority    int       `json:"priority"`
+	Tags        []string  `json:"tags"`
+}
+
+func (th *taskHandler) CreateTask(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody CreateTaskRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := th.validateCreateTaskRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	params := th.convertCreateTaskReqeuestToParams(requestBody)
+	if err := th.tuc.CreateTask(ctx, params); err != nil {
+		log.Error("Failed to create task", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return StatusResponse(http.StatusOK)
+}
+
+func (th *taskHandler) validateCreateTaskRequest(requestBody *CreateTaskRequest) []problem.FieldError {
+	fieldErrors := validateTaskFields(requestBody.Title, requestBody.Description, requestBody.DueDate, requestBody.Priority)
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid request body: %v", requestBody)
+	}
+	return fieldErrors
+}
+
+// validateTaskFields checks the fields that creating and updating a task have in common.
+func validateTaskFields(title, description string, dueDate time.Time, priority int) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if title == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "title", Reason: "is required"})
+	}
+	if description == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "description", Reason: "is required"})
+	}
+	if dueDate.IsZero() {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "due_date", Reason: "is required"})
+	}
+	if !entity.ValidPriorities[priority] {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "priority", Reason: "must be between 1 and 5"})
+	}
+	return fieldErrors
+}
+
+func (th *taskHandler) convertCreateTaskReqeuestToParams(req CreateTaskRequest) *usecase.CreateTaskParams {
+	return &usecase.CreateTaskParams{
+		Title:       req.Title,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+		Priority:    req.Priority,
+		Tags:        req.Tags,
+	}
+}
+
+type UpdateTaskRequest struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	Priority    int       `json:"priority"`
+	// Tags are kept when omitted and removed when empty.
+	Tags *[]string `json:"tags"`
+}
+
+func (th *taskHandler) UpdateTask(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody UpdateTaskRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := th.validateUpdateTaskRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	params := th.convertUpdateTaskReqeuestToParams(requestBody)
+	if err := th.tuc.UpdateTask(ctx, params); err != nil {
+		log.Error("Failed to update task", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return StatusResponse(http.StatusOK)
+}
+
+func (th *taskHandler) validateUpdateTaskRequest(requestBody *UpdateTaskRequest) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if requestBody.ID == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "id", Reason: "is required"})
+	}
+	fieldErrors = append(
+		fieldErrors,
+		validateTaskFields(requestBody.Title, requestBody.Description, requestBody.DueDate, requestBody.Priority)...,
+	)
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid request body: %v", requestBody)
+	}
+	return fieldErrors
+}
+
+func (th *taskHandler) convertUpdateTaskReqeuestToParams(req UpdateTaskRequest) *usecase.UpdateTaskParams {
+	return &usecase.UpdateTaskParams{
+		ID:          req.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+		Priority:    req.Priority,
+		Tags:        req.Tags,
+	}
+}
+
+type UpdateTaskStatusRequest struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (th *taskHandler) UpdateTaskStatus(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody UpdateTaskStatusRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := th.validateUpdateTaskStatusRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	params := th.convertUpdateTaskStatusReqeuestToParams(requestBody)
+	if err := th.tuc.UpdateTaskStatus(ctx, params); err != nil {
+		log.Error("Failed to update task status", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return StatusResponse(http.StatusOK)
+}
+
+func (th *taskHandler) validateUpdateTaskStatusRequest(requestBody *UpdateTaskStatusRequest) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if requestBody.ID == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "id", Reason: "is required"})
+	}
+	if !entity.ValidStatuses[requestBody.Status] {
+		fieldErrors = append(fieldErrors, problem.FieldError{
+			Field: "status",
+			Reason: fmt.Sprintf(
+				"must be one of %s, %s, %s, %s, %s",
+				entity.StatusTodo, entity.StatusInProgress, entity.StatusBlocked, entity.StatusDone, entity.StatusArchived,
+			),
+		})
+	}
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid request body: %v", requestBody)
+	}
+	return fieldErrors
+}
+
+func (th *taskHandler) convertUpdateTaskStatusReqeuestToParams(req UpdateTaskStatusRequest) *usecase.UpdateTaskStatusParams {
+	return &usecase.UpdateTaskStatusParams{
+		ID:     req.ID,
+		Status: req.Status,
+	}
+}
+
+func (th *taskHandler) DeleteTask(r *http.Request) *Response {
+	ctx := r.Context()
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Warn("ID is required")
+		return ProblemResponse(problem.Validation(r.URL.Path, []problem.FieldError{{Field: "id", Reason: "is required"}}))
+	}
+
+	if err := th.tuc.DeleteTask(ctx, id); err != nil {
+		log.Error("Failed to delete task", log.Ferror(err))
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return StatusResponse(http.StatusOK)
+}
+
+func (th *taskHandler) WatchTasks(r *http.Request) (<-chan entity.TaskEvent, *Response) {
+	events, err := th.tuc.WatchTasks(r.Context())
+	if err != nil {
+		log.Error("Failed to watch tasks", log.Ferror(err))
+		return nil, ErrorResponse(err, r.URL.Path)
+	}
+	return events, nil
+}
@@ -0,0 +1,254 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type UserHandler interface {
+	GetUser(r *http.Request) *Response
+	CreateUser(r *http.Request) *Response
+	Login(r *http.Request) *Response
+	RefreshToken(r *http.Request) *Response
+	Logout(r *http.Request) *Response
+	LogoutEverywhere(r *http.Request) *Response
+	UpdateUser(r *http.Request) *Response
+}
+
+type userHandler struct {
+	uuc usecase.UserUseCase
+}
+
+func NewUserHandler(uuc usecase.UserUseCase) UserHandler {
+	return &userHandler{
+		uuc: uuc,
+	}
+}
+
+type GetUserResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	DueSoonHours int    `json:"due_soon_hours"`
+}
+
+func (uh *userHandler) GetUser(r *http.Request) *Response {
+	ctx := r.Context()
+
+	user, err := uh.uuc.GetUser(ctx)
+	if err != nil {
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return JSONResponse(http.StatusOK, GetUserResponse{
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		DueSoonHours: user.DueSoonHours,
+	})
+}
+
+type CreateUserRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (uh *userHandler) CreateUser(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody CreateUserRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := uh.validateCreateUserRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	tokens, err := uh.uuc.CreateUserAndToken(ctx, requestBody.Email, requestBody.Password)
+	if err != nil {
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return authTokensResponse(tokens)
+}
+
+func (uh *userHandler) validateCreateUserRequest(requestBody *CreateUserRequest) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if requestBody.Email == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "email", Reason: "is required"})
+	}
+	if requestBody.Password == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "password", Reason: "is required"})
+	}
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid request body: %v", requestBody)
+	}
+	return fieldErrors
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (uh *userHandler) Login(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody LoginRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := uh.validateLoginRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	tokens, err := uh.uuc.Login(ctx, requestBody.Email, requestBody.Password)
+	if err != nil {
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return authTokensResponse(tokens)
+}
+
+func (uh *userHandler) validateLoginRequest(requestBody *LoginRequest) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if requestBody.Email == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "email", Reason: "is required"})
+	}
+	if requestBody.Password == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "password", Reason: "is required"})
+	}
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid login request", log.Fstring("email", requestBody.Email))
+	}
+	return fieldErrors
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (uh *userHandler) RefreshToken(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody RefreshTokenRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := uh.validateRefreshTokenRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	tokens, err := uh.uuc.RefreshToken(ctx, requestBody.RefreshToken)
+	if err != nil {
+		return ErrorResponse(err, r.URL.Path)
+	}
+
+	return authTokensResponse(tokens)
+}
+
+func (uh *userHandler) validateRefreshTokenRequest(requestBody *RefreshTokenRequest) []problem.FieldError {
+	if requestBody.RefreshToken == "" {
+		log.Warn("Invalid request body: missing refresh token")
+		return []problem.FieldError{{Field: "refresh_token", Reason: "is required"}}
+	}
+	return nil
+}
+
+type LogoutRequest struct {
+	// RefreshToken is optional; when given, its family is revoked as well.
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (uh *userHandler) Logout(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody LogoutRequest
+	if err := decodeJSON(r, &requestBody); err != nil && !errors.Is(err, io.EOF) {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+
+	if err := uh.uuc.Logout(ctx, requestBody.RefreshToken); err != nil {
+		return ErrorResponse(err, r.URL.Path)
+	}
+	return StatusResponse(http.StatusOK)
+}
+
+func (uh *userHandler) LogoutEverywhere(r *http.Request) *Response {
+	ctx := r.Context()
+
+	if err := uh.uuc.LogoutEverywhere(ctx); err != nil {
+		return ErrorResponse(err, r.URL.Path)
+	}
+	return StatusResponse(http.StatusOK)
+}
+
+type AuthTokensResponse struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// authTokensResponse sends the access token in the Authorization header and the refresh token in the body.
+func authTokensResponse(tokens *usecase.AuthTokens) *Response {
+	res := JSONResponse(http.StatusOK, AuthTokensResponse{
+		RefreshToken: tokens.RefreshToken,
+	})
+	res.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	return res
+}
+
+type UpdateUserRequest struct {
+	Name         string `json:"name"`
+	DueSoonHours int    `json:"due_soon_hours"`
+	// Email 	string `json:"email"`
+	// Password 	string `json:"password"`
+}
+
+func (uh *userHandler) UpdateUser(r *http.Request) *Response {
+	ctx := r.Context()
+
+	var requestBody UpdateUserRequest
+	if err := decodeJSON(r, &requestBody); err != nil {
+		log.Error("Failed to decode request body", log.Ferror(err))
+		return ProblemResponse(problem.MalformedBody(r.URL.Path))
+	}
+	if fieldErrors := uh.validateUpdateUserRequest(&requestBody); len(fieldErrors) != 0 {
+		return ProblemResponse(problem.Validation(r.URL.Path, fieldErrors))
+	}
+
+	if err := uh.uuc.UpdateUser(ctx, &usecase.UpdateUserParams{
+		Name:         requestBody.Name,
+		DueSoonHours: requestBody.DueSoonHours,
+	}); err != nil {
+		return ErrorResponse(err, r.URL.Path)
+	}
+	return StatusResponse(http.StatusOK)
+}
+
+func (uh *userHandler) validateUpdateUserRequest(requestBody *UpdateUserRequest) []problem.FieldError {
+	var fieldErrors []problem.FieldError
+	if requestBody.Name == "" {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: "name", Reason: "is required"})
+	}
+	if requestBody.DueSoonHours < 0 || requestBody.DueSoonHours > entity.MaxDueSoonHours {
+		fieldErrors = append(fieldErrors, problem.FieldError{
+			Field:  "due_soon_hours",
+			Reason: fmt.Sprintf("must be between 1 and %d", entity.MaxDueSoonHours),
+		})
+	}
+	if len(fieldErrors) != 0 {
+		log.Warn("Invalid request body: %v", requestBody)
+	}
+	return fieldErrors
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type JWKSHandler interface {
+	GetJWKS(c echo.Context) error
+}
+
+type jwksHandler struct {
+	core core.JWKSHandler
+}
+
+func NewJWKSHandler(juc usecase.JWKSUseCase) JWKSHandler {
+	return &jwksHandler{
+		core: core.NewJWKSHandler(juc),
+	}
+}
+
+func (jh *jwksHandler) GetJWKS(c echo.Context) error {
+	return writeResponse(c, jh.core.GetJWKS(c.Request()))
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+)
+
+// writeResponse sends the response built by the core handlers.
+func writeResponse(c echo.Context, res *core.Response) error {
+	for key, values := range res.Header {
+		c.Response().Header()[key] = values
+	}
+	if res.Body == nil {
+		return c.NoContent(res.Status)
+	}
+	return c.Blob(res.Status, res.ContentType, res.Body)
+}
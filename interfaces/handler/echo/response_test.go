@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_writeResponse_problem(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name string
+		err  error
+		want problem.Problem
+	}{
+		{
+			name: "field validation",
+			err:  entity.NewFieldValidationError("title", "title is required"),
+			want: problem.Problem{
+				Type:     problem.DefaultType,
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "title is required",
+				Instance: "/api/task/create",
+				Errors:   []problem.FieldError{{Field: "title", Reason: "title is required"}},
+			},
+		},
+		{
+			name: "not found",
+			err:  repository.ErrTaskNotFound,
+			want: problem.Problem{
+				Type:     problem.DefaultType,
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "task not found",
+				Instance: "/api/task/create",
+			},
+		},
+		{
+			name: "internal",
+			err:  errors.New("connection refused"),
+			want: problem.Problem{
+				Type:     problem.DefaultType,
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "An unexpected error occurred.",
+				Instance: "/api/task/create",
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/task/create", nil)
+			recorder := httptest.NewRecorder()
+
+			c := echo.New().NewContext(req, recorder)
+			if err := writeResponse(c, core.ErrorResponse(tt.err, req.URL.Path)); err != nil {
+				t.Fatalf("writeResponse() error = %v", err)
+			}
+
+			if status := recorder.Code; status != tt.want.Status {
+				t.Fatalf("writeResponse() status = %v, want %v", status, tt.want.Status)
+			}
+			if ct := recorder.Header().Get("Content-Type"); ct != problem.ContentType {
+				t.Errorf("writeResponse() Content-Type = %q, want %q", ct, problem.ContentType)
+			}
+			var got problem.Problem
+			if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode problem: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("writeResponse() body = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
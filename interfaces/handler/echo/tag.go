@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type TagHandler interface {
+	ListTags(c echo.Context) error
+	CreateTag(c echo.Context) error
+	UpdateTag(c echo.Context) error
+	DeleteTag(c echo.Context) error
+}
+
+type tagHandler struct {
+	core core.TagHandler
+}
+
+func NewTagHandler(tguc usecase.TagUseCase) TagHandler {
+	return &tagHandler{
+		core: core.NewTagHandler(tguc),
+	}
+}
+
+type (
+	GetTagResponse   = core.GetTagResponse
+	ListTagsResponse = core.ListTagsResponse
+	CreateTagRequest = core.CreateTagRequest
+	UpdateTagRequest = core.UpdateTagRequest
+)
+
+func (tgh *tagHandler) ListTags(c echo.Context) error {
+	return writeResponse(c, tgh.core.ListTags(c.Request()))
+}
+
+func (tgh *tagHandler) CreateTag(c echo.Context) error {
+	return writeResponse(c, tgh.core.CreateTag(c.Request()))
+}
+
+func (tgh *tagHandler) UpdateTag(c echo.Context) error {
+	return writeResponse(c, tgh.core.UpdateTag(c.Request()))
+}
+
+func (tgh *tagHandler) DeleteTag(c echo.Context) error {
+	return writeResponse(c, tgh.core.DeleteTag(c.Request()))
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+	"github.com/tusmasoma/go-clean-arch/usecase/mock"
+)
+
+// TestHandler_TagRoutes checks that the handlers pass requests to the core and write its responses.
+// The behavior of each endpoint is tested in the core package.
+func TestHandler_TagRoutes(t *testing.T) {
+	t.Parallel()
+
+	tag := entity.Tag{ID: uuid.New().String(), Name: "backend", CreatedAt: time.Now()}
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTagUseCase,
+		)
+		in              func() *http.Request
+		wantStatus      int
+		wantContentType string
+	}{
+		{
+			name: "list",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().ListTags(gomock.Any()).Return([]entity.Tag{tag}, nil)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/tag/list", nil)
+				return req
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: core.JSONContentType,
+		},
+		{
+			name: "create",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().CreateTag(gomock.Any(), &usecase.CreateTagParams{Name: tag.Name}).Return(&tag, nil)
+			},
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(CreateTagRequest{Name: tag.Name})
+				req, _ := http.NewRequest(http.MethodPost, "/api/tag/create", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: core.JSONContentType,
+		},
+		{
+			name: "update",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().UpdateTag(gomock.Any(), &usecase.UpdateTagParams{ID: tag.ID, Name: "server"}).Return(nil)
+			},
+			in: func() *http.Request {
+				reqBody, _ := json.Marshal(UpdateTagRequest{ID: tag.ID, Name: "server"})
+				req, _ := http.NewRequest(http.MethodPut, "/api/tag/update", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "delete",
+			setup: func(tguc *mock.MockTagUseCase) {
+				tguc.EXPECT().DeleteTag(gomock.Any(), tag.ID).Return(nil)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/tag/delete?id=%s", tag.ID), nil)
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tguc := mock.NewMockTagUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tguc)
+			}
+
+			handler := NewTagHandler(tguc)
+			router := echo.New()
+			router.GET("/api/tag/list", handler.ListTags)
+			router.POST("/api/tag/create", handler.CreateTag)
+			router.PUT("/api/tag/update", handler.UpdateTag)
+			router.DELETE("/api/tag/delete", handler.DeleteTag)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, tt.in())
+
+			if status := recorder.Code; status != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, tt.wantStatus)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != tt.wantContentType {
+				t.Errorf("handler returned wrong Content-Type: got %q want %q", contentType, tt.wantContentType)
+			}
+		})
+	}
+}
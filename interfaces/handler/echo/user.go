@@ -1,114 +1,66 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/labstack/echo/v4"
-	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
 	"github.com/tusmasoma/go-clean-arch/usecase"
 )
 
 type UserHandler interface {
 	GetUser(c echo.Context) error
 	CreateUser(c echo.Context) error
+	Login(c echo.Context) error
+	RefreshToken(c echo.Context) error
+	Logout(c echo.Context) error
+	LogoutEverywhere(c echo.Context) error
 	UpdateUser(c echo.Context) error
 }
 
 type userHandler struct {
-	uuc usecase.UserUseCase
+	core core.UserHandler
 }
 
 func NewUserHandler(uuc usecase.UserUseCase) UserHandler {
 	return &userHandler{
-		uuc: uuc,
+		core: core.NewUserHandler(uuc),
 	}
 }
 
-type GetUserResponse struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-}
+type (
+	GetUserResponse     = core.GetUserResponse
+	CreateUserRequest   = core.CreateUserRequest
+	LoginRequest        = core.LoginRequest
+	RefreshTokenRequest = core.RefreshTokenRequest
+	LogoutRequest       = core.LogoutRequest
+	AuthTokensResponse  = core.AuthTokensResponse
+	UpdateUserRequest   = core.UpdateUserRequest
+)
 
 func (uh *userHandler) GetUser(c echo.Context) error {
-	ctx := c.Request().Context()
-
-	user, err := uh.uuc.GetUser(ctx)
-	if err != nil {
-		return c.NoContent(http.StatusInternalServerError)
-	}
-
-	response := GetUserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-	}
-	return c.JSON(http.StatusOK, response)
-}
-
-type CreateUserRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	return writeResponse(c, uh.core.GetUser(c.Request()))
 }
 
 func (uh *userHandler) CreateUser(c echo.Context) error {
-	ctx := c.Request().Context()
-
-	var requestBody CreateUserRequest
-	if err := c.Bind(&requestBody); err != nil {
-		log.Error("Failed to decode request body", log.Ferror(err))
-		return c.NoContent(http.StatusBadRequest)
-	}
-	if !uh.isValidCreateUserRequest(&requestBody) {
-		return c.NoContent(http.StatusBadRequest)
-	}
-
-	token, err := uh.uuc.CreateUserAndToken(ctx, requestBody.Email, requestBody.Password)
-	if err != nil {
-		return c.NoContent(http.StatusInternalServerError)
-	}
-
-	c.Response().Header().Set("Authorization", "Bearer "+token)
-	return c.NoContent(http.StatusOK)
+	return writeResponse(c, uh.core.CreateUser(c.Request()))
 }
 
-func (uh *userHandler) isValidCreateUserRequest(requestBody *CreateUserRequest) bool {
-	if requestBody.Email == "" || requestBody.Password == "" {
-		log.Warn("Invalid request body: %v", requestBody)
-		return false
-	}
-	return true
+func (uh *userHandler) Login(c echo.Context) error {
+	return writeResponse(c, uh.core.Login(c.Request()))
 }
 
-type UpdateUserRequest struct {
-	Name string `json:"name"`
-	// Email 	string `json:"email"`
-	// Password 	string `json:"password"`
+func (uh *userHandler) RefreshToken(c echo.Context) error {
+	return writeResponse(c, uh.core.RefreshToken(c.Request()))
 }
 
-func (uh *userHandler) UpdateUser(c echo.Context) error {
-	ctx := c.Request().Context()
-
-	var requestBody UpdateUserRequest
-	if err := c.Bind(&requestBody); err != nil {
-		log.Error("Failed to decode request body", log.Ferror(err))
-		return c.NoContent(http.StatusBadRequest)
-	}
-	if !uh.isValidUpdateUserRequest(&requestBody) {
-		return c.NoContent(http.StatusBadRequest)
-	}
+func (uh *userHandler) Logout(c echo.Context) error {
+	return writeResponse(c, uh.core.Logout(c.Request()))
+}
 
-	if err := uh.uuc.UpdateUser(ctx, requestBody.Name); err != nil {
-		return c.NoContent(http.StatusInternalServerError)
-	}
-	return c.NoContent(http.StatusOK)
+func (uh *userHandler) LogoutEverywhere(c echo.Context) error {
+	return writeResponse(c, uh.core.LogoutEverywhere(c.Request()))
 }
 
-func (uh *userHandler) isValidUpdateUserRequest(requestBody *UpdateUserRequest) bool {
-	if requestBody.Name == "" {
-		log.Warn("Invalid request body: %v", requestBody)
-		return false
-	}
-	return true
+func (uh *userHandler) UpdateUser(c echo.Context) error {
+	return writeResponse(c, uh.core.UpdateUser(c.Request()))
 }
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type JWKSHandler interface {
+	GetJWKS(c *gin.Context)
+}
+
+type jwksHandler struct {
+	core core.JWKSHandler
+}
+
+func NewJWKSHandler(juc usecase.JWKSUseCase) JWKSHandler {
+	return &jwksHandler{
+		core: core.NewJWKSHandler(juc),
+	}
+}
+
+func (jh *jwksHandler) GetJWKS(c *gin.Context) {
+	writeResponse(c, jh.core.GetJWKS(c.Request))
+}
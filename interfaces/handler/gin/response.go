@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+)
+
+// writeResponse sends the response built by the core handlers.
+func writeResponse(c *gin.Context, res *core.Response) {
+	for key, values := range res.Header {
+		c.Writer.Header()[key] = values
+	}
+	if res.Body == nil {
+		c.Status(res.Status)
+		return
+	}
+	c.Data(res.Status, res.ContentType, res.Body)
+}
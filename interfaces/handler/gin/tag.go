@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type TagHandler interface {
+	ListTags(c *gin.Context)
+	CreateTag(c *gin.Context)
+	UpdateTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
+}
+
+type tagHandler struct {
+	core core.TagHandler
+}
+
+func NewTagHandler(tguc usecase.TagUseCase) TagHandler {
+	return &tagHandler{
+		core: core.NewTagHandler(tguc),
+	}
+}
+
+type (
+	GetTagResponse   = core.GetTagResponse
+	ListTagsResponse = core.ListTagsResponse
+	CreateTagRequest = core.CreateTagRequest
+	UpdateTagRequest = core.UpdateTagRequest
+)
+
+func (tgh *tagHandler) ListTags(c *gin.Context) {
+	writeResponse(c, tgh.core.ListTags(c.Request))
+}
+
+func (tgh *tagHandler) CreateTag(c *gin.Context) {
+	writeResponse(c, tgh.core.CreateTag(c.Request))
+}
+
+func (tgh *tagHandler) UpdateTag(c *gin.Context) {
+	writeResponse(c, tgh.core.UpdateTag(c.Request))
+}
+
+func (tgh *tagHandler) DeleteTag(c *gin.Context) {
+	writeResponse(c, tgh.core.DeleteTag(c.Request))
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
 	"github.com/tusmasoma/go-clean-arch/usecase"
 	"github.com/tusmasoma/go-clean-arch/usecase/mock"
 )
@@ -64,6 +67,48 @@ func TestHandler_GetTask(t *testing.T) {
 			},
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			name: "Fail: task not found",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().GetTask(
+					gomock.Any(),
+					taskID,
+				).Return(nil, repository.ErrTaskNotFound)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/task/get?id=%s", taskID), nil)
+				return req
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Fail: task of another user",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().GetTask(
+					gomock.Any(),
+					taskID,
+				).Return(nil, usecase.ErrTaskNotOwned)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/task/get?id=%s", taskID), nil)
+				return req
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "Fail: internal error",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().GetTask(
+					gomock.Any(),
+					taskID,
+				).Return(nil, errors.New("connection refused"))
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/task/get?id=%s", taskID), nil)
+				return req
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
 	}
 
 	for _, tt := range patterns {
@@ -130,7 +175,8 @@ func TestHandler_ListTasks(t *testing.T) {
 			setup: func(tuc *mock.MockTaskUseCase) {
 				tuc.EXPECT().ListTasks(
 					gomock.Any(),
-				).Return(tasks, nil)
+					gomock.Any(),
+				).Return(tasks, "", nil)
 			},
 			in: func() *http.Request {
 				req, _ := http.NewRequest(http.MethodGet, "/api/task/list", nil)
@@ -138,6 +184,90 @@ func TestHandler_ListTasks(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "success with query",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().ListTasks(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.ListTasksParams) {
+					if params.MinPriority != 2 || params.MaxPriority != 4 {
+						t.Errorf("unexpected priority range: got %v-%v, want %v-%v", params.MinPriority, params.MaxPriority, 2, 4)
+					}
+					if params.Overdue == nil || !*params.Overdue {
+						t.Errorf("unexpected Overdue: got %v, want %v", params.Overdue, true)
+					}
+					if params.SortBy != "due_date" || !params.SortDesc {
+						t.Errorf("unexpected sort: got %v desc=%v, want %v desc=%v", params.SortBy, params.SortDesc, "due_date", true)
+					}
+					if params.Limit != 10 || params.Cursor != "next" {
+						t.Errorf("unexpected page: got %v %v, want %v %v", params.Limit, params.Cursor, 10, "next")
+					}
+				}).Return(tasks, "cursor", nil)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(
+					http.MethodGet,
+					"/api/task/list?min_priority=2&max_priority=4&overdue=true&sort_by=due_date&order=desc&limit=10&cursor=next",
+					nil,
+				)
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success with tags",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().ListTasks(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.ListTasksParams) {
+					if len(params.Tags) != 2 || params.Tags[0] != "backend" || params.Tags[1] != "urgent" {
+						t.Errorf("unexpected Tags: got %v, want %v", params.Tags, []string{"backend", "urgent"})
+					}
+					if !params.MatchAllTags {
+						t.Errorf("unexpected MatchAllTags: got %v, want %v", params.MatchAllTags, true)
+					}
+				}).Return(tasks, "", nil)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/task/list?tags=backend,urgent&tag_match=all", nil)
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Fail: invalid request of tag_match",
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/task/list?tags=backend&tag_match=some", nil)
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: invalid request of sort_by",
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/task/list?sort_by=title", nil)
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: invalid request of priority range",
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/task/list?min_priority=4&max_priority=2", nil)
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: invalid request of due_after",
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/api/task/list?due_after=tomorrow", nil)
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range patterns {
@@ -505,6 +635,123 @@ func TestHandler_UpdateTask(t *testing.T) {
 	}
 }
 
+func TestHandler_UpdateTaskStatus(t *testing.T) {
+	t.Parallel()
+
+	taskID := uuid.New().String()
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTaskUseCase,
+		)
+		in         func() *http.Request
+		wantStatus int
+	}{
+		{
+			name: "success",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().UpdateTaskStatus(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.UpdateTaskStatusParams) {
+					if params.ID != taskID {
+						t.Errorf("unexpected ID: got %v, want %v", params.ID, taskID)
+					}
+					if params.Status != entity.StatusDone {
+						t.Errorf("unexpected Status: got %v, want %v", params.Status, entity.StatusDone)
+					}
+				}).Return(nil)
+			},
+			in: func() *http.Request {
+				taskUpdateStatusReq := UpdateTaskStatusRequest{
+					ID:     taskID,
+					Status: entity.StatusDone,
+				}
+				reqBody, _ := json.Marshal(taskUpdateStatusReq)
+				req, _ := http.NewRequest(http.MethodPut, "/api/task/update_status", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Fail: transition not allowed",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().UpdateTaskStatus(
+					gomock.Any(),
+					gomock.Any(),
+				).Return(fmt.Errorf("cannot change status from %s to %s", entity.StatusDone, entity.StatusBlocked))
+			},
+			in: func() *http.Request {
+				taskUpdateStatusReq := UpdateTaskStatusRequest{
+					ID:     taskID,
+					Status: entity.StatusBlocked,
+				}
+				reqBody, _ := json.Marshal(taskUpdateStatusReq)
+				req, _ := http.NewRequest(http.MethodPut, "/api/task/update_status", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "Fail: invalid request of id is empty",
+			in: func() *http.Request {
+				taskUpdateStatusReq := UpdateTaskStatusRequest{
+					ID:     "",
+					Status: entity.StatusDone,
+				}
+				reqBody, _ := json.Marshal(taskUpdateStatusReq)
+				req, _ := http.NewRequest(http.MethodPut, "/api/task/update_status", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fail: invalid request of unknown status",
+			in: func() *http.Request {
+				taskUpdateStatusReq := UpdateTaskStatusRequest{
+					ID:     taskID,
+					Status: "unknown",
+				}
+				reqBody, _ := json.Marshal(taskUpdateStatusReq)
+				req, _ := http.NewRequest(http.MethodPut, "/api/task/update_status", bytes.NewBuffer(reqBody))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tuc := mock.NewMockTaskUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tuc)
+			}
+
+			handler := NewTaskHandler(tuc)
+			recorder := httptest.NewRecorder()
+
+			router := gin.Default()
+			router.PUT("/api/task/update_status", handler.UpdateTaskStatus)
+
+			router.ServeHTTP(recorder, tt.in())
+
+			if status := recorder.Code; status != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
 func TestHandler_DeleteTask(t *testing.T) {
 	t.Parallel()
 
@@ -568,3 +815,75 @@ func TestHandler_DeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_WatchTasks(t *testing.T) {
+	t.Parallel()
+
+	event := entity.NewTaskEvent(entity.TaskEventCreated, entity.Task{
+		ID:          uuid.New().String(),
+		Title:       "title",
+		Description: "description",
+		DueDate:     time.Now().AddDate(0, 0, 1),
+		Priority:    3,
+		Status:      entity.StatusTodo,
+		CreatedAt:   time.Now(),
+	}, time.Now())
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTaskUseCase,
+		)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				events := make(chan entity.TaskEvent, 1)
+				events <- event
+				close(events)
+				tuc.EXPECT().WatchTasks(gomock.Any()).Return((<-chan entity.TaskEvent)(events), nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "event: created\ndata: {\"type\":\"created\",\"task\":{\"id\":\"" + event.Task.ID + "\"",
+		},
+		{
+			name: "Fail: user not in context",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().WatchTasks(gomock.Any()).Return(nil, usecase.ErrUserNotInContext)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tuc := mock.NewMockTaskUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tuc)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/task/watch", nil)
+			handler := NewTaskHandler(tuc)
+			recorder := httptest.NewRecorder()
+
+			router := gin.Default()
+			router.GET("/api/task/watch", handler.WatchTasks)
+
+			router.ServeHTTP(recorder, req)
+
+			if status := recorder.Code; status != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, tt.wantStatus)
+			}
+			if !strings.Contains(recorder.Body.String(), tt.wantBody) {
+				t.Fatalf("handler returned unexpected body: got %v want %v", recorder.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
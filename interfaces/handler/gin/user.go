@@ -1,121 +1,66 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
-	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
 	"github.com/tusmasoma/go-clean-arch/usecase"
 )
 
 type UserHandler interface {
 	GetUser(c *gin.Context)
 	CreateUser(c *gin.Context)
+	Login(c *gin.Context)
+	RefreshToken(c *gin.Context)
+	Logout(c *gin.Context)
+	LogoutEverywhere(c *gin.Context)
 	UpdateUser(c *gin.Context)
 }
 
 type userHandler struct {
-	uuc usecase.UserUseCase
+	core core.UserHandler
 }
 
 func NewUserHandler(uuc usecase.UserUseCase) UserHandler {
 	return &userHandler{
-		uuc: uuc,
+		core: core.NewUserHandler(uuc),
 	}
 }
 
-type GetUserResponse struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-}
+type (
+	GetUserResponse     = core.GetUserResponse
+	CreateUserRequest   = core.CreateUserRequest
+	LoginRequest        = core.LoginRequest
+	RefreshTokenRequest = core.RefreshTokenRequest
+	LogoutRequest       = core.LogoutRequest
+	AuthTokensResponse  = core.AuthTokensResponse
+	UpdateUserRequest   = core.UpdateUserRequest
+)
 
 func (uh *userHandler) GetUser(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	user, err := uh.uuc.GetUser(ctx)
-	if err != nil {
-		c.Status(http.StatusInternalServerError)
-		return
-	}
-
-	response := GetUserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-	}
-	c.JSON(http.StatusOK, response)
-}
-
-type CreateUserRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	writeResponse(c, uh.core.GetUser(c.Request))
 }
 
 func (uh *userHandler) CreateUser(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	var requestBody CreateUserRequest
-	if err := c.ShouldBindJSON(&requestBody); err != nil {
-		log.Error("Failed to decode request body", log.Ferror(err))
-		c.Status(http.StatusBadRequest)
-		return
-	}
-	if !uh.isValidCreateUserRequest(&requestBody) {
-		c.Status(http.StatusBadRequest)
-		return
-	}
-
-	token, err := uh.uuc.CreateUserAndToken(ctx, requestBody.Email, requestBody.Password)
-	if err != nil {
-		c.Status(http.StatusInternalServerError)
-		return
-	}
-
-	c.Header("Authorization", "Bearer "+token)
-	c.Status(http.StatusOK)
+	writeResponse(c, uh.core.CreateUser(c.Request))
 }
 
-func (uh *userHandler) isValidCreateUserRequest(requestBody *CreateUserRequest) bool {
-	if requestBody.Email == "" || requestBody.Password == "" {
-		log.Warn("Invalid request body: %v", requestBody)
-		return false
-	}
-	return true
+func (uh *userHandler) Login(c *gin.Context) {
+	writeResponse(c, uh.core.Login(c.Request))
 }
 
-type UpdateUserRequest struct {
-	Name string `json:"name"`
-	// Email 	string `json:"email"`
-	// Password 	string `json:"password"`
+func (uh *userHandler) RefreshToken(c *gin.Context) {
+	writeResponse(c, uh.core.RefreshToken(c.Request))
 }
 
-func (uh *userHandler) UpdateUser(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	var requestBody UpdateUserRequest
-	if err := c.ShouldBindJSON(&requestBody); err != nil {
-		log.Error("Failed to decode request body", log.Ferror(err))
-		c.Status(http.StatusBadRequest)
-		return
-	}
-	if !uh.isValidUpdateUserRequest(&requestBody) {
-		c.Status(http.StatusBadRequest)
-		return
-	}
+func (uh *userHandler) Logout(c *gin.Context) {
+	writeResponse(c, uh.core.Logout(c.Request))
+}
 
-	if err := uh.uuc.UpdateUser(ctx, requestBody.Name); err != nil {
-		c.Status(http.StatusInternalServerError)
-		return
-	}
-	c.Status(http.StatusOK)
+func (uh *userHandler) LogoutEverywhere(c *gin.Context) {
+	writeResponse(c, uh.core.LogoutEverywhere(c.Request))
 }
 
-func (uh *userHandler) isValidUpdateUserRequest(requestBody *UpdateUserRequest) bool {
-	if requestBody.Name == "" {
-		log.Warn("Invalid request body: %v", requestBody)
-		return false
-	}
-	return true
+func (uh *userHandler) UpdateUser(c *gin.Context) {
+	writeResponse(c, uh.core.UpdateUser(c.Request))
 }
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+const invalidRequestMessage = "Invalid request"
+
+// statusError translates the kind of a domain error into a gRPC status.
+// Domain errors carry their own message; errors of no known kind are internal
+// and only carry msg, so that no details of the failure leak to the client.
+// A validation error tied to a field is reported as a google.rpc.BadRequest detail.
+func statusError(err error, msg string) error {
+	code := statusCode(err)
+	if code == codes.Internal {
+		return status.Error(code, msg)
+	}
+	var domainErr *entity.Error
+	if code == codes.InvalidArgument && errors.As(err, &domainErr) && domainErr.Field != "" {
+		return withBadRequest(
+			status.New(code, err.Error()),
+			[]*errdetails.BadRequest_FieldViolation{fieldViolation(domainErr.Field, domainErr.Message)},
+		)
+	}
+	return status.Error(code, err.Error())
+}
+
+// statusCode maps conflicts to Aborted, which grpc-gateway translates to 409 like the HTTP handlers.
+func statusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, entity.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, entity.ErrUnauthenticated):
+		return codes.Unauthenticated
+	case errors.Is(err, entity.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, entity.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, entity.ErrConflict):
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
+
+// invalidArgument reports a request that failed validation, listing every offending field.
+func invalidArgument(violations []*errdetails.BadRequest_FieldViolation) error {
+	return withBadRequest(status.New(codes.InvalidArgument, invalidRequestMessage), violations)
+}
+
+func withBadRequest(st *status.Status, violations []*errdetails.BadRequest_FieldViolation) error {
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func fieldViolation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+func Test_statusCode(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "validation", err: entity.NewValidationError("title is required"), want: codes.InvalidArgument},
+		{name: "unauthenticated", err: usecase.ErrInvalidCredentials, want: codes.Unauthenticated},
+		{name: "forbidden", err: usecase.ErrTaskNotOwned, want: codes.PermissionDenied},
+		{name: "not found", err: repository.ErrTaskNotFound, want: codes.NotFound},
+		{name: "wrapped not found", err: fmt.Errorf("failed to get task: %w", repository.ErrTaskNotFound), want: codes.NotFound},
+		{name: "conflict", err: usecase.ErrUserAlreadyExists, want: codes.Aborted},
+		{name: "internal", err: errors.New("connection refused"), want: codes.Internal},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := statusCode(tt.err); got != tt.want {
+				t.Errorf("statusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusError(t *testing.T) {
+	t.Parallel()
+
+	err := statusError(repository.ErrTaskNotFound, "Failed to get task")
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound || st.Message() != "task not found" {
+		t.Errorf("statusError() = %v, want NotFound with the domain message", err)
+	}
+
+	err = statusError(errors.New("dial tcp 10.0.0.1:3306: connection refused"), "Failed to get task")
+	if st, _ := status.FromError(err); st.Code() != codes.Internal || st.Message() != "Failed to get task" {
+		t.Errorf("statusError() = %v, want Internal without the details of the failure", err)
+	}
+}
+
+func Test_statusError_fieldViolation(t *testing.T) {
+	t.Parallel()
+
+	err := statusError(entity.NewFieldValidationError("priority", "priority must be between 1 and 5"), "Failed to create task")
+	st, _ := status.FromError(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("statusError() code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+	want := []*errdetails.BadRequest_FieldViolation{fieldViolation("priority", "priority must be between 1 and 5")}
+	if got := badRequestViolations(t, st); !equalViolations(got, want) {
+		t.Errorf("statusError() violations = %v, want %v", got, want)
+	}
+}
+
+func Test_invalidArgument(t *testing.T) {
+	t.Parallel()
+
+	want := []*errdetails.BadRequest_FieldViolation{
+		fieldViolation("title", "is required"),
+		fieldViolation("priority", "must be between 1 and 5"),
+	}
+	st, _ := status.FromError(invalidArgument(want))
+	if st.Code() != codes.InvalidArgument || st.Message() != invalidRequestMessage {
+		t.Fatalf("invalidArgument() = %v, want InvalidArgument with %q", st, invalidRequestMessage)
+	}
+	if got := badRequestViolations(t, st); !equalViolations(got, want) {
+		t.Errorf("invalidArgument() violations = %v, want %v", got, want)
+	}
+}
+
+func badRequestViolations(t *testing.T, st *status.Status) []*errdetails.BadRequest_FieldViolation {
+	t.Helper()
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			return br.GetFieldViolations()
+		}
+	}
+	t.Fatalf("status %v has no BadRequest detail", st)
+	return nil
+}
+
+func equalViolations(got, want []*errdetails.BadRequest_FieldViolation) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !proto.Equal(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
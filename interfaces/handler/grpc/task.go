@@ -2,14 +2,17 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
 	pb "github.com/tusmasoma/go-clean-arch/interfaces/handler/grpc/proto/gateway"
+	"github.com/tusmasoma/go-clean-arch/repository"
 	"github.com/tusmasoma/go-clean-arch/usecase"
 )
 
@@ -18,7 +21,9 @@ type TaskHandler interface {
 	ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error)
 	CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.CreateTaskResponse, error)
 	UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.UpdateTaskResponse, error)
+	UpdateTaskStatus(ctx context.Context, req *pb.UpdateTaskStatusRequest) (*pb.UpdateTaskStatusResponse, error)
 	DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error)
+	WatchTasks(req *pb.WatchTasksRequest, stream pb.TaskService_WatchTasksServer) error
 }
 
 type taskHandler struct {
@@ -36,13 +41,13 @@ func (th *taskHandler) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb
 	id := req.GetId()
 	if id == "" {
 		log.Warn("ID is required")
-		return nil, status.Errorf(codes.InvalidArgument, "ID is required")
+		return nil, invalidArgument([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", "is required")})
 	}
 
 	task, err := th.tuc.GetTask(ctx, id)
 	if err != nil {
 		log.Error("Failed to get task", log.Ferror(err))
-		return nil, status.Errorf(codes.Internal, "Failed to get task")
+		return nil, statusError(err, "Failed to get task")
 	}
 
 	return &pb.GetTaskResponse{
@@ -52,49 +57,113 @@ func (th *taskHandler) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb
 		DueDate:     timestamppb.New(task.DueDate),
 		Priority:    int32(task.Priority),
 		CreatedAt:   timestamppb.New(task.CreatedAt),
+		Status:      task.Status,
+		CompletedAt: toTimestamp(task.CompletedAt),
+		IsOverdue:   task.IsOverdue,
+		IsDueSoon:   task.IsDueSoon,
+		Tags:        tagNames(task.Tags),
 	}, nil
 }
 
-func (th *taskHandler) ListTasks(ctx context.Context, _ *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
-	tasks, err := th.tuc.ListTasks(ctx)
+func (th *taskHandler) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	if violations := th.validateListTasksRequest(req); len(violations) != 0 {
+		return nil, invalidArgument(violations)
+	}
+	tasks, next, err := th.tuc.ListTasks(ctx, th.convertListTasksRequestToParams(req))
 	if err != nil {
 		log.Error("Failed to list tasks", log.Ferror(err))
-		return nil, status.Errorf(codes.Internal, "Failed to list tasks")
+		return nil, statusError(err, "Failed to list tasks")
 	}
 
 	var res []*pb.Task
 	for _, task := range tasks {
-		res = append(res, &pb.Task{
-			Id:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			DueDate:     timestamppb.New(task.DueDate),
-			Priority:    int32(task.Priority),
-			CreatedAt:   timestamppb.New(task.CreatedAt),
-		})
+		res = append(res, toTaskMessage(task))
+	}
+
+	return &pb.ListTasksResponse{Tasks: res, NextPageToken: next}, nil
+}
+
+func (th *taskHandler) validateListTasksRequest(req *pb.ListTasksRequest) []*errdetails.BadRequest_FieldViolation {
+	minPriority, maxPriority := int(req.GetMinPriority()), int(req.GetMaxPriority())
+	dueAfter, dueBefore := fromTimestamp(req.GetDueAfter()), fromTimestamp(req.GetDueBefore())
+	var violations []*errdetails.BadRequest_FieldViolation
+	if minPriority != 0 && !entity.ValidPriorities[minPriority] {
+		violations = append(violations, fieldViolation("min_priority", "must be between 1 and 5"))
+	}
+	if maxPriority != 0 && !entity.ValidPriorities[maxPriority] {
+		violations = append(violations, fieldViolation("max_priority", "must be between 1 and 5"))
+	}
+	if minPriority != 0 && maxPriority != 0 && minPriority > maxPriority {
+		violations = append(violations, fieldViolation("min_priority", "must not be greater than max_priority"))
+	}
+	if !dueAfter.IsZero() && !dueBefore.IsZero() && !dueAfter.Before(dueBefore) {
+		violations = append(violations, fieldViolation("due_after", "must be before due_before"))
+	}
+	for _, name := range req.GetTags() {
+		if strings.TrimSpace(name) == "" {
+			violations = append(violations, fieldViolation("tags", "must not contain an empty name"))
+			break
+		}
 	}
+	if req.GetSortBy() != "" && !repository.ValidTaskSortKeys[req.GetSortBy()] {
+		violations = append(violations, fieldViolation("sort_by", "is not a sortable field"))
+	}
+	if req.GetOrder() != "" && req.GetOrder() != "asc" && req.GetOrder() != "desc" {
+		violations = append(violations, fieldViolation("order", "must be asc or desc"))
+	}
+	if req.GetPageSize() < 0 || req.GetPageSize() > usecase.MaxListTasksLimit {
+		violations = append(violations, fieldViolation(
+			"page_size", fmt.Sprintf("must be between 0 and %d", usecase.MaxListTasksLimit),
+		))
+	}
+	if len(violations) != 0 {
+		log.Warn(
+			"Invalid request",
+			log.Fint("min_priority", minPriority),
+			log.Fint("max_priority", maxPriority),
+			log.Ftime("due_after", dueAfter),
+			log.Ftime("due_before", dueBefore),
+			log.Fstring("sort_by", req.GetSortBy()),
+			log.Fstring("order", req.GetOrder()),
+			log.Fint("page_size", int(req.GetPageSize())),
+		)
+	}
+	return violations
+}
 
-	return &pb.ListTasksResponse{Tasks: res}, nil
+func (th *taskHandler) convertListTasksRequestToParams(req *pb.ListTasksRequest) *usecase.ListTasksParams {
+	return &usecase.ListTasksParams{
+		MinPriority:  int(req.GetMinPriority()),
+		MaxPriority:  int(req.GetMaxPriority()),
+		DueAfter:     fromTimestamp(req.GetDueAfter()),
+		DueBefore:    fromTimestamp(req.GetDueBefore()),
+		Overdue:      req.Overdue,
+		TitlePrefix:  req.GetTitlePrefix(),
+		Tags:         req.GetTags(),
+		MatchAllTags: req.GetMatchAllTags(),
+		SortBy:       req.GetSortBy(),
+		SortDesc:     req.GetOrder() == "desc",
+		Limit:        int(req.GetPageSize()),
+		Cursor:       req.GetPageToken(),
+	}
 }
 
 func (th *taskHandler) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.CreateTaskResponse, error) {
-	if !th.isValidCreateTasksRequest(req) {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request")
+	if violations := th.validateCreateTasksRequest(req); len(violations) != 0 {
+		return nil, invalidArgument(violations)
 	}
 	params := th.convertCreateTaskReqeuestToParams(req)
 	if err := th.tuc.CreateTask(ctx, params); err != nil {
 		log.Error("Failed to create task", log.Ferror(err))
-		return nil, status.Errorf(codes.Internal, "Failed to create task")
+		return nil, statusError(err, "Failed to create task")
 	}
 
 	return &pb.CreateTaskResponse{}, nil
 }
 
-func (th *taskHandler) isValidCreateTasksRequest(req *pb.CreateTaskRequest) bool {
-	if req.GetTitle() == "" ||
-		req.GetDescription() == "" ||
-		req.GetDueDate().AsTime().IsZero() ||
-		!entity.ValidPriorities[int(req.GetPriority())] {
+func (th *taskHandler) validateCreateTasksRequest(req *pb.CreateTaskRequest) []*errdetails.BadRequest_FieldViolation {
+	violations := validateTaskFields(req.GetTitle(), req.GetDescription(), req.GetDueDate().AsTime(), int(req.GetPriority()))
+	if len(violations) != 0 {
 		log.Warn(
 			"Invalid request",
 			log.Fstring("title", req.GetTitle()),
@@ -102,9 +171,26 @@ func (th *taskHandler) isValidCreateTasksRequest(req *pb.CreateTaskRequest) bool
 			log.Ftime("due_date", req.GetDueDate().AsTime()),
 			log.Fint("priority", int(req.GetPriority())),
 		)
-		return false
 	}
-	return true
+	return violations
+}
+
+// validateTaskFields checks the fields that creating and updating a task have in common.
+func validateTaskFields(title, description string, dueDate time.Time, priority int) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if title == "" {
+		violations = append(violations, fieldViolation("title", "is required"))
+	}
+	if description == "" {
+		violations = append(violations, fieldViolation("description", "is required"))
+	}
+	if dueDate.IsZero() {
+		violations = append(violations, fieldViolation("due_date", "is required"))
+	}
+	if !entity.ValidPriorities[priority] {
+		violations = append(violations, fieldViolation("priority", "must be between 1 and 5"))
+	}
+	return violations
 }
 
 func (th *taskHandler) convertCreateTaskReqeuestToParams(req *pb.CreateTaskRequest) *usecase.CreateTaskParams {
@@ -113,28 +199,36 @@ func (th *taskHandler) convertCreateTaskReqeuestToParams(req *pb.CreateTaskReque
 		Description: req.GetDescription(),
 		DueDate:     req.GetDueDate().AsTime(),
 		Priority:    int(req.GetPriority()),
+		Tags:        req.GetTags(),
 	}
 }
 
 func (th *taskHandler) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.UpdateTaskResponse, error) {
-	if !th.isValidUpdateTasksRequest(req) {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request")
+	if violations := th.validateUpdateTasksRequest(req); len(violations) != 0 {
+		return nil, invalidArgument(violations)
 	}
 	params := th.convertUpdateTaskRequestToParams(req)
 	if err := th.tuc.UpdateTask(ctx, params); err != nil {
 		log.Error("Failed to update task", log.Ferror(err))
-		return nil, status.Errorf(codes.Internal, "Failed to update task")
+		return nil, statusError(err, "Failed to update task")
 	}
 
 	return &pb.UpdateTaskResponse{}, nil
 }
 
-func (th *taskHandler) isValidUpdateTasksRequest(req *pb.UpdateTaskRequest) bool {
-	if req.GetId() == "" ||
-		req.GetTitle() == "" ||
-		req.GetDescription() == "" ||
-		req.GetDueDate().AsTime().IsZero() ||
-		!entity.ValidPriorities[int(req.GetPriority())] {
+func (th *taskHandler) validateUpdateTasksRequest(req *pb.UpdateTaskRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.GetId() == "" {
+		violations = append(violations, fieldViolation("id", "is required"))
+	}
+	violations = append(
+		violations,
+		validateTaskFields(req.GetTitle(), req.GetDescription(), req.GetDueDate().AsTime(), int(req.GetPriority()))...,
+	)
+	if req.GetClearTags() && len(req.GetTags()) != 0 {
+		violations = append(violations, fieldViolation("clear_tags", "must not be set together with tags"))
+	}
+	if len(violations) != 0 {
 		log.Warn(
 			"Invalid request",
 			log.Fstring("id", req.GetId()),
@@ -143,9 +237,8 @@ func (th *taskHandler) isValidUpdateTasksRequest(req *pb.UpdateTaskRequest) bool
 			log.Ftime("due_date", req.GetDueDate().AsTime()),
 			log.Fint("priority", int(req.GetPriority())),
 		)
-		return false
 	}
-	return true
+	return violations
 }
 
 func (th *taskHandler) convertUpdateTaskRequestToParams(req *pb.UpdateTaskRequest) *usecase.UpdateTaskParams {
@@ -155,6 +248,61 @@ func (th *taskHandler) convertUpdateTaskRequestToParams(req *pb.UpdateTaskReques
 		Description: req.GetDescription(),
 		DueDate:     req.GetDueDate().AsTime(),
 		Priority:    int(req.GetPriority()),
+		Tags:        updateTaskTags(req),
+	}
+}
+
+// updateTaskTags returns nil, keeping the tags of the task, unless tags or clear_tags are given.
+func updateTaskTags(req *pb.UpdateTaskRequest) *[]string {
+	switch {
+	case len(req.GetTags()) != 0:
+		tags := req.GetTags()
+		return &tags
+	case req.GetClearTags():
+		return &[]string{}
+	default:
+		return nil
+	}
+}
+
+func (th *taskHandler) UpdateTaskStatus(ctx context.Context, req *pb.UpdateTaskStatusRequest) (*pb.UpdateTaskStatusResponse, error) {
+	if violations := th.validateUpdateTaskStatusRequest(req); len(violations) != 0 {
+		return nil, invalidArgument(violations)
+	}
+	params := th.convertUpdateTaskStatusRequestToParams(req)
+	if err := th.tuc.UpdateTaskStatus(ctx, params); err != nil {
+		log.Error("Failed to update task status", log.Ferror(err))
+		return nil, statusError(err, "Failed to update task status")
+	}
+
+	return &pb.UpdateTaskStatusResponse{}, nil
+}
+
+func (th *taskHandler) validateUpdateTaskStatusRequest(req *pb.UpdateTaskStatusRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.GetId() == "" {
+		violations = append(violations, fieldViolation("id", "is required"))
+	}
+	if !entity.ValidStatuses[req.GetStatus()] {
+		violations = append(violations, fieldViolation("status", fmt.Sprintf(
+			"must be one of %s, %s, %s, %s, %s",
+			entity.StatusTodo, entity.StatusInProgress, entity.StatusBlocked, entity.StatusDone, entity.StatusArchived,
+		)))
+	}
+	if len(violations) != 0 {
+		log.Warn(
+			"Invalid request",
+			log.Fstring("id", req.GetId()),
+			log.Fstring("status", req.GetStatus()),
+		)
+	}
+	return violations
+}
+
+func (th *taskHandler) convertUpdateTaskStatusRequestToParams(req *pb.UpdateTaskStatusRequest) *usecase.UpdateTaskStatusParams {
+	return &usecase.UpdateTaskStatusParams{
+		ID:     req.GetId(),
+		Status: req.GetStatus(),
 	}
 }
 
@@ -162,13 +310,73 @@ func (th *taskHandler) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest
 	id := req.GetId()
 	if id == "" {
 		log.Warn("ID is required")
-		return nil, status.Errorf(codes.InvalidArgument, "ID is required")
+		return nil, invalidArgument([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", "is required")})
 	}
 
 	if err := th.tuc.DeleteTask(ctx, id); err != nil {
 		log.Error("Failed to delete task", log.Ferror(err))
-		return nil, status.Errorf(codes.Internal, "Failed to delete task")
+		return nil, statusError(err, "Failed to delete task")
 	}
 
 	return &pb.DeleteTaskResponse{}, nil
 }
+
+// WatchTasks streams the changes of the tasks of the authenticated user until the client goes away.
+func (th *taskHandler) WatchTasks(_ *pb.WatchTasksRequest, stream pb.TaskService_WatchTasksServer) error {
+	events, err := th.tuc.WatchTasks(stream.Context())
+	if err != nil {
+		log.Error("Failed to watch tasks", log.Ferror(err))
+		return statusError(err, "Failed to watch tasks")
+	}
+
+	for event := range events {
+		if err = stream.Send(&pb.TaskEvent{
+			Type:       event.Type,
+			Task:       toTaskMessage(event.Task),
+			OccurredAt: timestamppb.New(event.OccurredAt),
+		}); err != nil {
+			log.Warn("Failed to send task event", log.Ferror(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func toTaskMessage(task entity.Task) *pb.Task {
+	return &pb.Task{
+		Id:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		DueDate:     timestamppb.New(task.DueDate),
+		Priority:    int32(task.Priority),
+		CreatedAt:   timestamppb.New(task.CreatedAt),
+		Status:      task.Status,
+		CompletedAt: toTimestamp(task.CompletedAt),
+		IsOverdue:   task.IsOverdue,
+		IsDueSoon:   task.IsDueSoon,
+		Tags:        tagNames(task.Tags),
+	}
+}
+
+func tagNames(tags []entity.Tag) []string {
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// fromTimestamp converts an unset timestamp to the zero time rather than the Unix epoch.
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -3,7 +3,9 @@ package handler
 import (
 	"context"
 	"errors"
+	"io"
 	"net"
+	"reflect"
 	"testing"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 
 	"github.com/tusmasoma/go-clean-arch/entity"
 	pb "github.com/tusmasoma/go-clean-arch/interfaces/handler/grpc/proto/gateway"
+	"github.com/tusmasoma/go-clean-arch/repository"
 	"github.com/tusmasoma/go-clean-arch/usecase"
 	"github.com/tusmasoma/go-clean-arch/usecase/mock"
 )
@@ -76,6 +79,7 @@ func TestHandler_GetTask(t *testing.T) {
 		DueDate:     dueDate,
 		Priority:    3,
 		CreatedAt:   time.Now(),
+		Tags:        []entity.Tag{{ID: uuid.New().String(), Name: "backend"}},
 	}
 
 	patterns := []struct {
@@ -102,6 +106,39 @@ func TestHandler_GetTask(t *testing.T) {
 			request:    &pb.GetTaskRequest{Id: ""},
 			wantStatus: codes.InvalidArgument,
 		},
+		{
+			name: "Fail: task not found",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().GetTask(
+					gomock.Any(),
+					taskID,
+				).Return(nil, repository.ErrTaskNotFound)
+			},
+			request:    &pb.GetTaskRequest{Id: taskID},
+			wantStatus: codes.NotFound,
+		},
+		{
+			name: "Fail: task of another user",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().GetTask(
+					gomock.Any(),
+					taskID,
+				).Return(nil, usecase.ErrTaskNotOwned)
+			},
+			request:    &pb.GetTaskRequest{Id: taskID},
+			wantStatus: codes.PermissionDenied,
+		},
+		{
+			name: "Fail: internal error",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().GetTask(
+					gomock.Any(),
+					taskID,
+				).Return(nil, errors.New("connection refused"))
+			},
+			request:    &pb.GetTaskRequest{Id: taskID},
+			wantStatus: codes.Internal,
+		},
 	}
 
 	for _, tt := range patterns {
@@ -121,6 +158,9 @@ func TestHandler_GetTask(t *testing.T) {
 				if resp.GetId() != task.ID || resp.GetTitle() != task.Title || resp.GetDescription() != task.Description {
 					t.Fatalf("handler returned wrong task data")
 				}
+				if len(resp.GetTags()) != 1 || resp.GetTags()[0] != "backend" {
+					t.Fatalf("handler returned wrong tags: %v", resp.GetTags())
+				}
 			}
 		})
 	}
@@ -163,11 +203,85 @@ func TestHandler_ListTasks(t *testing.T) {
 			setup: func(tuc *mock.MockTaskUseCase) {
 				tuc.EXPECT().ListTasks(
 					gomock.Any(),
-				).Return(tasks, nil)
+					gomock.Any(),
+				).Return(tasks, "", nil)
 			},
 			request:    &pb.ListTasksRequest{},
 			wantStatus: codes.OK,
 		},
+		{
+			name: "success with query",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().ListTasks(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.ListTasksParams) {
+					if params.MinPriority != 2 || params.MaxPriority != 4 {
+						t.Errorf("unexpected priority range: got %v-%v, want %v-%v", params.MinPriority, params.MaxPriority, 2, 4)
+					}
+					if !params.DueAfter.IsZero() {
+						t.Errorf("unexpected DueAfter: got %v, want zero", params.DueAfter)
+					}
+					if params.SortBy != "priority" || params.SortDesc {
+						t.Errorf("unexpected sort: got %v desc=%v, want %v desc=%v", params.SortBy, params.SortDesc, "priority", false)
+					}
+					if params.Limit != 10 || params.Cursor != "next" {
+						t.Errorf("unexpected page: got %v %v, want %v %v", params.Limit, params.Cursor, 10, "next")
+					}
+				}).Return(tasks, "cursor", nil)
+			},
+			request: &pb.ListTasksRequest{
+				MinPriority: 2,
+				MaxPriority: 4,
+				SortBy:      "priority",
+				Order:       "asc",
+				PageSize:    10,
+				PageToken:   "next",
+			},
+			wantStatus: codes.OK,
+		},
+		{
+			name: "success with tags",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().ListTasks(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.ListTasksParams) {
+					if len(params.Tags) != 2 || params.Tags[0] != "backend" || params.Tags[1] != "urgent" {
+						t.Errorf("unexpected Tags: got %v, want %v", params.Tags, []string{"backend", "urgent"})
+					}
+					if !params.MatchAllTags {
+						t.Errorf("unexpected MatchAllTags: got %v, want %v", params.MatchAllTags, true)
+					}
+				}).Return(tasks, "", nil)
+			},
+			request: &pb.ListTasksRequest{
+				Tags:         []string{"backend", "urgent"},
+				MatchAllTags: true,
+			},
+			wantStatus: codes.OK,
+		},
+		{
+			name: "Fail: invalid request of tags",
+			request: &pb.ListTasksRequest{
+				Tags: []string{"backend", ""},
+			},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name: "Fail: invalid request of sort_by",
+			request: &pb.ListTasksRequest{
+				SortBy: "title",
+			},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name: "Fail: invalid request of page_size",
+			request: &pb.ListTasksRequest{
+				PageSize: 1000,
+			},
+			wantStatus: codes.InvalidArgument,
+		},
 	}
 
 	for _, tt := range patterns {
@@ -341,6 +455,9 @@ func TestHandler_UpdateTask(t *testing.T) { //nolint:gocognit // ignore cognitiv
 					if params.Priority != 2 {
 						t.Errorf("unexpected Priority: got %v, want %v", params.Priority, 3)
 					}
+					if params.Tags != nil {
+						t.Errorf("unexpected Tags: got %v, want them kept", *params.Tags)
+					}
 				}).Return(nil)
 			},
 			request: &pb.UpdateTaskRequest{
@@ -352,6 +469,63 @@ func TestHandler_UpdateTask(t *testing.T) { //nolint:gocognit // ignore cognitiv
 			},
 			wantStatus: codes.OK,
 		},
+		{
+			name: "success: replaces the tags",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().UpdateTask(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.UpdateTaskParams) {
+					if params.Tags == nil || !reflect.DeepEqual(*params.Tags, []string{"backend"}) {
+						t.Errorf("unexpected Tags: got %v, want %v", params.Tags, []string{"backend"})
+					}
+				}).Return(nil)
+			},
+			request: &pb.UpdateTaskRequest{
+				Id:          taskID,
+				Title:       "updated title",
+				Description: "updated description",
+				DueDate:     timestamppb.New(dueDate),
+				Priority:    2,
+				Tags:        []string{"backend"},
+			},
+			wantStatus: codes.OK,
+		},
+		{
+			name: "success: clears the tags",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().UpdateTask(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.UpdateTaskParams) {
+					if params.Tags == nil || len(*params.Tags) != 0 {
+						t.Errorf("unexpected Tags: got %v, want empty", params.Tags)
+					}
+				}).Return(nil)
+			},
+			request: &pb.UpdateTaskRequest{
+				Id:          taskID,
+				Title:       "updated title",
+				Description: "updated description",
+				DueDate:     timestamppb.New(dueDate),
+				Priority:    2,
+				ClearTags:   true,
+			},
+			wantStatus: codes.OK,
+		},
+		{
+			name: "Fail: invalid request of clear_tags with tags",
+			request: &pb.UpdateTaskRequest{
+				Id:          taskID,
+				Title:       "updated title",
+				Description: "updated description",
+				DueDate:     timestamppb.New(dueDate),
+				Priority:    2,
+				Tags:        []string{"backend"},
+				ClearTags:   true,
+			},
+			wantStatus: codes.InvalidArgument,
+		},
 		{
 			name: "Fail: invalid request of id is empty",
 			request: &pb.UpdateTaskRequest{
@@ -442,6 +616,80 @@ func TestHandler_UpdateTask(t *testing.T) { //nolint:gocognit // ignore cognitiv
 	}
 }
 
+func TestHandler_UpdateTaskStatus(t *testing.T) { //nolint:gocognit // ignore cognitive complexity
+	t.Parallel()
+
+	taskID := uuid.New().String()
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTaskUseCase,
+		)
+		request    *pb.UpdateTaskStatusRequest
+		wantStatus codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().UpdateTaskStatus(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, params *usecase.UpdateTaskStatusParams) {
+					if params.ID != taskID {
+						t.Errorf("unexpected ID: got %v, want %v", params.ID, taskID)
+					}
+					if params.Status != entity.StatusDone {
+						t.Errorf("unexpected Status: got %v, want %v", params.Status, entity.StatusDone)
+					}
+				}).Return(nil)
+			},
+			request: &pb.UpdateTaskStatusRequest{
+				Id:     taskID,
+				Status: entity.StatusDone,
+			},
+			wantStatus: codes.OK,
+		},
+		{
+			name: "Fail: invalid request of id is empty",
+			request: &pb.UpdateTaskStatusRequest{
+				Id:     "",
+				Status: entity.StatusDone,
+			},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name: "Fail: invalid request of unknown status",
+			request: &pb.UpdateTaskStatusRequest{
+				Id:     taskID,
+				Status: "unknown",
+			},
+			wantStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, cleanup := setupTestServer(t, tt.setup)
+			defer cleanup()
+
+			req, err := client.UpdateTaskStatus(context.Background(), tt.request)
+			if status.Code(err) != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status.Code(err), tt.wantStatus)
+			}
+
+			if tt.wantStatus == codes.OK {
+				if req == nil {
+					t.Fatalf("handler returned wrong task data")
+				}
+			}
+		})
+	}
+}
+
 func TestHandler_DeleteTask(t *testing.T) {
 	t.Parallel()
 
@@ -494,3 +742,83 @@ func TestHandler_DeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_WatchTasks(t *testing.T) {
+	t.Parallel()
+
+	event := entity.NewTaskEvent(entity.TaskEventCreated, entity.Task{
+		ID:          uuid.New().String(),
+		Title:       "title",
+		Description: "description",
+		DueDate:     time.Now().AddDate(0, 0, 1),
+		Priority:    3,
+		Status:      entity.StatusTodo,
+		CreatedAt:   time.Now(),
+	}, time.Now())
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTaskUseCase,
+		)
+		wantEvents int
+		wantStatus codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				events := make(chan entity.TaskEvent, 1)
+				events <- event
+				close(events)
+				tuc.EXPECT().WatchTasks(gomock.Any()).Return((<-chan entity.TaskEvent)(events), nil)
+			},
+			wantEvents: 1,
+			wantStatus: codes.OK,
+		},
+		{
+			name: "Fail: user not in context",
+			setup: func(tuc *mock.MockTaskUseCase) {
+				tuc.EXPECT().WatchTasks(gomock.Any()).Return(nil, usecase.ErrUserNotInContext)
+			},
+			wantStatus: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, cleanup := setupTestServer(t, tt.setup)
+			defer cleanup()
+
+			stream, err := client.WatchTasks(context.Background(), &pb.WatchTasksRequest{})
+			if err != nil {
+				t.Fatalf("failed to open stream: %v", err)
+			}
+
+			var received int
+			for {
+				var got *pb.TaskEvent
+				got, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				received++
+				if got.GetType() != event.Type || got.GetTask().GetId() != event.Task.ID {
+					t.Fatalf("handler returned wrong event: %v", got)
+				}
+			}
+
+			if !errors.Is(err, io.EOF) && status.Code(err) != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status.Code(err), tt.wantStatus)
+			}
+			if errors.Is(err, io.EOF) && tt.wantStatus != codes.OK {
+				t.Fatalf("handler ended the stream without an error, want %v", tt.wantStatus)
+			}
+			if received != tt.wantEvents {
+				t.Fatalf("handler sent %d events, want %d", received, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	pb "github.com/tusmasoma/go-clean-arch/interfaces/handler/grpc/proto/gateway"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type UserHandler interface {
+	CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error)
+	Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error)
+	GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error)
+	UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error)
+}
+
+type userHandler struct {
+	uuc usecase.UserUseCase
+	pb.UnimplementedUserServiceServer
+}
+
+func NewUserHandler(uuc usecase.UserUseCase) *userHandler { //nolint:revive // This function is used in the test
+	return &userHandler{
+		uuc: uuc,
+	}
+}
+
+func (uh *userHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	if violations := validateCredentials(req.GetEmail(), req.GetPassword()); len(violations) != 0 {
+		log.Warn("Invalid request", log.Fstring("email", req.GetEmail()))
+		return nil, invalidArgument(violations)
+	}
+
+	tokens, err := uh.uuc.CreateUserAndToken(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		log.Error("Failed to create user", log.Ferror(err))
+		return nil, statusError(err, "Failed to create user")
+	}
+
+	return &pb.CreateUserResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func (uh *userHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	if violations := validateCredentials(req.GetEmail(), req.GetPassword()); len(violations) != 0 {
+		log.Warn("Invalid login request", log.Fstring("email", req.GetEmail()))
+		return nil, invalidArgument(violations)
+	}
+
+	tokens, err := uh.uuc.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		log.Error("Failed to login", log.Ferror(err))
+		return nil, statusError(err, "Failed to login")
+	}
+
+	return &pb.LoginResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func validateCredentials(email, password string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if email == "" {
+		violations = append(violations, fieldViolation("email", "is required"))
+	}
+	if password == "" {
+		violations = append(violations, fieldViolation("password", "is required"))
+	}
+	return violations
+}
+
+func (uh *userHandler) GetUser(ctx context.Context, _ *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	user, err := uh.uuc.GetUser(ctx)
+	if err != nil {
+		log.Error("Failed to get user", log.Ferror(err))
+		return nil, statusError(err, "Failed to get user")
+	}
+
+	return &pb.GetUserResponse{
+		Id:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		DueSoonHours: int32(user.DueSoonHours),
+	}, nil
+}
+
+func (uh *userHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	if violations := uh.validateUpdateUserRequest(req); len(violations) != 0 {
+		return nil, invalidArgument(violations)
+	}
+
+	if err := uh.uuc.UpdateUser(ctx, &usecase.UpdateUserParams{
+		Name:         req.GetName(),
+		DueSoonHours: int(req.GetDueSoonHours()),
+	}); err != nil {
+		log.Error("Failed to update user", log.Ferror(err))
+		return nil, statusError(err, "Failed to update user")
+	}
+
+	return &pb.UpdateUserResponse{}, nil
+}
+
+func (uh *userHandler) validateUpdateUserRequest(req *pb.UpdateUserRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.GetName() == "" {
+		violations = append(violations, fieldViolation("name", "is required"))
+	}
+	if req.GetDueSoonHours() < 0 || int(req.GetDueSoonHours()) > entity.MaxDueSoonHours {
+		violations = append(violations, fieldViolation(
+			"due_soon_hours", fmt.Sprintf("must be between 1 and %d", entity.MaxDueSoonHours),
+		))
+	}
+	if len(violations) != 0 {
+		log.Warn(
+			"Invalid request",
+			log.Fstring("name", req.GetName()),
+			log.Fint("due_soon_hours", int(req.GetDueSoonHours())),
+		)
+	}
+	return violations
+}
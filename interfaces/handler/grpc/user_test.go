@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	pb "github.com/tusmasoma/go-clean-arch/interfaces/handler/grpc/proto/gateway"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+	"github.com/tusmasoma/go-clean-arch/usecase/mock"
+)
+
+func setupUserTestServer(t *testing.T, setup func(m *mock.MockUserUseCase)) (pb.UserServiceClient, func()) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	uuc := mock.NewMockUserUseCase(ctrl)
+
+	if setup != nil {
+		setup(uuc)
+	}
+
+	handler := NewUserHandler(uuc)
+
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	pb.RegisterUserServiceServer(s, handler)
+
+	go func() {
+		if err := s.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("failed to serve: %v", err)
+		}
+	}()
+
+	conn, err := grpc.Dial("", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) { //nolint:staticcheck // ignore deprecation
+		return lis.Dial()
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+
+	client := pb.NewUserServiceClient(conn)
+
+	cleanup := func() {
+		conn.Close()
+		s.Stop()
+	}
+
+	return client, cleanup
+}
+
+func TestUserHandler_CreateUser(t *testing.T) {
+	t.Parallel()
+
+	tokens := &usecase.AuthTokens{AccessToken: "access", RefreshToken: "refresh"}
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockUserUseCase,
+		)
+		request    *pb.CreateUserRequest
+		wantStatus codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().CreateUserAndToken(
+					gomock.Any(),
+					"test@gmail.com",
+					"password123",
+				).Return(tokens, nil)
+			},
+			request:    &pb.CreateUserRequest{Email: "test@gmail.com", Password: "password123"},
+			wantStatus: codes.OK,
+		},
+		{
+			name:       "Fail: invalid request of email is empty",
+			request:    &pb.CreateUserRequest{Password: "password123"},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name: "Fail: user already exists",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().CreateUserAndToken(
+					gomock.Any(),
+					"test@gmail.com",
+					"password123",
+				).Return(nil, usecase.ErrUserAlreadyExists)
+			},
+			request:    &pb.CreateUserRequest{Email: "test@gmail.com", Password: "password123"},
+			wantStatus: codes.Aborted,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, cleanup := setupUserTestServer(t, tt.setup)
+			defer cleanup()
+
+			resp, err := client.CreateUser(context.Background(), tt.request)
+			if status.Code(err) != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status.Code(err), tt.wantStatus)
+			}
+
+			if tt.wantStatus == codes.OK {
+				if resp.GetAccessToken() != tokens.AccessToken || resp.GetRefreshToken() != tokens.RefreshToken {
+					t.Fatalf("handler returned wrong tokens")
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_Login(t *testing.T) {
+	t.Parallel()
+
+	tokens := &usecase.AuthTokens{AccessToken: "access", RefreshToken: "refresh"}
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockUserUseCase,
+		)
+		request    *pb.LoginRequest
+		wantStatus codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().Login(
+					gomock.Any(),
+					"test@gmail.com",
+					"password123",
+				).Return(tokens, nil)
+			},
+			request:    &pb.LoginRequest{Email: "test@gmail.com", Password: "password123"},
+			wantStatus: codes.OK,
+		},
+		{
+			name:       "Fail: invalid request of password is empty",
+			request:    &pb.LoginRequest{Email: "test@gmail.com"},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name: "Fail: invalid credentials",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().Login(
+					gomock.Any(),
+					"test@gmail.com",
+					"wrongpassword",
+				).Return(nil, usecase.ErrInvalidCredentials)
+			},
+			request:    &pb.LoginRequest{Email: "test@gmail.com", Password: "wrongpassword"},
+			wantStatus: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, cleanup := setupUserTestServer(t, tt.setup)
+			defer cleanup()
+
+			resp, err := client.Login(context.Background(), tt.request)
+			if status.Code(err) != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status.Code(err), tt.wantStatus)
+			}
+
+			if tt.wantStatus == codes.OK {
+				if resp.GetAccessToken() != tokens.AccessToken || resp.GetRefreshToken() != tokens.RefreshToken {
+					t.Fatalf("handler returned wrong tokens")
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_GetUser(t *testing.T) {
+	t.Parallel()
+
+	user := &entity.User{
+		ID:           uuid.New().String(),
+		Name:         "test",
+		Email:        "test@gmail.com",
+		DueSoonHours: 24,
+	}
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockUserUseCase,
+		)
+		wantStatus codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().GetUser(gomock.Any()).Return(user, nil)
+			},
+			wantStatus: codes.OK,
+		},
+		{
+			name: "Fail: user not in context",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().GetUser(gomock.Any()).Return(nil, usecase.ErrUserNotInContext)
+			},
+			wantStatus: codes.Unauthenticated,
+		},
+		{
+			name: "Fail: internal error",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().GetUser(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			wantStatus: codes.Internal,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, cleanup := setupUserTestServer(t, tt.setup)
+			defer cleanup()
+
+			resp, err := client.GetUser(context.Background(), &pb.GetUserRequest{})
+			if status.Code(err) != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status.Code(err), tt.wantStatus)
+			}
+
+			if tt.wantStatus == codes.OK {
+				if resp.GetId() != user.ID || resp.GetEmail() != user.Email || int(resp.GetDueSoonHours()) != user.DueSoonHours {
+					t.Fatalf("handler returned wrong user data")
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_UpdateUser(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockUserUseCase,
+		)
+		request    *pb.UpdateUserRequest
+		wantStatus codes.Code
+	}{
+		{
+			name: "success",
+			setup: func(uuc *mock.MockUserUseCase) {
+				uuc.EXPECT().UpdateUser(
+					gomock.Any(),
+					&usecase.UpdateUserParams{Name: "updated", DueSoonHours: 48},
+				).Return(nil)
+			},
+			request:    &pb.UpdateUserRequest{Name: "updated", DueSoonHours: 48},
+			wantStatus: codes.OK,
+		},
+		{
+			name:       "Fail: invalid request of name is empty",
+			request:    &pb.UpdateUserRequest{DueSoonHours: 48},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name:       "Fail: invalid request of due_soon_hours is out of range",
+			request:    &pb.UpdateUserRequest{Name: "updated", DueSoonHours: entity.MaxDueSoonHours + 1},
+			wantStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, cleanup := setupUserTestServer(t, tt.setup)
+			defer cleanup()
+
+			_, err := client.UpdateUser(context.Background(), tt.request)
+			if status.Code(err) != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status.Code(err), tt.wantStatus)
+			}
+		})
+	}
+}
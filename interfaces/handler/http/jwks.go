@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type JWKSHandler interface {
+	GetJWKS(w http.ResponseWriter, r *http.Request)
+}
+
+type jwksHandler struct {
+	core core.JWKSHandler
+}
+
+func NewJWKSHandler(juc usecase.JWKSUseCase) JWKSHandler {
+	return &jwksHandler{
+		core: core.NewJWKSHandler(juc),
+	}
+}
+
+func (jh *jwksHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, jh.core.GetJWKS(r))
+}
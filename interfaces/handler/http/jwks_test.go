@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase/mock"
+)
+
+func TestJWKSHandler_GetJWKS(t *testing.T) {
+	t.Parallel()
+
+	keySet := entity.JSONWebKeySet{
+		Keys: []entity.JSONWebKey{
+			{KeyType: "RSA", Use: "sig", Algorithm: "RS256", KeyID: "current", N: "n1", E: "AQAB"},
+			{KeyType: "RSA", Use: "sig", Algorithm: "RS256", KeyID: "retired", N: "n2", E: "AQAB"},
+		},
+	}
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockJWKSUseCase,
+		)
+		wantStatus int
+		want       entity.JSONWebKeySet
+	}{
+		{
+			name: "success",
+			setup: func(m *mock.MockJWKSUseCase) {
+				m.EXPECT().GetJWKS(gomock.Any()).Return(keySet)
+			},
+			wantStatus: http.StatusOK,
+			want:       keySet,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			juc := mock.NewMockJWKSUseCase(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(juc)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			handler := NewJWKSHandler(juc)
+			recorder := httptest.NewRecorder()
+			handler.GetJWKS(recorder, req)
+
+			if status := recorder.Code; status != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, tt.wantStatus)
+			}
+			if cacheControl := recorder.Header().Get("Cache-Control"); cacheControl != core.JWKSCacheControl {
+				t.Errorf("Cache-Control = %v, want %v", cacheControl, core.JWKSCacheControl)
+			}
+			var got entity.JSONWebKeySet
+			if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetJWKS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+)
+
+// writeResponse sends the response built by the core handlers.
+func writeResponse(w http.ResponseWriter, res *core.Response) {
+	for key, values := range res.Header {
+		w.Header()[key] = values
+	}
+	if res.ContentType != "" {
+		w.Header().Set("Content-Type", res.ContentType)
+	}
+	w.WriteHeader(res.Status)
+	if res.Body == nil {
+		return
+	}
+	if _, err := w.Write(res.Body); err != nil {
+		log.Error("Failed to write response", log.Ferror(err))
+	}
+}
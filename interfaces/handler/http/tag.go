@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+type TagHandler interface {
+	ListTags(w http.ResponseWriter, r *http.Request)
+	CreateTag(w http.ResponseWriter, r *http.Request)
+	UpdateTag(w http.ResponseWriter, r *http.Request)
+	DeleteTag(w http.ResponseWriter, r *http.Request)
+}
+
+type tagHandler struct {
+	core core.TagHandler
+}
+
+func NewTagHandler(tguc usecase.TagUseCase) TagHandler {
+	return &tagHandler{
+		core: core.NewTagHandler(tguc),
+	}
+}
+
+type (
+	GetTagResponse   = core.GetTagResponse
+	ListTagsResponse = core.ListTagsResponse
+	CreateTagRequest = core.CreateTagRequest
+	UpdateTagRequest = core.UpdateTagRequest
+)
+
+func (tgh *tagHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, tgh.core.ListTags(r))
+}
+
+func (tgh *tagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, tgh.core.CreateTag(r))
+}
+
+func (tgh *tagHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, tgh.core.UpdateTag(r))
+}
+
+func (tgh *tagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, tgh.core.DeleteTag(r))
+}
@@ -0,0 +1,86 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+const (
+	ContentType = "application/problem+json"
+	// DefaultType tells clients that the problem has no semantics beyond its status code.
+	DefaultType = "about:blank"
+)
+
+// FieldError explains why a single field of the request was rejected.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+func New(status int, detail, instance string) *Problem {
+	return &Problem{
+		Type:     DefaultType,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+	}
+}
+
+// Validation returns the problem of a request with invalid fields.
+func Validation(instance string, fieldErrors []FieldError) *Problem {
+	p := New(http.StatusBadRequest, "The request has invalid fields.", instance)
+	p.Errors = fieldErrors
+	return p
+}
+
+// MalformedBody returns the problem of a request body that could not be decoded.
+func MalformedBody(instance string) *Problem {
+	return New(http.StatusBadRequest, "The request body is not valid JSON.", instance)
+}
+
+// FromError returns the problem describing err. Domain errors carry their message as detail;
+// any other error is internal and described generically, so that no details of the failure leak to the client.
+func FromError(err error, instance string) *Problem {
+	status := StatusCode(err)
+	if status == http.StatusInternalServerError {
+		return New(status, "An unexpected error occurred.", instance)
+	}
+
+	p := New(status, err.Error(), instance)
+	var domainErr *entity.Error
+	if errors.As(err, &domainErr) && domainErr.Field != "" {
+		p.Errors = []FieldError{{Field: domainErr.Field, Reason: domainErr.Message}}
+	}
+	return p
+}
+
+// StatusCode translates the kind of a domain error into the status code of the response.
+// Errors of no known kind are internal errors.
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, entity.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, entity.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, entity.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,98 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/usecase"
+)
+
+func Test_StatusCode(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "validation", err: entity.NewValidationError("invalid"), want: http.StatusBadRequest},
+		{name: "unauthenticated", err: usecase.ErrInvalidCredentials, want: http.StatusUnauthorized},
+		{name: "forbidden", err: usecase.ErrTaskNotOwned, want: http.StatusForbidden},
+		{name: "not found", err: repository.ErrTaskNotFound, want: http.StatusNotFound},
+		{name: "wrapped not found", err: fmt.Errorf("failed to get task: %w", repository.ErrTaskNotFound), want: http.StatusNotFound},
+		{name: "conflict", err: usecase.ErrUserAlreadyExists, want: http.StatusConflict},
+		{name: "internal", err: errors.New("connection refused"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := StatusCode(tt.err); got != tt.want {
+				t.Errorf("StatusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_FromError(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name string
+		err  error
+		want *Problem
+	}{
+		{
+			name: "field validation",
+			err:  entity.NewFieldValidationError("priority", "priority must be between 1 and 5"),
+			want: &Problem{
+				Type:     DefaultType,
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "priority must be between 1 and 5",
+				Instance: "/api/task/create",
+				Errors:   []FieldError{{Field: "priority", Reason: "priority must be between 1 and 5"}},
+			},
+		},
+		{
+			name: "not found",
+			err:  repository.ErrTaskNotFound,
+			want: &Problem{
+				Type:     DefaultType,
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "task not found",
+				Instance: "/api/task/create",
+			},
+		},
+		{
+			name: "internal error does not leak",
+			err:  errors.New("dial tcp 10.0.0.1:3306: connection refused"),
+			want: &Problem{
+				Type:     DefaultType,
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "An unexpected error occurred.",
+				Instance: "/api/task/create",
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := FromError(tt.err, "/api/task/create"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromError() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+)
+
+const ContentType = "text/event-stream"
+
+// HeartbeatInterval keeps an idle stream from being closed by proxies in between.
+const HeartbeatInterval = 15 * time.Second
+
+type TaskEvent struct {
+	Type       string               `json:"type"`
+	Task       core.GetTaskResponse `json:"task"`
+	OccurredAt time.Time            `json:"occurred_at"`
+}
+
+// ServeTaskEvents writes every event as a server-sent event named after its type,
+// until events is closed, which happens when the request context is done, or the client goes away.
+func ServeTaskEvents(w http.ResponseWriter, events <-chan entity.TaskEvent) {
+	rc := http.NewResponseController(w)
+	// The stream lasts longer than the write timeout of the server.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn("Failed to clear write deadline", log.Ferror(err))
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		log.Error("Failed to flush event stream", log.Ferror(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			err = writeTaskEvent(w, event)
+		case <-heartbeat.C:
+			_, err = io.WriteString(w, ": heartbeat\n\n")
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			log.Warn("Failed to write event stream", log.Ferror(err))
+			return
+		}
+	}
+}
+
+func writeTaskEvent(w io.Writer, event entity.TaskEvent) error {
+	data, err := json.Marshal(TaskEvent{
+		Type:       event.Type,
+		Task:       core.NewGetTaskResponse(event.Task),
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
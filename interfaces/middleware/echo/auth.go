@@ -10,6 +10,8 @@ import (
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
 	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
 
 	"github.com/tusmasoma/go-clean-arch/repository"
 )
@@ -20,11 +22,13 @@ type AuthMiddleware interface {
 
 type authMiddleware struct {
 	ar repository.AuthRepository
+	vr repository.RevocationRepository
 }
 
-func NewAuthMiddleware(ar repository.AuthRepository) AuthMiddleware {
+func NewAuthMiddleware(ar repository.AuthRepository, vr repository.RevocationRepository) AuthMiddleware {
 	return &authMiddleware{
 		ar: ar,
+		vr: vr,
 	}
 }
 
@@ -35,31 +39,43 @@ func (am *authMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 		authHeader := c.Request().Header.Get("Authorization")
 		if authHeader == "" {
 			log.Info("Authentication failed: missing Authorization header")
-			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Authentication failed: missing Authorization header"})
+			return writeUnauthorized(c, "Authentication failed: missing Authorization header")
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
 			log.Warn("Authorization failed: header format must be Bearer {token}")
-			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Authorization failed: header format must be Bearer {token}"})
+			return writeUnauthorized(c, "Authorization failed: header format must be Bearer {token}")
 		}
 		jwt := parts[1]
 
-		if err := am.ar.ValidateAccessToken(jwt); err != nil {
+		claims, err := am.ar.ValidateAndParse(jwt)
+		if err != nil {
 			log.Warn("Authentication failed: invalid access token", log.Ferror(err))
-			return c.JSON(http.StatusUnauthorized, echo.Map{"error": fmt.Sprintf("Authentication failed: %v", err)})
+			return writeUnauthorized(c, fmt.Sprintf("Authentication failed: %v", err))
 		}
 
-		payload, err := am.ar.GetPayloadFromToken(jwt)
+		revoked, err := am.vr.IsRevoked(ctx, *claims)
 		if err != nil {
-			log.Warn("Authentication failed: invalid access token", log.Ferror(err))
-			return c.JSON(http.StatusUnauthorized, echo.Map{"error": fmt.Sprintf("Authentication failed: %v", err)})
+			log.Error("Authentication failed: could not check token revocation", log.Ferror(err))
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		if revoked {
+			log.Info("Authentication failed: revoked access token", log.Fstring("jti", claims.JTI))
+			return writeUnauthorized(c, "Authentication failed: token has been revoked")
 		}
 
-		ctx = context.WithValue(ctx, config.ContextUserIDKey, payload["userId"])
+		ctx = context.WithValue(ctx, config.ContextUserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, config.ContextTokenClaimsKey, claims)
 		c.SetRequest(c.Request().WithContext(ctx))
 
-		log.Info("Successfully Authentication", log.Fstring("userID", payload["userId"]))
+		log.Info("Successfully Authentication", log.Fstring("userID", claims.UserID))
 		return next(c)
 	}
 }
+
+// writeUnauthorized answers with the problem of a request that failed authentication.
+func writeUnauthorized(c echo.Context, detail string) error {
+	res := core.ProblemResponse(problem.New(http.StatusUnauthorized, detail, c.Request().URL.Path))
+	return c.Blob(res.Status, res.ContentType, res.Body)
+}
@@ -18,6 +18,11 @@ func (lrw *loggingResponseWriter) WriteHeader(statusCode int) {
 	lrw.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, to flush server-sent events for instance.
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 func Logging(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		lrw := &loggingResponseWriter{ResponseWriter: c.Response().Writer}
@@ -11,6 +11,8 @@ import (
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
 	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
 
 	"github.com/tusmasoma/go-clean-arch/repository"
 )
@@ -23,11 +25,13 @@ type AuthMiddleware interface {
 
 type authMiddleware struct {
 	ar repository.AuthRepository
+	vr repository.RevocationRepository
 }
 
-func NewAuthMiddleware(ar repository.AuthRepository) AuthMiddleware {
+func NewAuthMiddleware(ar repository.AuthRepository, vr repository.RevocationRepository) AuthMiddleware {
 	return &authMiddleware{
 		ar: ar,
+		vr: vr,
 	}
 }
 
@@ -38,35 +42,49 @@ func (am *authMiddleware) Authenticate() gin.HandlerFunc {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			log.Info("Authentication failed: missing Authorization header")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed: missing Authorization header"})
+			abortUnauthorized(c, "Authentication failed: missing Authorization header")
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
 			log.Warn("Authorization failed: header format must be Bearer {token}")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization failed: header format must be Bearer {token}"})
+			abortUnauthorized(c, "Authorization failed: header format must be Bearer {token}")
 			return
 		}
 		jwt := parts[1]
 
-		if err := am.ar.ValidateAccessToken(jwt); err != nil {
+		claims, err := am.ar.ValidateAndParse(jwt)
+		if err != nil {
 			log.Warn("Authentication failed: invalid access token", log.Ferror(err))
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Authentication failed: %v", err)})
+			abortUnauthorized(c, fmt.Sprintf("Authentication failed: %v", err))
 			return
 		}
 
-		payload, err := am.ar.GetPayloadFromToken(jwt)
+		revoked, err := am.vr.IsRevoked(ctx, *claims)
 		if err != nil {
-			log.Warn("Authentication failed: invalid access token", log.Ferror(err))
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Authentication failed: %v", err)})
+			log.Error("Authentication failed: could not check token revocation", log.Ferror(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		if revoked {
+			log.Info("Authentication failed: revoked access token", log.Fstring("jti", claims.JTI))
+			abortUnauthorized(c, "Authentication failed: token has been revoked")
 			return
 		}
 
-		ctx = context.WithValue(ctx, config.ContextUserIDKey, payload["userId"])
+		ctx = context.WithValue(ctx, config.ContextUserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, config.ContextTokenClaimsKey, claims)
 		c.Request = c.Request.WithContext(ctx)
 
-		log.Info("Successfully Authentication", log.Fstring("userID", payload["userId"]))
+		log.Info("Successfully Authentication", log.Fstring("userID", claims.UserID))
 		c.Next()
 	}
 }
+
+// abortUnauthorized answers with the problem of a request that failed authentication.
+func abortUnauthorized(c *gin.Context, detail string) {
+	res := core.ProblemResponse(problem.New(http.StatusUnauthorized, detail, c.Request.URL.Path))
+	c.Abort()
+	c.Data(res.Status, res.ContentType, res.Body)
+}
@@ -18,6 +18,11 @@ func (lrw *loggingResponseWriter) WriteHeader(statusCode int) {
 	lrw.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, to flush server-sent events for instance.
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 func Logging() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		lrw := &loggingResponseWriter{ResponseWriter: c.Writer}
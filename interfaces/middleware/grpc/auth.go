@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type AuthInterceptor interface {
+	Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error)
+	Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
+}
+
+type authInterceptor struct {
+	ar            repository.AuthRepository
+	vr            repository.RevocationRepository
+	publicMethods map[string]bool
+}
+
+// NewAuthInterceptor authenticates every RPC except publicMethods,
+// which are full method names such as "/user.UserService/Login".
+func NewAuthInterceptor(
+	ar repository.AuthRepository,
+	vr repository.RevocationRepository,
+	publicMethods ...string,
+) AuthInterceptor {
+	allowed := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		allowed[method] = true
+	}
+	return &authInterceptor{
+		ar:            ar,
+		vr:            vr,
+		publicMethods: allowed,
+	}
+}
+
+// Unary authenticates the bearer token of the authorization metadata, like the auth middleware of the HTTP servers.
+func (ai *authInterceptor) Unary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if ai.publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	ctx, err := ai.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Stream authenticates a streaming RPC once when it starts, and hands the authenticated context to the handler.
+func (ai *authInterceptor) Stream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if ai.publicMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	ctx, err := ai.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (ai *authInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		log.Info("Authentication failed: missing authorization metadata")
+		return nil, status.Error(grpcCodes.Unauthenticated, "missing authorization metadata")
+	}
+
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		log.Warn("Authorization failed: metadata format must be Bearer {token}")
+		return nil, status.Error(grpcCodes.Unauthenticated, "authorization metadata format must be Bearer {token}")
+	}
+
+	claims, err := ai.ar.ValidateAndParse(parts[1])
+	if err != nil {
+		log.Warn("Authentication failed: invalid access token", log.Ferror(err))
+		return nil, status.Errorf(grpcCodes.Unauthenticated, "authentication failed: %v", err)
+	}
+
+	revoked, err := ai.vr.IsRevoked(ctx, *claims)
+	if err != nil {
+		log.Error("Authentication failed: could not check token revocation", log.Ferror(err))
+		return nil, status.Error(grpcCodes.Internal, "could not check token revocation")
+	}
+	if revoked {
+		log.Info("Authentication failed: revoked access token", log.Fstring("jti", claims.JTI))
+		return nil, status.Error(grpcCodes.Unauthenticated, "token has been revoked")
+	}
+
+	ctx = context.WithValue(ctx, config.ContextUserIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, config.ContextTokenClaimsKey, claims)
+
+	log.Info("Successfully Authentication", log.Fstring("userID", claims.UserID))
+	return ctx, nil
+}
@@ -20,6 +20,26 @@ func LoggingUnaryInterceptor(
 
 	resp, err := handler(ctx, req)
 
+	logAccess(startTime, info.FullMethod, err)
+	return resp, err
+}
+
+// LoggingStreamInterceptor logs a streaming RPC once it has finished.
+func LoggingStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	startTime := time.Now()
+
+	err := handler(srv, ss)
+
+	logAccess(startTime, info.FullMethod, err)
+	return err
+}
+
+func logAccess(startTime time.Time, method string, err error) {
 	st, _ := status.FromError(err)
 	statusCode := grpcCodes.OK
 	if err != nil {
@@ -29,7 +49,7 @@ func LoggingUnaryInterceptor(
 	log.Info(
 		"Access log",
 		log.Ftime("Date", startTime),
-		log.Fstring("Method", info.FullMethod),
+		log.Fstring("Method", method),
 		log.Fint("StatusCode", int(statusCode)),
 		log.Fduration("Duration", time.Since(startTime)),
 	)
@@ -38,11 +58,9 @@ func LoggingUnaryInterceptor(
 		log.Error(
 			"Error log",
 			log.Ftime("Date", startTime),
-			log.Fstring("Method", info.FullMethod),
+			log.Fstring("Method", method),
 			log.Fint("StatusCode", int(statusCode)),
 			log.Fstring("Error", err.Error()),
 		)
 	}
-
-	return resp, err
 }
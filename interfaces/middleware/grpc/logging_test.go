@@ -72,3 +72,65 @@ func Test_LoggingUnaryInterceptor(t *testing.T) {
 		})
 	}
 }
+
+func Test_LoggingStreamInterceptor(t *testing.T) {
+	var logBuf bytes.Buffer
+	log.SetOutput(&logBuf)
+
+	tests := []struct {
+		name            string
+		handler         grpc.StreamHandler
+		expectedStatus  codes.Code
+		expectAccessLog bool
+		expectErrorLog  bool
+	}{
+		{
+			name: "successful stream",
+			handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return nil
+			},
+			expectedStatus:  codes.OK,
+			expectAccessLog: true,
+			expectErrorLog:  false,
+		},
+		{
+			name: "canceled stream",
+			handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return status.Error(codes.Canceled, "context canceled")
+			},
+			expectedStatus:  codes.Canceled,
+			expectAccessLog: true,
+			expectErrorLog:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoggingStreamInterceptor(
+				nil,
+				&fakeServerStream{ctx: context.Background()},
+				&grpc.StreamServerInfo{
+					FullMethod:     "/test.TestService/TestStream",
+					IsServerStream: true,
+				},
+				tt.handler,
+			)
+
+			if status.Code(err) != tt.expectedStatus {
+				t.Errorf("expected status code %v, got %v", tt.expectedStatus, status.Code(err))
+			}
+
+			logOutput := logBuf.String()
+			if tt.expectAccessLog && !strings.Contains(logOutput, "Access log") {
+				t.Errorf("expected access log, got %s", logOutput)
+			}
+
+			if tt.expectErrorLog && !strings.Contains(logOutput, "Error log") {
+				t.Errorf("expected error log, got %s", logOutput)
+			}
+
+			logBuf.Reset()
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
 	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/core"
+	"github.com/tusmasoma/go-clean-arch/interfaces/handler/problem"
 
 	"github.com/tusmasoma/go-clean-arch/repository"
 )
@@ -22,11 +24,13 @@ type AuthMiddleware interface {
 
 type authMiddleware struct {
 	ar repository.AuthRepository
+	vr repository.RevocationRepository
 }
 
-func NewAuthMiddleware(ar repository.AuthRepository) AuthMiddleware {
+func NewAuthMiddleware(ar repository.AuthRepository, vr repository.RevocationRepository) AuthMiddleware {
 	return &authMiddleware{
 		ar: ar,
+		vr: vr,
 	}
 }
 
@@ -37,34 +41,51 @@ func (am *authMiddleware) Authenticate(next http.Handler) http.Handler {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
 			log.Info("Authentication failed: missing Authorization header")
-			http.Error(w, "Authentication failed: missing Authorization header", http.StatusUnauthorized)
+			writeUnauthorized(w, r, "Authentication failed: missing Authorization header")
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
 			log.Warn("Authorization failed: header format must be Bearer {token}")
-			http.Error(w, "Authorization failed: header format must be Bearer {token}", http.StatusUnauthorized)
+			writeUnauthorized(w, r, "Authorization failed: header format must be Bearer {token}")
 			return
 		}
 		jwt := parts[1]
 
-		if err := am.ar.ValidateAccessToken(jwt); err != nil {
+		claims, err := am.ar.ValidateAndParse(jwt)
+		if err != nil {
 			log.Warn("Authentication failed: invalid access token", log.Ferror(err))
-			http.Error(w, fmt.Sprintf("Authentication failed: %v", err), http.StatusUnauthorized)
+			writeUnauthorized(w, r, fmt.Sprintf("Authentication failed: %v", err))
 			return
 		}
 
-		payload, err := am.ar.GetPayloadFromToken(jwt)
+		revoked, err := am.vr.IsRevoked(ctx, *claims)
 		if err != nil {
-			log.Warn("Authentication failed: invalid access token", log.Ferror(err))
-			http.Error(w, fmt.Sprintf("Authentication failed: %v", err), http.StatusUnauthorized)
+			log.Error("Authentication failed: could not check token revocation", log.Ferror(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if revoked {
+			log.Info("Authentication failed: revoked access token", log.Fstring("jti", claims.JTI))
+			writeUnauthorized(w, r, "Authentication failed: token has been revoked")
 			return
 		}
 
-		ctx = context.WithValue(ctx, config.ContextUserIDKey, payload["userId"])
+		ctx = context.WithValue(ctx, config.ContextUserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, config.ContextTokenClaimsKey, claims)
 
-		log.Info("Successfully Authentication", log.Fstring("userID", payload["userId"]))
+		log.Info("Successfully Authentication", log.Fstring("userID", claims.UserID))
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// writeUnauthorized answers with the problem of a request that failed authentication.
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, detail string) {
+	res := core.ProblemResponse(problem.New(http.StatusUnauthorized, detail, r.URL.Path))
+	w.Header().Set("Content-Type", res.ContentType)
+	w.WriteHeader(res.Status)
+	if _, err := w.Write(res.Body); err != nil {
+		log.Error("Failed to write response", log.Ferror(err))
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/tusmasoma/go-clean-arch/config"
 
+	"github.com/tusmasoma/go-clean-arch/repository"
 	"github.com/tusmasoma/go-clean-arch/repository/mock"
 )
 
@@ -33,20 +34,24 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 		name  string
 		setup func(
 			m *mock.MockAuthRepository,
+			m1 *mock.MockRevocationRepository,
 		)
 		in         func() *http.Request
 		wantStatus int
 	}{
 		{
 			name: "success",
-			setup: func(m *mock.MockAuthRepository) {
-				m.EXPECT().ValidateAccessToken(jwt).Return(nil)
-				m.EXPECT().GetPayloadFromToken(jwt).Return(
-					map[string]string{
-						"userId": userID,
-						"email":  email,
+			setup: func(m *mock.MockAuthRepository, m1 *mock.MockRevocationRepository) {
+				m.EXPECT().ValidateAndParse(jwt).Return(
+					&repository.TokenClaims{
+						UserID: userID,
+						Email:  email,
 					}, nil,
 				)
+				m1.EXPECT().IsRevoked(gomock.Any(), repository.TokenClaims{
+					UserID: userID,
+					Email:  email,
+				}).Return(false, nil)
 			},
 			in: func() *http.Request {
 				req, _ := http.NewRequest(http.MethodGet, "/", nil)
@@ -74,9 +79,9 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 		},
 		{
 			name: "Fail: Invalid Token",
-			setup: func(m *mock.MockAuthRepository) {
-				m.EXPECT().ValidateAccessToken("invalidToken").Return(
-					errors.New("invalid token"),
+			setup: func(m *mock.MockAuthRepository, m1 *mock.MockRevocationRepository) {
+				m.EXPECT().ValidateAndParse("invalidToken").Return(
+					nil, repository.ErrInvalidToken,
 				)
 			},
 			in: func() *http.Request {
@@ -86,6 +91,41 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 			},
 			wantStatus: http.StatusUnauthorized,
 		},
+		{
+			name: "Fail: Revoked Token",
+			setup: func(m *mock.MockAuthRepository, m1 *mock.MockRevocationRepository) {
+				m.EXPECT().ValidateAndParse(jwt).Return(
+					&repository.TokenClaims{
+						JTI:    "revoked",
+						UserID: userID,
+					}, nil,
+				)
+				m1.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Authorization", "Bearer "+jwt)
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Fail: Revocation Store Unavailable",
+			setup: func(m *mock.MockAuthRepository, m1 *mock.MockRevocationRepository) {
+				m.EXPECT().ValidateAndParse(jwt).Return(
+					&repository.TokenClaims{
+						UserID: userID,
+					}, nil,
+				)
+				m1.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused"))
+			},
+			in: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Authorization", "Bearer "+jwt)
+				return req
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
 	}
 
 	for _, tt := range patterns {
@@ -95,12 +135,13 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			ar := mock.NewMockAuthRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
 
 			if tt.setup != nil {
-				tt.setup(ar)
+				tt.setup(ar, vr)
 			}
 
-			am := NewAuthMiddleware(ar)
+			am := NewAuthMiddleware(ar, vr)
 
 			handler := am.Authenticate(http.HandlerFunc(dummyTestHandler))
 
@@ -1,8 +1,35 @@
 //go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
 package repository
 
+import (
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+var (
+	ErrInvalidToken = entity.NewUnauthenticatedError("invalid token")
+	ErrTokenExpired = entity.NewUnauthenticatedError("token expired")
+)
+
+// TokenClaims are the verified claims of an access token.
+type TokenClaims struct {
+	JTI       string
+	UserID    string
+	Email     string
+	IssuedAt  time.Time
+	NotBefore time.Time
+	ExpiresAt time.Time
+}
+
 type AuthRepository interface {
 	GenerateToken(userID, email string) (jwt string, jti string)
-	ValidateAccessToken(jwt string) error
-	GetPayloadFromToken(jwt string) (map[string]string, error)
+	// ValidateAndParse verifies the signature, algorithm, issuer, audience and lifetime of the token
+	// and returns its claims. Expired tokens fail with ErrTokenExpired, any other problem with ErrInvalidToken.
+	ValidateAndParse(jwt string) (*TokenClaims, error)
+	// GenerateRefreshToken returns a new opaque refresh token and the record to store for it.
+	// An empty familyID starts a new family.
+	GenerateRefreshToken(userID, familyID string) (token string, refreshToken entity.RefreshToken)
+	// PublicKeys returns every key access tokens are accepted from, for other services to verify issued tokens.
+	PublicKeys() entity.JSONWebKeySet
 }
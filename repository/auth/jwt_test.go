@@ -1,26 +1,96 @@
 package auth
 
 import (
-	"reflect"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	jwtgo "github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// signingKey signs the tokens of the tests, retiredKey stands for a key from before a rotation
+// and unknownKey for a key the repository does not trust.
+var (
+	signingKey = mustGenerateKey()
+	retiredKey = mustGenerateKey()
+	unknownKey = mustGenerateKey()
 )
 
+func mustGenerateKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func newTestKeySet() *config.KeySet {
+	return &config.KeySet{
+		Signing: config.SigningKey{ID: config.Thumbprint(&signingKey.PublicKey), PrivateKey: signingKey},
+		Verification: []config.VerificationKey{
+			{ID: config.Thumbprint(&signingKey.PublicKey), PublicKey: &signingKey.PublicKey},
+			{ID: config.Thumbprint(&retiredKey.PublicKey), PublicKey: &retiredKey.PublicKey},
+		},
+	}
+}
+
+func newTestAuthRepository(now time.Time) repository.AuthRepository {
+	return NewAuthRepository(&config.AuthConfig{
+		AccessTokenTTL: 15 * time.Minute,
+		Issuer:         "issuer",
+		Audience:       "audience",
+		Leeway:         30 * time.Second,
+	}, newTestKeySet(), fixedClock{now})
+}
+
+func signTestToken(t *testing.T, privKey *rsa.PrivateKey, h interface{}, c interface{}) string {
+	t.Helper()
+	headerBytes, _ := json.Marshal(h)
+	payloadBytes, _ := json.Marshal(c)
+	unsigned := base64UrlEncode(headerBytes) + "." + base64UrlEncode(payloadBytes)
+	hashed := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatalf("Failed to sign token: %s", err)
+	}
+	return unsigned + "." + base64UrlEncode(signature)
+}
+
 func Test_JWTToken(t *testing.T) {
 	userID := uuid.MustParse("f6db2530-cd9b-4ac1-8dc1-38c795e6eec2")
 	email := "test@gmail.com"
+	now := time.Now().Truncate(time.Second)
 
-	repo := NewAuthRepository()
+	repo := newTestAuthRepository(now)
 
 	// GenerateToken test
 	jwt, jti := repo.GenerateToken(userID.String(), email)
 
 	// JWTのフォーマットが正しいことを確認
 	token, err := jwtgo.Parse(jwt, func(token *jwtgo.Token) (interface{}, error) {
-		// ここで公開キーを使って署名を検証する（公開キーは環境に依存する）
-		return loadPublicKey(rawPublicKey)
+		if token.Header["kid"] != config.Thumbprint(&signingKey.PublicKey) {
+			t.Errorf("Expected kid of the signing key, got %v", token.Header["kid"])
+		}
+		return &signingKey.PublicKey, nil
 	})
 	if err != nil {
 		t.Errorf("Failed to parse JWT: %s", err)
@@ -32,31 +102,224 @@ func Test_JWTToken(t *testing.T) {
 		t.Errorf("Failed to parse claims")
 	}
 
-	if claims["Email"] != email {
-		t.Errorf("Expected email %s, got %s", email, claims["Email"])
+	if claims["email"] != email {
+		t.Errorf("Expected email %s, got %s", email, claims["email"])
 	}
-
 	if claims["jti"] != jti {
 		t.Errorf("Expected JTI %s, got %s", jti, claims["jti"])
 	}
+	if claims["sub"] != userID.String() {
+		t.Errorf("Expected sub %s, got %s", userID, claims["sub"])
+	}
+	if claims["iss"] != "issuer" || claims["aud"] != "audience" {
+		t.Errorf("Expected iss and aud, got %v and %v", claims["iss"], claims["aud"])
+	}
 
-	// ValidateAccessToken test
-	err = repo.ValidateAccessToken(jwt)
+	// ValidateAndParse test
+	got, err := repo.ValidateAndParse(jwt)
 	if err != nil {
-		t.Errorf("Failed to ValidateAccessToken: %s", err)
+		t.Fatalf("Failed to ValidateAndParse: %s", err)
+	}
+	want := repository.TokenClaims{
+		JTI:       jti,
+		UserID:    userID.String(),
+		Email:     email,
+		IssuedAt:  now,
+		NotBefore: now,
+		ExpiresAt: now.Add(15 * time.Minute),
 	}
+	if !got.IssuedAt.Equal(want.IssuedAt) || !got.NotBefore.Equal(want.NotBefore) || !got.ExpiresAt.Equal(want.ExpiresAt) ||
+		got.JTI != want.JTI || got.UserID != want.UserID || got.Email != want.Email {
+		t.Errorf("ValidateAndParse() \n got = %v,\n want = %v", got, want)
+	}
+}
 
-	// GetPayloadFromToken test
-	payload, err := repo.GetPayloadFromToken(jwt)
-	if err != nil {
-		t.Errorf("Failed to GetPayloadFromToken: %s", err)
+func Test_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	rs256 := header{Typ: "JWT", Alg: "RS256", Kid: config.Thumbprint(&signingKey.PublicKey)}
+	valid := claims{
+		JTI:       "jti",
+		Subject:   "userID",
+		Email:     "test@gmail.com",
+		Issuer:    "issuer",
+		Audience:  audience{"audience"},
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: now.Add(15 * time.Minute).Unix(),
+	}
+	with := func(modify func(c *claims)) claims {
+		c := valid
+		modify(&c)
+		return c
 	}
-	wantPayload := map[string]string{
-		"jti":    jti,
-		"userId": userID.String(),
-		"Email":  email,
+
+	patterns := []struct {
+		name    string
+		token   func(t *testing.T) string
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name:  "success",
+			token: func(t *testing.T) string { return signTestToken(t, signingKey, rs256, valid) },
+			now:   now.Add(time.Minute),
+		},
+		{
+			name: "success: signed by a retired key",
+			token: func(t *testing.T) string {
+				return signTestToken(t, retiredKey, header{Typ: "JWT", Alg: "RS256", Kid: config.Thumbprint(&retiredKey.PublicKey)}, valid)
+			},
+			now: now,
+		},
+		{
+			name: "success: audience array",
+			token: func(t *testing.T) string {
+				return signTestToken(t, signingKey, rs256, with(func(c *claims) { c.Audience = audience{"other", "audience"} }))
+			},
+			now: now,
+		},
+		{
+			name:  "success: expired within leeway",
+			token: func(t *testing.T) string { return signTestToken(t, signingKey, rs256, valid) },
+			now:   now.Add(15*time.Minute + 10*time.Second),
+		},
+		{
+			name:    "Fail: expired",
+			token:   func(t *testing.T) string { return signTestToken(t, signingKey, rs256, valid) },
+			now:     now.Add(time.Hour),
+			wantErr: repository.ErrTokenExpired,
+		},
+		{
+			name:    "Fail: not valid yet",
+			token:   func(t *testing.T) string { return signTestToken(t, signingKey, rs256, valid) },
+			now:     now.Add(-time.Minute),
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: wrong audience",
+			token: func(t *testing.T) string {
+				return signTestToken(t, signingKey, rs256, with(func(c *claims) { c.Audience = audience{"other"} }))
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: wrong issuer",
+			token: func(t *testing.T) string {
+				return signTestToken(t, signingKey, rs256, with(func(c *claims) { c.Issuer = "other" }))
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: missing exp",
+			token: func(t *testing.T) string {
+				return signTestToken(t, signingKey, rs256, with(func(c *claims) { c.ExpiresAt = 0 }))
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: alg none",
+			token: func(t *testing.T) string {
+				headerBytes, _ := json.Marshal(header{Typ: "JWT", Alg: "none"})
+				payloadBytes, _ := json.Marshal(valid)
+				return base64UrlEncode(headerBytes) + "." + base64UrlEncode(payloadBytes) + "."
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: unexpected alg header",
+			token: func(t *testing.T) string {
+				return signTestToken(t, signingKey, header{Typ: "JWT", Alg: "HS256", Kid: rs256.Kid}, valid)
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: tampered payload",
+			token: func(t *testing.T) string {
+				parts := strings.Split(signTestToken(t, signingKey, rs256, valid), ".")
+				payloadBytes, _ := json.Marshal(with(func(c *claims) { c.Subject = "other" }))
+				return parts[0] + "." + base64UrlEncode(payloadBytes) + "." + parts[2]
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: unknown key",
+			token: func(t *testing.T) string {
+				return signTestToken(t, unknownKey, header{Typ: "JWT", Alg: "RS256", Kid: config.Thumbprint(&unknownKey.PublicKey)}, valid)
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name: "Fail: missing kid",
+			token: func(t *testing.T) string {
+				return signTestToken(t, signingKey, header{Typ: "JWT", Alg: "RS256"}, valid)
+			},
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name:    "Fail: signed by another key than kid",
+			token:   func(t *testing.T) string { return signTestToken(t, unknownKey, rs256, valid) },
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+		{
+			name:    "Fail: malformed",
+			token:   func(t *testing.T) string { return "invalidToken" },
+			now:     now,
+			wantErr: repository.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newTestAuthRepository(tt.now)
+			got, err := repo.ValidateAndParse(tt.token(t))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateAndParse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.UserID != "userID" {
+				t.Errorf("ValidateAndParse() UserID = %v, want %v", got.UserID, "userID")
+			}
+		})
+	}
+}
+
+func Test_PublicKeys(t *testing.T) {
+	t.Parallel()
+
+	repo := newTestAuthRepository(time.Now())
+	got := repo.PublicKeys()
+
+	keys := []*rsa.PublicKey{&signingKey.PublicKey, &retiredKey.PublicKey}
+	if len(got.Keys) != len(keys) {
+		t.Fatalf("PublicKeys() returned %d keys, want %d", len(got.Keys), len(keys))
 	}
-	if !reflect.DeepEqual(payload, wantPayload) {
-		t.Errorf("GetPayloadFromToken() \n got = %v,\n want = %v", payload, wantPayload)
+	for i, key := range keys {
+		jwk := got.Keys[i]
+		if jwk.KeyType != "RSA" || jwk.Use != "sig" || jwk.Algorithm != "RS256" {
+			t.Errorf("PublicKeys() key %d = %+v, want an RS256 signature key", i, jwk)
+		}
+		if jwk.KeyID != config.Thumbprint(key) {
+			t.Errorf("PublicKeys() key %d kid = %v, want %v", i, jwk.KeyID, config.Thumbprint(key))
+		}
+		n, err := base64UrlDecode(jwk.N)
+		if err != nil || new(big.Int).SetBytes(n).Cmp(key.N) != 0 {
+			t.Errorf("PublicKeys() key %d has an unexpected modulus", i)
+		}
+		if jwk.E != "AQAB" {
+			t.Errorf("PublicKeys() key %d e = %v, want AQAB", i, jwk.E)
+		}
 	}
 }
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type passwordRepository struct {
+	cost int
+}
+
+func NewPasswordRepository(conf *config.AuthConfig) repository.PasswordRepository {
+	cost := conf.BcryptCost
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		log.Warn("Bcrypt cost out of range, using the default", log.Fint("cost", cost))
+		cost = bcrypt.DefaultCost
+	}
+	return &passwordRepository{
+		cost: cost,
+	}
+}
+
+func (pr *passwordRepository) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), pr.cost)
+	if err != nil {
+		log.Error("Failed to hash password", log.Ferror(err))
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (pr *passwordRepository) Compare(hash, password string) error {
+	// bcrypt compares the digests in constant time.
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return repository.ErrPasswordMismatch
+		}
+		log.Error("Failed to compare password", log.Ferror(err))
+		return err
+	}
+	return nil
+}
+
+func (pr *passwordRepository) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return cost != pr.cost
+}
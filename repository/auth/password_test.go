@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_PasswordRepository(t *testing.T) {
+	t.Parallel()
+
+	repo := NewPasswordRepository(&config.AuthConfig{BcryptCost: bcrypt.MinCost})
+
+	hash, err := repo.Hash("password123")
+	if err != nil {
+		t.Fatalf("Failed to Hash: %s", err)
+	}
+	if hash == "password123" {
+		t.Fatalf("Hash() returned the raw password")
+	}
+
+	if err = repo.Compare(hash, "password123"); err != nil {
+		t.Errorf("Compare() error = %v, want nil", err)
+	}
+	if err = repo.Compare(hash, "wrong"); !errors.Is(err, repository.ErrPasswordMismatch) {
+		t.Errorf("Compare() error = %v, want %v", err, repository.ErrPasswordMismatch)
+	}
+	if err = repo.Compare("password123", "password123"); err == nil || errors.Is(err, repository.ErrPasswordMismatch) {
+		t.Errorf("Compare() with a malformed hash error = %v, want a hash error", err)
+	}
+
+	if repo.NeedsRehash(hash) {
+		t.Errorf("NeedsRehash() = true for a hash with the current cost")
+	}
+	stronger := NewPasswordRepository(&config.AuthConfig{BcryptCost: bcrypt.MinCost + 1})
+	if !stronger.NeedsRehash(hash) {
+		t.Errorf("NeedsRehash() = false after the cost changed")
+	}
+	if !repo.NeedsRehash("password123") {
+		t.Errorf("NeedsRehash() = false for a raw password")
+	}
+}
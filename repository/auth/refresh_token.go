@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"crypto/rand"
+
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+const refreshTokenBytes = 32
+
+func (ar *authRepository) GenerateRefreshToken(userID, familyID string) (string, entity.RefreshToken) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	token := base64UrlEncode(b)
+
+	if familyID == "" {
+		familyID = uuid.New().String()
+	}
+	now := ar.clock.Now()
+	return token, entity.RefreshToken{
+		TokenHash: entity.HashRefreshToken(token),
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: now.Add(ar.refreshTTL),
+		CreatedAt: now,
+	}
+}
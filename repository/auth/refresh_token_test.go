@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+func Test_GenerateRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	repo := NewAuthRepository(&config.AuthConfig{RefreshTokenTTL: 24 * time.Hour}, newTestKeySet(), fixedClock{now})
+
+	token, rt := repo.GenerateRefreshToken("userID", "")
+	if token == "" {
+		t.Fatalf("GenerateRefreshToken() returned an empty token")
+	}
+	if rt.TokenHash != entity.HashRefreshToken(token) || rt.TokenHash == token {
+		t.Errorf("GenerateRefreshToken() TokenHash = %v, want the hash of the token", rt.TokenHash)
+	}
+	if rt.UserID != "userID" || rt.FamilyID == "" {
+		t.Errorf("GenerateRefreshToken() UserID = %v, FamilyID = %v", rt.UserID, rt.FamilyID)
+	}
+	if !rt.CreatedAt.Equal(now) || !rt.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("GenerateRefreshToken() CreatedAt = %v, ExpiresAt = %v", rt.CreatedAt, rt.ExpiresAt)
+	}
+
+	rotated, next := repo.GenerateRefreshToken("userID", rt.FamilyID)
+	if rotated == token {
+		t.Errorf("GenerateRefreshToken() returned the same token twice")
+	}
+	if next.FamilyID != rt.FamilyID {
+		t.Errorf("GenerateRefreshToken() FamilyID = %v, want %v", next.FamilyID, rt.FamilyID)
+	}
+}
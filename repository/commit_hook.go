@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+type commitHooksKey struct{}
+
+type commitHooks struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context)
+}
+
+type hookedTransactionRepository struct {
+	next TransactionRepository
+}
+
+// WithCommitHooks wraps a TransactionRepository so that the functions registered with AfterCommit
+// during a transaction run once the outermost transaction has committed, and never on rollback.
+func WithCommitHooks(next TransactionRepository) TransactionRepository {
+	return &hookedTransactionRepository{
+		next: next,
+	}
+}
+
+func (hr *hookedTransactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if InTransaction(ctx) {
+		// a nested transaction joins the outer one, whose commit runs the hooks
+		return hr.next.Transaction(ctx, fn)
+	}
+
+	hooks := &commitHooks{}
+	if err := hr.next.Transaction(context.WithValue(ctx, commitHooksKey{}, hooks), fn); err != nil {
+		return err
+	}
+
+	hooks.mu.Lock()
+	fns := hooks.fns
+	hooks.fns = nil
+	hooks.mu.Unlock()
+	for _, f := range fns {
+		f(ctx)
+	}
+	return nil
+}
+
+// InTransaction reports whether ctx belongs to a transaction started through WithCommitHooks.
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	return ok
+}
+
+// AfterCommit defers fn until the transaction of ctx commits.
+// Outside a transaction started through WithCommitHooks, fn runs right away.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.fns = append(hooks.fns, fn)
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type transactionFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
+func (f transactionFunc) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return f(ctx, fn)
+}
+
+func TestRepository_WithCommitHooks(t *testing.T) {
+	t.Parallel()
+
+	errRollback := errors.New("rollback")
+
+	patterns := []struct {
+		name string
+		fn   func(tr TransactionRepository, ran *int) func(ctx context.Context) error
+		want struct {
+			ran int
+			err error
+		}
+	}{
+		{
+			name: "success: hooks run after commit",
+			fn: func(_ TransactionRepository, ran *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					AfterCommit(ctx, func(context.Context) { *ran++ })
+					AfterCommit(ctx, func(context.Context) { *ran++ })
+					if *ran != 0 {
+						t.Errorf("want: hooks deferred until commit, got: %v runs", *ran)
+					}
+					return nil
+				}
+			},
+			want: struct {
+				ran int
+				err error
+			}{ran: 2},
+		},
+		{
+			name: "success: hooks of a nested transaction run after the outer commit",
+			fn: func(tr TransactionRepository, ran *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					err := tr.Transaction(ctx, func(ctx context.Context) error {
+						AfterCommit(ctx, func(context.Context) { *ran++ })
+						return nil
+					})
+					if *ran != 0 {
+						t.Errorf("want: hooks deferred until the outer commit, got: %v runs", *ran)
+					}
+					return err
+				}
+			},
+			want: struct {
+				ran int
+				err error
+			}{ran: 1},
+		},
+		{
+			name: "Fail: hooks are dropped on rollback",
+			fn: func(_ TransactionRepository, ran *int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					AfterCommit(ctx, func(context.Context) { *ran++ })
+					return errRollback
+				}
+			},
+			want: struct {
+				ran int
+				err error
+			}{ran: 0, err: errRollback},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := WithCommitHooks(transactionFunc(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}))
+			ran := 0
+			err := tr.Transaction(context.Background(), tt.fn(tr, &ran))
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Transaction() error = %v, wantErr %v", err, tt.want.err)
+			}
+			if ran != tt.want.ran {
+				t.Errorf("want: %v hook runs, got: %v", tt.want.ran, ran)
+			}
+		})
+	}
+}
+
+func TestRepository_AfterCommit_outsideTransaction(t *testing.T) {
+	t.Parallel()
+
+	ran := false
+	AfterCommit(context.Background(), func(context.Context) { ran = true })
+	if !ran {
+		t.Errorf("want: hook to run right away outside a transaction")
+	}
+}
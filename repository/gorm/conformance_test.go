@@ -0,0 +1,56 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/repositorytest"
+)
+
+func Test_TaskRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	if err := db.AutoMigrate(&taskModel{}, &taskTagModel{}); err != nil { // migrate
+		t.Fatal(err)
+	}
+	repositorytest.TestTaskRepository(t, func(*testing.T) (repository.TaskRepository, repository.TransactionRepository) {
+		return NewTaskRepository(db), NewTransactionRepository(db)
+	})
+}
+
+func Test_UserRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	if err := db.AutoMigrate(&userModel{}); err != nil { // migrate
+		t.Fatal(err)
+	}
+	repositorytest.TestUserRepository(t, func(*testing.T) (repository.UserRepository, repository.TransactionRepository) {
+		return NewUserRepository(db), NewTransactionRepository(db)
+	})
+}
+
+func Test_TagRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	if err := db.AutoMigrate(&tagModel{}, &taskModel{}, &taskTagModel{}); err != nil { // migrate
+		t.Fatal(err)
+	}
+	repositorytest.TestTagRepository(t, func(*testing.T) (repository.TagRepository, repository.TaskRepository, repository.TransactionRepository) {
+		return NewTagRepository(db), NewTaskRepository(db), NewTransactionRepository(db)
+	})
+}
+
+func Test_RefreshTokenRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	if err := db.AutoMigrate(&refreshTokenModel{}); err != nil { // migrate
+		t.Fatal(err)
+	}
+	repositorytest.TestRefreshTokenRepository(t, func(*testing.T) (repository.RefreshTokenRepository, repository.TransactionRepository) {
+		return NewRefreshTokenRepository(db), NewTransactionRepository(db)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/tusmasoma/go-clean-arch/config"
 	"github.com/tusmasoma/go-clean-arch/repository"
+	mysqlrepo "github.com/tusmasoma/go-clean-arch/repository/mysql"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -24,7 +25,12 @@ func NewTransactionRepository(db *gorm.DB) repository.TransactionRepository {
 	}
 }
 
+// Transaction joins a transaction already in ctx rather than beginning another one.
 func (tr *transactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if TxFromCtx(ctx) != nil {
+		return fn(ctx)
+	}
+
 	var err error
 
 	tx := tr.db.WithContext(ctx).Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead})
@@ -86,5 +92,23 @@ func NewMySQLDB(ctx context.Context) (*gorm.DB, error) {
 		return nil, err
 	}
 
+	// the schema is the one of the MySQL repositories, so are its migrations
+	if conf.AutoMigrate {
+		sqlDB, err := db.DB() //nolint: govet // shadowing is intended
+		if err != nil {
+			return nil, err
+		}
+		m, err := mysqlrepo.NewMigrator(sqlDB)
+		if err != nil {
+			sqlDB.Close()
+			return nil, err
+		}
+		if _, err = m.Up(ctx); err != nil {
+			log.Critical("Failed to migrate database", log.Ferror(err))
+			sqlDB.Close()
+			return nil, err
+		}
+	}
+
 	return db, nil
 }
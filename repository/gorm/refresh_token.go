@@ -0,0 +1,123 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type refreshTokenModel struct {
+	TokenHash string    `gorm:"column:token_hash;type:char(64);primaryKey"`
+	FamilyID  string    `gorm:"column:family_id;type:char(36);not null;index"`
+	UserID    string    `gorm:"column:user_id;type:char(36);not null;index"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	Used      bool      `gorm:"column:used;not null;default:false"`
+	Revoked   bool      `gorm:"column:revoked;not null;default:false"`
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+func (rr *refreshTokenRepository) Get(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	var rm refreshTokenModel
+	if err := executor.WithContext(ctx).First(&rm, "token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRefreshTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &entity.RefreshToken{
+		TokenHash: rm.TokenHash,
+		FamilyID:  rm.FamilyID,
+		UserID:    rm.UserID,
+		ExpiresAt: rm.ExpiresAt,
+		CreatedAt: rm.CreatedAt,
+		Used:      rm.Used,
+		Revoked:   rm.Revoked,
+	}, nil
+}
+
+func (rr *refreshTokenRepository) Create(ctx context.Context, token entity.RefreshToken) error {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	// Select is required so that false flags are inserted instead of being skipped as zero values.
+	if err := executor.WithContext(ctx).
+		Select("token_hash", "family_id", "user_id", "expires_at", "created_at", "used", "revoked").
+		Create(&refreshTokenModel{
+			TokenHash: token.TokenHash,
+			FamilyID:  token.FamilyID,
+			UserID:    token.UserID,
+			ExpiresAt: token.ExpiresAt,
+			CreatedAt: token.CreatedAt,
+			Used:      token.Used,
+			Revoked:   token.Revoked,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) MarkUsed(ctx context.Context, tokenHash string) (bool, error) {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	result := executor.WithContext(ctx).Model(&refreshTokenModel{}).
+		Where("token_hash = ? AND used = ? AND revoked = ?", tokenHash, false, false).
+		Update("used", true)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (rr *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	if err := executor.WithContext(ctx).Model(&refreshTokenModel{}).
+		Where("family_id = ?", familyID).
+		Update("revoked", true).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) RevokeUser(ctx context.Context, userID string) error {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	if err := executor.WithContext(ctx).Model(&refreshTokenModel{}).
+		Where("user_id = ?", userID).
+		Update("revoked", true).Error; err != nil {
+		return err
+	}
+	return nil
+}
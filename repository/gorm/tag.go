@@ -0,0 +1,132 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type tagModel struct {
+	ID        string    `gorm:"type:char(36);primaryKey"`
+	UserID    string    `gorm:"column:user_id;type:char(36);uniqueIndex:idx_tag_models_user_id_name"`
+	Name      string    `gorm:"column:name;size:50;uniqueIndex:idx_tag_models_user_id_name"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) repository.TagRepository {
+	return &tagRepository{
+		db: db,
+	}
+}
+
+func (tr *tagRepository) Get(ctx context.Context, id string) (*entity.Tag, error) {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	var tm tagModel
+	if err := executor.WithContext(ctx).First(&tm, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return &entity.Tag{
+		ID:        tm.ID,
+		UserID:    tm.UserID,
+		Name:      tm.Name,
+		CreatedAt: tm.CreatedAt,
+	}, nil
+}
+
+func (tr *tagRepository) List(ctx context.Context, userID string) ([]entity.Tag, error) {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	var tms []tagModel
+	if err := executor.WithContext(ctx).Where("user_id = ?", userID).Order("name").Find(&tms).Error; err != nil {
+		return nil, err
+	}
+
+	tags := make([]entity.Tag, len(tms))
+	for i, tm := range tms {
+		tags[i] = entity.Tag{
+			ID:        tm.ID,
+			UserID:    tm.UserID,
+			Name:      tm.Name,
+			CreatedAt: tm.CreatedAt,
+		}
+	}
+	return tags, nil
+}
+
+func (tr *tagRepository) Create(ctx context.Context, tag entity.Tag) error {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	if err := executor.WithContext(ctx).Create(&tagModel{
+		ID:        tag.ID,
+		UserID:    tag.UserID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+	}).Error; err != nil {
+		if isDuplicateTagName(err) {
+			return repository.ErrDuplicateTagName
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr *tagRepository) Update(ctx context.Context, tag entity.Tag) error {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	if err := executor.WithContext(ctx).Model(&tagModel{}).Where("id = ?", tag.ID).
+		Update("name", tag.Name).Error; err != nil {
+		if isDuplicateTagName(err) {
+			return repository.ErrDuplicateTagName
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr *tagRepository) Delete(ctx context.Context, id string) error {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	return executor.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&taskTagModel{}, "tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tagModel{}, "id = ?", id).Error
+	})
+}
+
+// isDuplicateTagName reports whether err is a duplicate key of tags, which is the name of a user
+// because IDs are generated.
+func isDuplicateTagName(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry
+}
@@ -2,6 +2,9 @@ package gorm
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,13 +14,21 @@ import (
 )
 
 type taskModel struct {
-	ID          string    `gorm:"type:char(36);primaryKey"`
-	UserID      string    `gorm:"column:user_id"`
-	Title       string    `gorm:"column:title"`
-	Description string    `gorm:"column:description"`
-	DueDate     time.Time `gorm:"column:duedate"`
-	Priority    int       `gorm:"column:priority"`
-	CreatedAt   time.Time `gorm:"column:created_at"`
+	ID          string     `gorm:"type:char(36);primaryKey"`
+	UserID      string     `gorm:"column:user_id"`
+	Title       string     `gorm:"column:title"`
+	Description string     `gorm:"column:description"`
+	DueDate     time.Time  `gorm:"column:duedate"`
+	Priority    int        `gorm:"column:priority"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null;default:todo"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+}
+
+// taskTagModel attaches a tag to a task.
+type taskTagModel struct {
+	TaskID string `gorm:"column:task_id;type:char(36);primaryKey"`
+	TagID  string `gorm:"column:tag_id;type:char(36);primaryKey;index"`
 }
 
 type taskRepository struct {
@@ -38,6 +49,14 @@ func (tr *taskRepository) Get(ctx context.Context, id string) (*entity.Task, err
 
 	var tm taskModel
 	if err := executor.WithContext(ctx).First(&tm, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	tagIDs, err := listTaskTagIDs(ctx, executor, []string{tm.ID})
+	if err != nil {
 		return nil, err
 	}
 
@@ -48,19 +67,79 @@ func (tr *taskRepository) Get(ctx context.Context, id string) (*entity.Task, err
 		Description: tm.Description,
 		DueDate:     tm.DueDate,
 		Priority:    tm.Priority,
+		Status:      tm.Status,
+		CompletedAt: tm.CompletedAt,
 		CreatedAt:   tm.CreatedAt,
+		TagIDs:      tagIDs[tm.ID],
 	}, nil
 }
 
-func (tr *taskRepository) List(ctx context.Context, userID string) ([]entity.Task, error) {
+func (tr *taskRepository) List(ctx context.Context, q repository.TaskQuery) ([]entity.Task, string, error) {
 	executor := tr.db
 	if tx := TxFromCtx(ctx); tx != nil {
 		executor = tx
 	}
 
+	cursor, err := q.DecodeCursor()
+	if err != nil {
+		return nil, "", err
+	}
+	column, ok := taskSortColumns[q.SortKey()]
+	if !ok {
+		return nil, "", fmt.Errorf("invalid sort key: %s", q.SortKey())
+	}
+
+	db := executor.WithContext(ctx).Where("user_id = ?", q.UserID)
+	if q.MinPriority != 0 {
+		db = db.Where("priority >= ?", q.MinPriority)
+	}
+	if q.MaxPriority != 0 {
+		db = db.Where("priority <= ?", q.MaxPriority)
+	}
+	if !q.DueAfter.IsZero() {
+		db = db.Where("duedate >= ?", q.DueAfter)
+	}
+	if !q.DueBefore.IsZero() {
+		db = db.Where("duedate < ?", q.DueBefore)
+	}
+	closed := []string{entity.StatusDone, entity.StatusArchived}
+	if q.Overdue != nil {
+		if *q.Overdue {
+			db = db.Where("duedate < ? AND status NOT IN ?", q.Now, closed)
+		} else {
+			db = db.Where("(duedate >= ? OR status IN ?)", q.Now, closed)
+		}
+	}
+	if q.TitlePrefix != "" {
+		db = db.Where("title LIKE ?", escapeLike(q.TitlePrefix)+"%")
+	}
+	if ids := q.DistinctTagIDs(); len(ids) != 0 {
+		tagged := executor.WithContext(ctx).Model(&taskTagModel{}).Select("task_id").Where("tag_id IN ?", ids)
+		if q.MatchAllTags {
+			tagged = tagged.Group("task_id").Having("COUNT(*) = ?", len(ids))
+		}
+		db = db.Where("id IN (?)", tagged)
+	}
+
+	op, order := ">", "ASC"
+	if q.SortDesc {
+		op, order = "<", "DESC"
+	}
+	if cursor != nil {
+		db = db.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op),
+			cursor.Value(), cursor.Value(), cursor.ID,
+		)
+	}
+	db = db.Order(fmt.Sprintf("%s %s, id %s", column, order, order))
+	if q.Limit > 0 {
+		// One extra row tells whether a next page exists.
+		db = db.Limit(q.Limit + 1)
+	}
+
 	var tms []taskModel
-	if err := executor.WithContext(ctx).Find(&tms, "user_id = ?", userID).Error; err != nil {
-		return nil, err
+	if err = db.Find(&tms).Error; err != nil {
+		return nil, "", err
 	}
 
 	tasks := make([]entity.Task, len(tms))
@@ -72,10 +151,24 @@ func (tr *taskRepository) List(ctx context.Context, userID string) ([]entity.Tas
 			Description: tm.Description,
 			DueDate:     tm.DueDate,
 			Priority:    tm.Priority,
+			Status:      tm.Status,
+			CompletedAt: tm.CompletedAt,
 			CreatedAt:   tm.CreatedAt,
 		}
 	}
-	return tasks, nil
+	page, next := q.Page(tasks)
+	ids := make([]string, len(page))
+	for i, task := range page {
+		ids[i] = task.ID
+	}
+	tagIDs, err := listTaskTagIDs(ctx, executor, ids)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range page {
+		page[i].TagIDs = tagIDs[page[i].ID]
+	}
+	return page, next, nil
 }
 
 func (tr *taskRepository) Create(ctx context.Context, task entity.Task) error {
@@ -84,18 +177,22 @@ func (tr *taskRepository) Create(ctx context.Context, task entity.Task) error {
 		executor = tx
 	}
 
-	if err := executor.WithContext(ctx).Create(&taskModel{
-		ID:          task.ID,
-		UserID:      task.UserID,
-		Title:       task.Title,
-		Description: task.Description,
-		DueDate:     task.DueDate,
-		Priority:    task.Priority,
-		CreatedAt:   task.CreatedAt,
-	}).Error; err != nil {
-		return err
-	}
-	return nil
+	return executor.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&taskModel{
+			ID:          task.ID,
+			UserID:      task.UserID,
+			Title:       task.Title,
+			Description: task.Description,
+			DueDate:     task.DueDate,
+			Priority:    task.Priority,
+			CreatedAt:   task.CreatedAt,
+			Status:      task.Status,
+			CompletedAt: task.CompletedAt,
+		}).Error; err != nil {
+			return err
+		}
+		return insertTaskTags(tx, task.ID, task.TagIDs)
+	})
 }
 
 func (tr *taskRepository) Update(ctx context.Context, task entity.Task) error {
@@ -104,16 +201,35 @@ func (tr *taskRepository) Update(ctx context.Context, task entity.Task) error {
 		executor = tx
 	}
 
-	if err := executor.WithContext(ctx).Model(&taskModel{}).Where("id = ?", task.ID).Updates(&taskModel{
-		Title:       task.Title,
-		Description: task.Description,
-		DueDate:     task.DueDate,
-		Priority:    task.Priority,
-	}).Error; err != nil {
-		return err
-	}
+	return executor.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Select is required so that clearing completed_at (reopening a task) is persisted,
+		// because Updates with a struct skips zero-value fields.
+		if err := tx.Model(&taskModel{}).Where("id = ?", task.ID).
+			Select("title", "description", "duedate", "priority", "status", "completed_at").
+			Updates(&taskModel{
+				Title:       task.Title,
+				Description: task.Description,
+				DueDate:     task.DueDate,
+				Priority:    task.Priority,
+				Status:      task.Status,
+				CompletedAt: task.CompletedAt,
+			}).Error; err != nil {
+			return err
+		}
 
-	return nil
+		// the tags are replaced, but none are attached to a missing task
+		if err := tx.Delete(&taskTagModel{}, "task_id = ?", task.ID).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&taskModel{}).Where("id = ?", task.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+		return insertTaskTags(tx, task.ID, task.TagIDs)
+	})
 }
 
 func (tr *taskRepository) Delete(ctx context.Context, id string) error {
@@ -122,8 +238,50 @@ func (tr *taskRepository) Delete(ctx context.Context, id string) error {
 		executor = tx
 	}
 
-	if err := executor.WithContext(ctx).Delete(&taskModel{}, "id = ?", id).Error; err != nil {
-		return err
+	return executor.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&taskModel{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&taskTagModel{}, "task_id = ?", id).Error
+	})
+}
+
+// listTaskTagIDs returns the tag IDs of the tasks in ascending order, by task ID.
+func listTaskTagIDs(ctx context.Context, executor *gorm.DB, ids []string) (map[string][]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var ttms []taskTagModel
+	if err := executor.WithContext(ctx).Where("task_id IN ?", ids).Order("task_id, tag_id").Find(&ttms).Error; err != nil {
+		return nil, err
+	}
+	tagIDs := make(map[string][]string, len(ids))
+	for _, ttm := range ttms {
+		tagIDs[ttm.TaskID] = append(tagIDs[ttm.TaskID], ttm.TagID)
+	}
+	return tagIDs, nil
+}
+
+func insertTaskTags(tx *gorm.DB, taskID string, tagIDs []string) error {
+	ids := repository.DistinctIDs(tagIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+	ttms := make([]taskTagModel, len(ids))
+	for i, id := range ids {
+		ttms[i] = taskTagModel{TaskID: taskID, TagID: id}
 	}
-	return nil
+	return tx.Create(&ttms).Error
+}
+
+var taskSortColumns = map[string]string{
+	repository.TaskSortDueDate:   "duedate",
+	repository.TaskSortPriority:  "priority",
+	repository.TaskSortCreatedAt: "created_at",
+}
+
+// escapeLike escapes the wildcard characters of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
@@ -10,12 +10,13 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
 func Test_TaskRepository(t *testing.T) {
 	ctx := context.Background()
 
-	if err := db.AutoMigrate(&taskModel{}); err != nil { // migrate
+	if err := db.AutoMigrate(&taskModel{}, &taskTagModel{}); err != nil { // migrate
 		t.Fatal(err)
 	}
 
@@ -29,6 +30,7 @@ func Test_TaskRepository(t *testing.T) {
 		"First Description",
 		time.Now().Add(24*time.Hour),
 		3,
+		time.Now(),
 	)
 	ValidateErr(t, err, nil)
 	task2, err := entity.NewTask(
@@ -37,6 +39,7 @@ func Test_TaskRepository(t *testing.T) {
 		"Second Description",
 		time.Now().Add(48*time.Hour),
 		4,
+		time.Now(),
 	)
 	ValidateErr(t, err, nil)
 
@@ -54,14 +57,43 @@ func Test_TaskRepository(t *testing.T) {
 	}
 
 	// List
-	gottasks, err := repo.List(ctx, userID)
+	gottasks, next, err := repo.List(ctx, repository.TaskQuery{UserID: userID})
 	ValidateErr(t, err, nil)
-	if len(gottasks) != 2 {
-		t.Errorf("want: %v, got: %v", 2, len(gottasks))
+	if len(gottasks) != 2 || next != "" {
+		t.Errorf("want: %v tasks and no cursor, got: %v tasks and cursor %q", 2, len(gottasks), next)
+	}
+
+	// List sorted by due date in descending order, one task per page
+	query := repository.TaskQuery{
+		UserID:      userID,
+		MinPriority: entity.Medium,
+		SortBy:      repository.TaskSortDueDate,
+		SortDesc:    true,
+		Limit:       1,
+	}
+	gottasks, next, err = repo.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task2.ID || next == "" {
+		t.Fatalf("want: first page with %v and a cursor, got: %v and cursor %q", task2.ID, gottasks, next)
+	}
+	query.Cursor = next
+	gottasks, next, err = repo.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task1.ID || next != "" {
+		t.Errorf("want: last page with %v, got: %v and cursor %q", task1.ID, gottasks, next)
+	}
+
+	// List by title prefix
+	gottasks, _, err = repo.List(ctx, repository.TaskQuery{UserID: userID, TitlePrefix: "Second"})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task2.ID {
+		t.Errorf("want: %v, got: %v", task2.ID, gottasks)
 	}
 
 	// Update
 	gottask.Title = "Updated First Task"
+	err = gottask.SetStatus(entity.StatusDone, time.Now())
+	ValidateErr(t, err, nil)
 	err = repo.Update(ctx, *gottask)
 	ValidateErr(t, err, nil)
 
@@ -71,6 +103,9 @@ func Test_TaskRepository(t *testing.T) {
 	if d := cmp.Diff(gottask.Title, "Updated First Task"); len(d) != 0 {
 		t.Errorf("differs: (-want +got)\n%s", d)
 	}
+	if gottask.Status != entity.StatusDone || gottask.CompletedAt == nil {
+		t.Errorf("want: status %v with completed_at, got: %v, %v", entity.StatusDone, gottask.Status, gottask.CompletedAt)
+	}
 
 	// Delete
 	err = repo.Delete(ctx, task1.ID)
@@ -78,7 +113,5 @@ func Test_TaskRepository(t *testing.T) {
 
 	// Get
 	_, err = repo.Get(ctx, task1.ID)
-	if err == nil {
-		t.Errorf("want: %v, got: %v", "record not found", nil)
-	}
+	ValidateErr(t, err, repository.ErrTaskNotFound)
 }
@@ -2,4 +2,7 @@ CREATE DATABASE IF NOT EXISTS `goCleanArcTestDB` DEFAULT CHARACTER SET utf8mb4 C
 USE `goCleanArcTestDB`;
 
 DROP TABLE IF EXISTS tasks CASCADE;
-DROP TABLE IF EXISTS users CASCADE;
\ No newline at end of file
+DROP TABLE IF EXISTS users CASCADE;
+DROP TABLE IF EXISTS refresh_tokens CASCADE;
+DROP TABLE IF EXISTS tags CASCADE;
+DROP TABLE IF EXISTS task_tags CASCADE;
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
@@ -12,10 +13,11 @@ import (
 )
 
 type userModel struct {
-	ID       string `gorm:"type:char(36);primaryKey"`
-	Name     string `gorm:"column:name"`
-	Email    string `gorm:"column:email"`
-	Password string `gorm:"column:password"`
+	ID           string `gorm:"type:char(36);primaryKey"`
+	Name         string `gorm:"column:name"`
+	Email        string `gorm:"column:email;size:255;uniqueIndex"`
+	Password     string `gorm:"column:password"`
+	DueSoonHours int    `gorm:"column:due_soon_hours;not null;default:24"`
 }
 
 type userRepository struct {
@@ -36,14 +38,41 @@ func (ur *userRepository) Get(ctx context.Context, id string) (*entity.User, err
 
 	var um userModel
 	if err := executor.WithContext(ctx).First(&um, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrUserNotFound
+		}
 		return nil, err
 	}
 
 	return &entity.User{
-		ID:       um.ID,
-		Name:     um.Name,
-		Email:    um.Email,
-		Password: um.Password,
+		ID:           um.ID,
+		Name:         um.Name,
+		Email:        um.Email,
+		Password:     um.Password,
+		DueSoonHours: um.DueSoonHours,
+	}, nil
+}
+
+func (ur *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	executor := ur.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	var um userModel
+	if err := executor.WithContext(ctx).First(&um, "email = ?", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &entity.User{
+		ID:           um.ID,
+		Name:         um.Name,
+		Email:        um.Email,
+		Password:     um.Password,
+		DueSoonHours: um.DueSoonHours,
 	}, nil
 }
 
@@ -54,11 +83,15 @@ func (ur *userRepository) Create(ctx context.Context, user entity.User) error {
 	}
 
 	if err := executor.WithContext(ctx).Create(&userModel{
-		ID:       user.ID,
-		Name:     user.Name,
-		Email:    user.Email,
-		Password: user.Password,
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Password:     user.Password,
+		DueSoonHours: user.DueSoonHours,
 	}).Error; err != nil {
+		if isDuplicateEmail(err) {
+			return repository.ErrDuplicateEmail
+		}
 		return err
 	}
 	return nil
@@ -70,11 +103,19 @@ func (ur *userRepository) Update(ctx context.Context, user entity.User) error {
 		executor = tx
 	}
 
-	if err := executor.WithContext(ctx).Model(&userModel{}).Where("id = ?", user.ID).Updates(&userModel{
-		Name:     user.Name,
-		Email:    user.Email,
-		Password: user.Password,
-	}).Error; err != nil {
+	// Select is required so that a zero due_soon_hours is persisted,
+	// because Updates with a struct skips zero-value fields.
+	if err := executor.WithContext(ctx).Model(&userModel{}).Where("id = ?", user.ID).
+		Select("name", "email", "password", "due_soon_hours").
+		Updates(&userModel{
+			Name:         user.Name,
+			Email:        user.Email,
+			Password:     user.Password,
+			DueSoonHours: user.DueSoonHours,
+		}).Error; err != nil {
+		if isDuplicateEmail(err) {
+			return repository.ErrDuplicateEmail
+		}
 		return err
 	}
 	return nil
@@ -109,3 +150,13 @@ func (ur *userRepository) LockUserByEmail(ctx context.Context, email string) (bo
 
 	return true, nil
 }
+
+// duplicateEntry is the MySQL error number of a duplicate key.
+const duplicateEntry = 1062
+
+// isDuplicateEmail reports whether err is a duplicate key of users, which is the email
+// because IDs are generated.
+func isDuplicateEmail(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry
+}
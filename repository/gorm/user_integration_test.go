@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
 func Test_UserRepository(t *testing.T) {
@@ -34,6 +35,16 @@ func Test_UserRepository(t *testing.T) {
 		t.Errorf("want: %v, got: %v", user, gotUser)
 	}
 
+	// GetByEmail
+	gotUser, err = repo.GetByEmail(ctx, "test@gmail.com")
+	ValidateErr(t, err, nil)
+	if !reflect.DeepEqual(user, gotUser) {
+		t.Errorf("want: %v, got: %v", user, gotUser)
+	}
+
+	_, err = repo.GetByEmail(ctx, "unknown@gmail.com")
+	ValidateErr(t, err, repository.ErrUserNotFound)
+
 	// LockUserByEmail
 	exists, err := repo.LockUserByEmail(ctx, "test@gmail.com")
 	ValidateErr(t, err, nil)
@@ -43,6 +54,8 @@ func Test_UserRepository(t *testing.T) {
 
 	// Update
 	gotUser.Name = "updatedName"
+	err = gotUser.SetDueSoonHours(48)
+	ValidateErr(t, err, nil)
 	err = repo.Update(ctx, *gotUser)
 	ValidateErr(t, err, nil)
 
@@ -57,7 +70,5 @@ func Test_UserRepository(t *testing.T) {
 	ValidateErr(t, err, nil)
 
 	_, err = repo.Get(ctx, user.ID)
-	if err == nil {
-		t.Errorf("want: %v, got: %v", nil, err)
-	}
+	ValidateErr(t, err, repository.ErrUserNotFound)
 }
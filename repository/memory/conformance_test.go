@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/repositorytest"
+)
+
+func Test_TaskRepository_conformance(t *testing.T) {
+	repositorytest.TestTaskRepository(t, func(*testing.T) (repository.TaskRepository, repository.TransactionRepository) {
+		store := NewStore()
+		return NewTaskRepository(store), NewTransactionRepository(store)
+	})
+}
+
+func Test_UserRepository_conformance(t *testing.T) {
+	repositorytest.TestUserRepository(t, func(*testing.T) (repository.UserRepository, repository.TransactionRepository) {
+		store := NewStore()
+		return NewUserRepository(store), NewTransactionRepository(store)
+	})
+}
+
+func Test_TagRepository_conformance(t *testing.T) {
+	repositorytest.TestTagRepository(t, func(*testing.T) (repository.TagRepository, repository.TaskRepository, repository.TransactionRepository) {
+		store := NewStore()
+		return NewTagRepository(store), NewTaskRepository(store), NewTransactionRepository(store)
+	})
+}
+
+func Test_RefreshTokenRepository_conformance(t *testing.T) {
+	repositorytest.TestRefreshTokenRepository(t, func(*testing.T) (repository.RefreshTokenRepository, repository.TransactionRepository) {
+		store := NewStore()
+		return NewRefreshTokenRepository(store), NewTransactionRepository(store)
+	})
+}
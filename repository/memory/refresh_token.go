@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+var errDuplicateRefreshToken = errors.New("refresh token already exists")
+
+type refreshTokenRepository struct {
+	store *Store
+}
+
+func NewRefreshTokenRepository(store *Store) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		store: store,
+	}
+}
+
+func (rr *refreshTokenRepository) Get(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	var ok bool
+	rr.store.read(ctx, func(snap *snapshot) {
+		token, ok = snap.refreshTokens[tokenHash]
+	})
+	if !ok {
+		return nil, repository.ErrRefreshTokenNotFound
+	}
+	return &token, nil
+}
+
+func (rr *refreshTokenRepository) Create(ctx context.Context, token entity.RefreshToken) error {
+	return rr.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.refreshTokens[token.TokenHash]; ok {
+			return errDuplicateRefreshToken
+		}
+		tx.refreshTokens()[token.TokenHash] = token
+		return nil
+	})
+}
+
+func (rr *refreshTokenRepository) MarkUsed(ctx context.Context, tokenHash string) (bool, error) {
+	marked := false
+	err := rr.store.write(ctx, func(tx *transaction) error {
+		token, ok := tx.next.refreshTokens[tokenHash]
+		if !ok || token.Used || token.Revoked {
+			return nil
+		}
+		token.Used = true
+		tx.refreshTokens()[tokenHash] = token
+		marked = true
+		return nil
+	})
+	return marked, err
+}
+
+func (rr *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return rr.revoke(ctx, func(token entity.RefreshToken) bool {
+		return token.FamilyID == familyID
+	})
+}
+
+func (rr *refreshTokenRepository) RevokeUser(ctx context.Context, userID string) error {
+	return rr.revoke(ctx, func(token entity.RefreshToken) bool {
+		return token.UserID == userID
+	})
+}
+
+func (rr *refreshTokenRepository) revoke(ctx context.Context, match func(token entity.RefreshToken) bool) error {
+	return rr.store.write(ctx, func(tx *transaction) error {
+		for hash, token := range tx.next.refreshTokens {
+			if !match(token) || token.Revoked {
+				continue
+			}
+			token.Revoked = true
+			tx.refreshTokens()[hash] = token
+		}
+		return nil
+	})
+}
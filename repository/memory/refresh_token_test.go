@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_RefreshTokenRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := NewRefreshTokenRepository(NewStore())
+
+	expiresAt := time.Now().Add(time.Hour)
+	token1 := entity.RefreshToken{TokenHash: "1", FamilyID: "family", UserID: "user", ExpiresAt: expiresAt}
+	token2 := entity.RefreshToken{TokenHash: "2", FamilyID: "family", UserID: "user", ExpiresAt: expiresAt}
+	other := entity.RefreshToken{TokenHash: "3", FamilyID: "other", UserID: "user", ExpiresAt: expiresAt}
+	for _, token := range []entity.RefreshToken{token1, token2, other} {
+		if err := repo.Create(ctx, token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	// MarkUsed succeeds only once
+	for i, want := range []bool{true, false} {
+		marked, err := repo.MarkUsed(ctx, token1.TokenHash)
+		if err != nil || marked != want {
+			t.Errorf("MarkUsed() call %d = %v, %v, want %v", i+1, marked, err, want)
+		}
+	}
+
+	// RevokeFamily
+	if err := repo.RevokeFamily(ctx, "family"); err != nil {
+		t.Fatalf("RevokeFamily() error = %v", err)
+	}
+	if marked, err := repo.MarkUsed(ctx, token2.TokenHash); err != nil || marked {
+		t.Errorf("want: a revoked token not to be marked, got: %v, %v", marked, err)
+	}
+	got, err := repo.Get(ctx, other.TokenHash)
+	if err != nil || got.Revoked {
+		t.Errorf("want: the token of another family to stay valid, got: %v, %v", got, err)
+	}
+
+	// RevokeUser
+	if err = repo.RevokeUser(ctx, "user"); err != nil {
+		t.Fatalf("RevokeUser() error = %v", err)
+	}
+	if got, err = repo.Get(ctx, other.TokenHash); err != nil || !got.Revoked {
+		t.Errorf("want: every token of the user revoked, got: %v, %v", got, err)
+	}
+
+	if _, err = repo.Get(ctx, "missing"); !errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		t.Errorf("want: %v, got: %v", repository.ErrRefreshTokenNotFound, err)
+	}
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// revocationRepository keeps revocations in the memory of the process.
+// It is the fallback when Redis is not configured; revocations are neither shared
+// between instances nor kept across restarts.
+type revocationRepository struct {
+	mu    sync.RWMutex
+	clock entity.Clock
+	// retention is the longest time a token is accepted after it was issued.
+	retention time.Duration
+	// tokens maps a jti to the expiry of the token.
+	tokens map[string]time.Time
+	// users maps a user ID to the cutoff before which its tokens are revoked.
+	// The cutoff has the whole-second precision of the iat claim it is compared with.
+	users map[string]userCutoff
+}
+
+type userCutoff struct {
+	before    time.Time
+	expiresAt time.Time
+}
+
+func NewRevocationRepository(conf *config.AuthConfig, clock entity.Clock) repository.RevocationRepository {
+	return &revocationRepository{
+		clock:     clock,
+		retention: conf.AccessTokenTTL + conf.Leeway,
+		tokens:    map[string]time.Time{},
+		users:     map[string]userCutoff{},
+	}
+}
+
+func (vr *revocationRepository) RevokeToken(_ context.Context, jti string, expiresAt time.Time) error {
+	vr.mu.Lock()
+	defer vr.mu.Unlock()
+
+	vr.purge()
+	vr.tokens[jti] = expiresAt
+	return nil
+}
+
+func (vr *revocationRepository) RevokeUserTokens(_ context.Context, userID string, before time.Time) error {
+	vr.mu.Lock()
+	defer vr.mu.Unlock()
+
+	vr.purge()
+	vr.users[userID] = userCutoff{
+		before:    before.Truncate(time.Second),
+		expiresAt: vr.clock.Now().Add(vr.retention),
+	}
+	return nil
+}
+
+func (vr *revocationRepository) IsRevoked(_ context.Context, claims repository.TokenClaims) (bool, error) {
+	vr.mu.RLock()
+	defer vr.mu.RUnlock()
+
+	now := vr.clock.Now()
+	if expiresAt, ok := vr.tokens[claims.JTI]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+	if cutoff, ok := vr.users[claims.UserID]; ok && now.Before(cutoff.expiresAt) {
+		// A token issued in the second of the cutoff is accepted, so that logging in right after
+		// the revocation works; iat cannot tell it apart from a token issued earlier in that second.
+		return claims.IssuedAt.Before(cutoff.before), nil
+	}
+	return false, nil
+}
+
+// purge drops the entries that can no longer match an accepted token. The caller must hold the write lock.
+func (vr *revocationRepository) purge() {
+	now := vr.clock.Now()
+	for jti, expiresAt := range vr.tokens {
+		if !now.Before(expiresAt) {
+			delete(vr.tokens, jti)
+		}
+	}
+	for userID, cutoff := range vr.users {
+		if !now.Before(cutoff.expiresAt) {
+			delete(vr.users, userID)
+		}
+	}
+}
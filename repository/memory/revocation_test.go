@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func Test_RevocationRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: now}
+	repo := NewRevocationRepository(&config.AuthConfig{AccessTokenTTL: 15 * time.Minute}, clock)
+
+	old := repository.TokenClaims{JTI: "old", UserID: "user", IssuedAt: now.Add(-time.Minute), ExpiresAt: now.Add(14 * time.Minute)}
+	current := repository.TokenClaims{JTI: "current", UserID: "user", IssuedAt: now, ExpiresAt: now.Add(15 * time.Minute)}
+	other := repository.TokenClaims{JTI: "other", UserID: "other", IssuedAt: now.Add(-time.Minute), ExpiresAt: now.Add(14 * time.Minute)}
+
+	isRevoked := func(claims repository.TokenClaims) bool {
+		t.Helper()
+		revoked, err := repo.IsRevoked(ctx, claims)
+		if err != nil {
+			t.Fatalf("IsRevoked() error = %v", err)
+		}
+		return revoked
+	}
+
+	if isRevoked(current) {
+		t.Fatalf("IsRevoked() = true before any revocation")
+	}
+
+	// RevokeToken
+	if err := repo.RevokeToken(ctx, current.JTI, current.ExpiresAt); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if !isRevoked(current) {
+		t.Errorf("IsRevoked() = false for a revoked token")
+	}
+	if isRevoked(old) {
+		t.Errorf("IsRevoked() = true for another token of the user")
+	}
+
+	// RevokeUserTokens
+	if err := repo.RevokeUserTokens(ctx, "user", now.Add(-time.Second)); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+	if !isRevoked(old) {
+		t.Errorf("IsRevoked() = false for a token issued before the cutoff")
+	}
+	if isRevoked(repository.TokenClaims{JTI: "new", UserID: "user", IssuedAt: now}) {
+		t.Errorf("IsRevoked() = true for a token issued after the cutoff")
+	}
+	if isRevoked(other) {
+		t.Errorf("IsRevoked() = true for a token of another user")
+	}
+
+	// a login in the second of the cutoff, whose iat is truncated to the second
+	if err := repo.RevokeUserTokens(ctx, "relogin", now.Add(500*time.Millisecond)); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+	if isRevoked(repository.TokenClaims{JTI: "relogin", UserID: "relogin", IssuedAt: now}) {
+		t.Errorf("IsRevoked() = true for a token issued in the second of the cutoff")
+	}
+	if !isRevoked(repository.TokenClaims{JTI: "stale", UserID: "relogin", IssuedAt: now.Add(-time.Second)}) {
+		t.Errorf("IsRevoked() = false for a token issued in the second before the cutoff")
+	}
+
+	// expired entries are forgotten
+	clock.now = now.Add(time.Hour)
+	if isRevoked(current) || isRevoked(old) {
+		t.Errorf("IsRevoked() = true after the revocations expired")
+	}
+}
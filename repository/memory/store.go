@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// Store keeps the data of the in-memory repositories as an immutable snapshot.
+// Readers load the committed snapshot without locking. Writers are serialized: a transaction
+// works on a copy of the snapshot, cloning a table on its first write to it, and commits by
+// swapping the copy in, so a rollback simply drops the copy.
+// Every write clones the whole table, which is fine for development and tests but not beyond.
+type Store struct {
+	// writeMu is held by the transaction or the single write that is building the next snapshot.
+	writeMu   sync.Mutex
+	committed atomic.Pointer[snapshot]
+}
+
+type snapshot struct {
+	tasks         map[string]entity.Task
+	users         map[string]entity.User
+	refreshTokens map[string]entity.RefreshToken
+	tags          map[string]entity.Tag
+}
+
+func NewStore() *Store {
+	s := &Store{}
+	s.committed.Store(&snapshot{
+		tasks:         map[string]entity.Task{},
+		users:         map[string]entity.User{},
+		refreshTokens: map[string]entity.RefreshToken{},
+		tags:          map[string]entity.Tag{},
+	})
+	return s
+}
+
+// transaction is the next snapshot under construction.
+type transaction struct {
+	store *Store
+	mu    sync.Mutex
+	next  snapshot
+	// the tables cloned so far, the others are still shared with the committed snapshot
+	tasksCloned, usersCloned, refreshTokensCloned, tagsCloned bool
+}
+
+type txKey struct{}
+
+func (s *Store) begin() *transaction {
+	s.writeMu.Lock()
+	return &transaction{store: s, next: *s.committed.Load()}
+}
+
+func (tx *transaction) commit() {
+	tx.store.committed.Store(&tx.next)
+	tx.store.writeMu.Unlock()
+}
+
+func (tx *transaction) rollback() {
+	tx.store.writeMu.Unlock()
+}
+
+func (s *Store) txFromCtx(ctx context.Context) *transaction {
+	tx, ok := ctx.Value(txKey{}).(*transaction)
+	if !ok || tx.store != s {
+		return nil
+	}
+	return tx
+}
+
+// read calls fn with the snapshot visible to ctx, which includes the writes of its transaction.
+func (s *Store) read(ctx context.Context, fn func(snap *snapshot)) {
+	if tx := s.txFromCtx(ctx); tx != nil {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		fn(&tx.next)
+		return
+	}
+	fn(s.committed.Load())
+}
+
+// write calls fn with the transaction of ctx, or commits fn on its own outside a transaction.
+func (s *Store) write(ctx context.Context, fn func(tx *transaction) error) error {
+	if tx := s.txFromCtx(ctx); tx != nil {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		return fn(tx)
+	}
+	tx := s.begin()
+	if err := fn(tx); err != nil {
+		tx.rollback()
+		return err
+	}
+	tx.commit()
+	return nil
+}
+
+func (tx *transaction) tasks() map[string]entity.Task {
+	if !tx.tasksCloned {
+		tx.next.tasks = maps.Clone(tx.next.tasks)
+		tx.tasksCloned = true
+	}
+	return tx.next.tasks
+}
+
+func (tx *transaction) users() map[string]entity.User {
+	if !tx.usersCloned {
+		tx.next.users = maps.Clone(tx.next.users)
+		tx.usersCloned = true
+	}
+	return tx.next.users
+}
+
+func (tx *transaction) refreshTokens() map[string]entity.RefreshToken {
+	if !tx.refreshTokensCloned {
+		tx.next.refreshTokens = maps.Clone(tx.next.refreshTokens)
+		tx.refreshTokensCloned = true
+	}
+	return tx.next.refreshTokens
+}
+
+func (tx *transaction) tags() map[string]entity.Tag {
+	if !tx.tagsCloned {
+		tx.next.tags = maps.Clone(tx.next.tags)
+		tx.tagsCloned = true
+	}
+	return tx.next.tags
+}
+
+type transactionRepository struct {
+	store *Store
+}
+
+func NewTransactionRepository(store *Store) repository.TransactionRepository {
+	return &transactionRepository{
+		store: store,
+	}
+}
+
+// Transaction runs fn in isolation from every other write to the store.
+// A nested transaction joins the outer one.
+func (tr *transactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if tr.store.txFromCtx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx := tr.store.begin()
+	defer func() {
+		if p := recover(); p != nil {
+			tx.rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.rollback()
+			return
+		}
+		tx.commit()
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
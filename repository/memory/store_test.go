@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_TransactionRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewStore()
+	tr := NewTransactionRepository(store)
+	taskRepo := NewTaskRepository(store)
+	userRepo := NewUserRepository(store)
+
+	user := entity.User{ID: "user", Name: "User", Email: "user@example.com"}
+	task := entity.Task{ID: "task", UserID: user.ID, Title: "Task", DueDate: time.Now()}
+	errRollback := errors.New("rollback")
+
+	// Rollback drops every write of the transaction
+	err := tr.Transaction(ctx, func(ctx context.Context) error {
+		if err := userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := taskRepo.Create(ctx, task); err != nil {
+			return err
+		}
+		if _, err := taskRepo.Get(ctx, task.ID); err != nil {
+			t.Errorf("want: the transaction to read its own write, got: %v", err)
+		}
+		if _, err := taskRepo.Get(context.Background(), task.ID); !errors.Is(err, repository.ErrTaskNotFound) {
+			t.Errorf("want: uncommitted writes to be invisible outside, got: %v", err)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errRollback)
+	}
+	if _, err = userRepo.Get(ctx, user.ID); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("want: %v after rollback, got: %v", repository.ErrUserNotFound, err)
+	}
+	if _, err = taskRepo.Get(ctx, task.ID); !errors.Is(err, repository.ErrTaskNotFound) {
+		t.Errorf("want: %v after rollback, got: %v", repository.ErrTaskNotFound, err)
+	}
+
+	// Commit, with a nested transaction joining the outer one
+	err = tr.Transaction(ctx, func(ctx context.Context) error {
+		if err := userRepo.Create(ctx, user); err != nil { //nolint: govet // shadowing is intended
+			return err
+		}
+		return tr.Transaction(ctx, func(ctx context.Context) error {
+			return taskRepo.Create(ctx, task)
+		})
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	if _, err = userRepo.Get(ctx, user.ID); err != nil {
+		t.Errorf("want: the user after commit, got: %v", err)
+	}
+	if _, err = taskRepo.Get(ctx, task.ID); err != nil {
+		t.Errorf("want: the task after commit, got: %v", err)
+	}
+
+	// a panic rolls back and leaves the store usable
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("want: the panic to be propagated")
+			}
+		}()
+		_ = tr.Transaction(ctx, func(ctx context.Context) error {
+			_ = taskRepo.Delete(ctx, task.ID)
+			panic("boom")
+		})
+	}()
+	if _, err = taskRepo.Get(ctx, task.ID); err != nil {
+		t.Errorf("want: the task to survive the panic, got: %v", err)
+	}
+}
+
+func Test_TransactionRepository_concurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewStore()
+	tr := NewTransactionRepository(store)
+	userRepo := NewUserRepository(store)
+
+	// concurrent sign-ups with the same email are serialized, so only one succeeds
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := tr.Transaction(ctx, func(ctx context.Context) error {
+				exists, err := userRepo.LockUserByEmail(ctx, "same@example.com")
+				if err != nil || exists {
+					return err
+				}
+				if err = userRepo.Create(ctx, entity.User{ID: string(rune('a' + i)), Email: "same@example.com"}); err != nil {
+					return err
+				}
+				mu.Lock()
+				created++
+				mu.Unlock()
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Transaction() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if created != 1 {
+		t.Errorf("want: 1 user created, got: %v", created)
+	}
+}
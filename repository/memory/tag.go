@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+var errDuplicateTagID = errors.New("tag with this id already exists")
+
+type tagRepository struct {
+	store *Store
+}
+
+func NewTagRepository(store *Store) repository.TagRepository {
+	return &tagRepository{
+		store: store,
+	}
+}
+
+func (tr *tagRepository) Get(ctx context.Context, id string) (*entity.Tag, error) {
+	var tag entity.Tag
+	var ok bool
+	tr.store.read(ctx, func(snap *snapshot) {
+		tag, ok = snap.tags[id]
+	})
+	if !ok {
+		return nil, repository.ErrTagNotFound
+	}
+	return &tag, nil
+}
+
+func (tr *tagRepository) List(ctx context.Context, userID string) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	tr.store.read(ctx, func(snap *snapshot) {
+		for _, tag := range snap.tags {
+			if tag.UserID == userID {
+				tags = append(tags, tag)
+			}
+		}
+	})
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (tr *tagRepository) Create(ctx context.Context, tag entity.Tag) error {
+	return tr.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.tags[tag.ID]; ok {
+			return errDuplicateTagID
+		}
+		if findTagByName(tx.next.tags, tag.UserID, tag.Name) != nil {
+			return repository.ErrDuplicateTagName
+		}
+		tx.tags()[tag.ID] = tag
+		return nil
+	})
+}
+
+// Update does nothing when no tag has the ID, like an UPDATE matching no rows.
+// The owner and the creation time of a tag are kept.
+func (tr *tagRepository) Update(ctx context.Context, tag entity.Tag) error {
+	return tr.store.write(ctx, func(tx *transaction) error {
+		old, ok := tx.next.tags[tag.ID]
+		if !ok {
+			return nil
+		}
+		tag.UserID, tag.CreatedAt = old.UserID, old.CreatedAt
+		if other := findTagByName(tx.next.tags, tag.UserID, tag.Name); other != nil && other.ID != tag.ID {
+			return repository.ErrDuplicateTagName
+		}
+		tx.tags()[tag.ID] = tag
+		return nil
+	})
+}
+
+func (tr *tagRepository) Delete(ctx context.Context, id string) error {
+	return tr.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.tags[id]; !ok {
+			return nil
+		}
+		delete(tx.tags(), id)
+		for taskID, task := range tx.next.tasks {
+			if i, found := slices.BinarySearch(task.TagIDs, id); found {
+				task = copyTask(task)
+				task.TagIDs = slices.Delete(task.TagIDs, i, i+1)
+				tx.tasks()[taskID] = task
+			}
+		}
+		return nil
+	})
+}
+
+func findTagByName(tags map[string]entity.Tag, userID, name string) *entity.Tag {
+	for _, tag := range tags {
+		if tag.UserID == userID && tag.Name == name {
+			tag := tag
+			return &tag
+		}
+	}
+	return nil
+}
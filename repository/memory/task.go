@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+var errDuplicateTaskID = errors.New("task with this id already exists")
+
+type taskRepository struct {
+	store *Store
+}
+
+func NewTaskRepository(store *Store) repository.TaskRepository {
+	return &taskRepository{
+		store: store,
+	}
+}
+
+// copyTask keeps the stored task from sharing CompletedAt and TagIDs with the caller.
+// It also drops Tags, which are not stored, and sorts TagIDs.
+func copyTask(task entity.Task) entity.Task {
+	if task.CompletedAt != nil {
+		completedAt := *task.CompletedAt
+		task.CompletedAt = &completedAt
+	}
+	if len(task.TagIDs) == 0 {
+		task.TagIDs = nil
+	} else {
+		task.TagIDs = slices.Clone(task.TagIDs)
+		slices.Sort(task.TagIDs)
+	}
+	task.Tags = nil
+	return task
+}
+
+func (tr *taskRepository) Get(ctx context.Context, id string) (*entity.Task, error) {
+	var task entity.Task
+	var ok bool
+	tr.store.read(ctx, func(snap *snapshot) {
+		task, ok = snap.tasks[id]
+	})
+	if !ok {
+		return nil, repository.ErrTaskNotFound
+	}
+	task = copyTask(task)
+	return &task, nil
+}
+
+func (tr *taskRepository) List(ctx context.Context, q repository.TaskQuery) ([]entity.Task, string, error) {
+	var tasks []entity.Task
+	tr.store.read(ctx, func(snap *snapshot) {
+		for _, task := range snap.tasks {
+			if task.UserID == q.UserID {
+				tasks = append(tasks, copyTask(task))
+			}
+		}
+	})
+	return repository.ApplyTaskQuery(tasks, q)
+}
+
+func (tr *taskRepository) Create(ctx context.Context, task entity.Task) error {
+	return tr.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.tasks[task.ID]; ok {
+			return errDuplicateTaskID
+		}
+		tx.tasks()[task.ID] = copyTask(task)
+		return nil
+	})
+}
+
+// Update does nothing when no task has the ID, like an UPDATE matching no rows.
+// The owner and the creation time of a task are kept.
+func (tr *taskRepository) Update(ctx context.Context, task entity.Task) error {
+	return tr.store.write(ctx, func(tx *transaction) error {
+		if old, ok := tx.next.tasks[task.ID]; ok {
+			task.UserID, task.CreatedAt = old.UserID, old.CreatedAt
+			tx.tasks()[task.ID] = copyTask(task)
+		}
+		return nil
+	})
+}
+
+func (tr *taskRepository) Delete(ctx context.Context, id string) error {
+	return tr.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.tasks[id]; ok {
+			delete(tx.tasks(), id)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_TaskRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := NewTaskRepository(NewStore())
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	task1 := entity.Task{ID: "1", UserID: "user", Title: "First Task", DueDate: now.Add(24 * time.Hour), Priority: 3, CreatedAt: now}
+	task2 := entity.Task{ID: "2", UserID: "user", Title: "Second Task", DueDate: now.Add(48 * time.Hour), Priority: 4, CreatedAt: now.Add(time.Second)}
+	other := entity.Task{ID: "3", UserID: "other", Title: "Other Task", DueDate: now, Priority: 3, CreatedAt: now}
+
+	for _, task := range []entity.Task{task1, task2, other} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	// List
+	got, next, err := repo.List(ctx, repository.TaskQuery{UserID: "user", SortBy: repository.TaskSortDueDate, SortDesc: true, Limit: 1})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != task2.ID || next == "" {
+		t.Errorf("want: first page with %v and a cursor, got: %v and cursor %q", task2.ID, got, next)
+	}
+
+	// Update keeps the caller from changing the stored task afterwards
+	completedAt := now
+	task1.CompletedAt = &completedAt
+	task1.Status = entity.StatusDone
+	if err = repo.Update(ctx, task1); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	completedAt = completedAt.Add(time.Hour)
+	gottask, err := repo.Get(ctx, task1.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if gottask.Status != entity.StatusDone || gottask.CompletedAt == nil || !gottask.CompletedAt.Equal(now) {
+		t.Errorf("want: done at %v, got: %v at %v", now, gottask.Status, gottask.CompletedAt)
+	}
+
+	// Update of a missing task does not create it
+	if err = repo.Update(ctx, entity.Task{ID: "missing", UserID: "user"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err = repo.Get(ctx, "missing"); !errors.Is(err, repository.ErrTaskNotFound) {
+		t.Errorf("want: %v, got: %v", repository.ErrTaskNotFound, err)
+	}
+
+	// Delete
+	if err = repo.Delete(ctx, task1.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = repo.Get(ctx, task1.ID); !errors.Is(err, repository.ErrTaskNotFound) {
+		t.Errorf("want: %v, got: %v", repository.ErrTaskNotFound, err)
+	}
+}
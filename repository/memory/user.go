@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+var errDuplicateUserID = errors.New("user with this id already exists")
+
+type userRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepository{
+		store: store,
+	}
+}
+
+func (ur *userRepository) Get(ctx context.Context, id string) (*entity.User, error) {
+	var user entity.User
+	var ok bool
+	ur.store.read(ctx, func(snap *snapshot) {
+		user, ok = snap.users[id]
+	})
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (ur *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	var user *entity.User
+	ur.store.read(ctx, func(snap *snapshot) {
+		user = findUserByEmail(snap.users, email)
+	})
+	if user == nil {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (ur *userRepository) Create(ctx context.Context, user entity.User) error {
+	return ur.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.users[user.ID]; ok {
+			return errDuplicateUserID
+		}
+		if findUserByEmail(tx.next.users, user.Email) != nil {
+			return repository.ErrDuplicateEmail
+		}
+		tx.users()[user.ID] = user
+		return nil
+	})
+}
+
+// Update does nothing when no user has the ID, like an UPDATE matching no rows.
+func (ur *userRepository) Update(ctx context.Context, user entity.User) error {
+	return ur.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.users[user.ID]; !ok {
+			return nil
+		}
+		if other := findUserByEmail(tx.next.users, user.Email); other != nil && other.ID != user.ID {
+			return repository.ErrDuplicateEmail
+		}
+		tx.users()[user.ID] = user
+		return nil
+	})
+}
+
+func (ur *userRepository) Delete(ctx context.Context, id string) error {
+	return ur.store.write(ctx, func(tx *transaction) error {
+		if _, ok := tx.next.users[id]; ok {
+			delete(tx.users(), id)
+		}
+		return nil
+	})
+}
+
+// LockUserByEmail only has to report whether the email is taken,
+// since a transaction already excludes every other write to the store.
+func (ur *userRepository) LockUserByEmail(ctx context.Context, email string) (bool, error) {
+	var found bool
+	ur.store.read(ctx, func(snap *snapshot) {
+		found = findUserByEmail(snap.users, email) != nil
+	})
+	return found, nil
+}
+
+func findUserByEmail(users map[string]entity.User, email string) *entity.User {
+	for _, user := range users {
+		if user.Email == email {
+			user := user
+			return &user
+		}
+	}
+	return nil
+}
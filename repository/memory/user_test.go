@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_UserRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := NewUserRepository(NewStore())
+
+	user1 := entity.User{ID: "1", Name: "First", Email: "first@example.com"}
+	user2 := entity.User{ID: "2", Name: "Second", Email: "second@example.com"}
+	for _, user := range []entity.User{user1, user2} {
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	// Create with a taken email
+	err := repo.Create(ctx, entity.User{ID: "3", Email: user1.Email})
+	if !errors.Is(err, repository.ErrDuplicateEmail) {
+		t.Errorf("want: %v, got: %v", repository.ErrDuplicateEmail, err)
+	}
+
+	// GetByEmail and LockUserByEmail
+	got, err := repo.GetByEmail(ctx, user2.Email)
+	if err != nil || got.ID != user2.ID {
+		t.Errorf("want: %v, got: %v, %v", user2.ID, got, err)
+	}
+	exists, err := repo.LockUserByEmail(ctx, "nobody@example.com")
+	if err != nil || exists {
+		t.Errorf("want: no user with the email, got: %v, %v", exists, err)
+	}
+
+	// Update to a taken email
+	user2.Email = user1.Email
+	if err = repo.Update(ctx, user2); !errors.Is(err, repository.ErrDuplicateEmail) {
+		t.Errorf("want: %v, got: %v", repository.ErrDuplicateEmail, err)
+	}
+	user2.Email = "renamed@example.com"
+	if err = repo.Update(ctx, user2); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err = repo.GetByEmail(ctx, "second@example.com"); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("want: %v for the previous email, got: %v", repository.ErrUserNotFound, err)
+	}
+
+	// Delete
+	if err = repo.Delete(ctx, user1.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = repo.Get(ctx, user1.ID); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("want: %v, got: %v", repository.ErrUserNotFound, err)
+	}
+}
@@ -0,0 +1,82 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Dialect holds what the Migrator has to do differently for each database.
+type Dialect struct {
+	Name string
+	// Lock blocks until conn holds the migration lock of the database, and Unlock releases it.
+	Lock   func(ctx context.Context, conn *sql.Conn) error
+	Unlock func(ctx context.Context, conn *sql.Conn) error
+	// Transactional reports whether DDL can be rolled back, so that a migration applies completely or not at all.
+	Transactional bool
+	// Bind rewrites the ? placeholders of a query.
+	Bind func(query string) string
+}
+
+// lockName identifies the migration lock among the other advisory locks of the database.
+const lockName = "schema_migrations"
+
+// MySQL locks with GET_LOCK. DDL commits implicitly in MySQL, so a migration that fails halfway
+// leaves the statements before the failure applied, and is best kept to a single statement.
+var MySQL = Dialect{
+	Name: "mysql",
+	Lock: func(ctx context.Context, conn *sql.Conn) error {
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, 60).Scan(&acquired); err != nil {
+			return err
+		}
+		if acquired.Int64 != 1 {
+			return errors.New("timed out waiting for the migration lock")
+		}
+		return nil
+	},
+	Unlock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName)
+		return err
+	},
+	Bind: func(query string) string { return query },
+}
+
+// Postgres locks with a session level advisory lock.
+var Postgres = Dialect{
+	Name: "postgres",
+	Lock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext($1))", lockName)
+		return err
+	},
+	Unlock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", lockName)
+		return err
+	},
+	Transactional: true,
+	Bind: func(query string) string {
+		var b strings.Builder
+		n := 0
+		for _, r := range query {
+			if r == '?' {
+				n++
+				fmt.Fprintf(&b, "$%d", n)
+				continue
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	},
+}
+
+// SQLite has no lock to take: every migration runs in a transaction holding the write lock
+// of the database, which checks again that the migration is still pending.
+var SQLite = Dialect{
+	Name:          "sqlite",
+	Lock:          func(context.Context, *sql.Conn) error { return nil },
+	Unlock:        func(context.Context, *sql.Conn) error { return nil },
+	Transactional: true,
+	Bind:          func(query string) string { return query },
+}
@@ -0,0 +1,311 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+)
+
+var (
+	// ErrChecksumMismatch means an applied migration was edited afterwards.
+	// Fix it with a new migration instead of editing the applied one.
+	ErrChecksumMismatch = errors.New("applied migration has been modified")
+	// ErrUnknownMigration means the database has a migration this build does not know,
+	// which happens when it was migrated by a newer build.
+	ErrUnknownMigration = errors.New("applied migration is unknown")
+	ErrUnknownVersion   = errors.New("no migration has the version")
+)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	checksum CHAR(64) NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`
+
+// Status is the state of one migration in the database.
+type Status struct {
+	Version int
+	Name    string
+	// AppliedAt is nil while the migration is pending.
+	AppliedAt *time.Time
+	// Modified is set when the migration was edited after it was applied.
+	Modified bool
+	// Unknown is set when the database has the migration but this build does not.
+	Unknown bool
+}
+
+type appliedMigration struct {
+	version   int
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrator applies the migrations of one dialect and records them in the schema_migrations table.
+// Every operation holds the migration lock of the database, so that instances starting at the
+// same time do not apply a migration twice.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+func New(db *sql.DB, dialect Dialect, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		log.Error("Failed to load migrations", log.Fstring("dialect", dialect.Name), log.Ferror(err))
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+// Latest returns the version of the newest migration, or 0 when there is none.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration and returns how many it applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.To(ctx, m.Latest())
+}
+
+// Down rolls back the most recently applied migration. It returns 0 when none is applied.
+func (m *Migrator) Down(ctx context.Context) (int, error) {
+	count := 0
+	err := m.locked(ctx, func(conn *sql.Conn, applied map[int]appliedMigration) error {
+		var last *Migration
+		for i := range m.migrations {
+			if _, ok := applied[m.migrations[i].Version]; ok {
+				last = &m.migrations[i]
+			}
+		}
+		if last == nil {
+			return nil
+		}
+		ran, err := m.run(ctx, conn, *last, false)
+		if ran {
+			count = 1
+		}
+		return err
+	})
+	return count, err
+}
+
+// To applies or rolls back migrations until exactly those up to version are applied,
+// and returns how many it applied or rolled back. Version 0 rolls back every migration.
+func (m *Migrator) To(ctx context.Context, version int) (int, error) {
+	if version != 0 && m.find(version) == nil {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	count := 0
+	err := m.locked(ctx, func(conn *sql.Conn, applied map[int]appliedMigration) error {
+		// roll back from the newest, then apply from the oldest
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok || mig.Version <= version {
+				continue
+			}
+			ran, err := m.run(ctx, conn, mig, false)
+			if err != nil {
+				return err
+			}
+			if ran {
+				count++
+			}
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok || mig.Version > version {
+				continue
+			}
+			ran, err := m.run(ctx, conn, mig, true)
+			if err != nil {
+				return err
+			}
+			if ran {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status lists the known migrations and the applied ones this build does not know, ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			s := Status{Version: mig.Version, Name: mig.Name}
+			if a, ok := applied[mig.Version]; ok {
+				appliedAt := a.appliedAt
+				s.AppliedAt = &appliedAt
+				s.Modified = a.checksum != mig.Checksum
+				delete(applied, mig.Version)
+			}
+			statuses = append(statuses, s)
+		}
+		for _, a := range applied {
+			appliedAt := a.appliedAt
+			statuses = append(statuses, Status{Version: a.version, Name: a.name, AppliedAt: &appliedAt, Unknown: true})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortStatuses(statuses)
+	return statuses, nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// withConn runs fn on a single connection holding the migration lock,
+// after making sure the schema_migrations table exists.
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		log.Error("Failed to get connection", log.Ferror(err))
+		return err
+	}
+	defer conn.Close()
+
+	if err = m.dialect.Lock(ctx, conn); err != nil {
+		log.Error("Failed to take migration lock", log.Ferror(err))
+		return err
+	}
+	defer func() {
+		if unlockErr := m.dialect.Unlock(context.WithoutCancel(ctx), conn); unlockErr != nil {
+			log.Error("Failed to release migration lock", log.Ferror(unlockErr))
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, createMigrationsTable); err != nil {
+		log.Error("Failed to create schema_migrations table", log.Ferror(err))
+		return err
+	}
+	return fn(conn)
+}
+
+// locked runs fn with the applied migrations after checking that they match the known ones.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, applied map[int]appliedMigration) error) error {
+	return m.withConn(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for version, a := range applied {
+			mig := m.find(version)
+			if mig == nil {
+				return fmt.Errorf("%w: %d_%s", ErrUnknownMigration, version, a.name)
+			}
+			if mig.Checksum != a.checksum {
+				return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, version, mig.Name)
+			}
+		}
+		return fn(conn, applied)
+	})
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		log.Error("Failed to read schema_migrations", log.Ferror(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var a appliedMigration
+		if err = rows.Scan(&a.version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[a.version] = a
+	}
+	return applied, rows.Err()
+}
+
+// run applies or rolls back mig and records it. On a transactional dialect this happens in one
+// transaction, which first checks that no other instance has done it in the meantime.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, mig Migration, up bool) (bool, error) {
+	script, record := mig.Down, m.dialect.Bind("DELETE FROM schema_migrations WHERE version = ?")
+	args := []interface{}{mig.Version}
+	if up {
+		script, record = mig.Up, m.dialect.Bind("INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)")
+		args = append(args, mig.Name, mig.Checksum, time.Now().UTC())
+	}
+
+	ran, err := true, error(nil)
+	if m.dialect.Transactional {
+		ran, err = m.runInTx(ctx, conn, mig.Version, up, script, record, args)
+	} else {
+		err = m.exec(ctx, conn, script, record, args)
+	}
+	if err != nil {
+		log.Error("Failed to run migration", log.Fint("version", mig.Version), log.Fstring("name", mig.Name), log.Fbool("up", up), log.Ferror(err))
+		return false, fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	if ran {
+		log.Info("Ran migration", log.Fint("version", mig.Version), log.Fstring("name", mig.Name), log.Fbool("up", up))
+	}
+	return ran, nil
+}
+
+func (m *Migrator) runInTx(ctx context.Context, conn *sql.Conn, version int, up bool, script, record string, args []interface{}) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback() //nolint:errcheck // fails only after a commit
+
+	var n int
+	if err = tx.QueryRowContext(ctx, m.dialect.Bind("SELECT COUNT(*) FROM schema_migrations WHERE version = ?"), version).Scan(&n); err != nil {
+		return false, err
+	}
+	if (n == 1) == up {
+		return false, nil
+	}
+	if err = m.exec(ctx, tx, script, record, args); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (m *Migrator) exec(ctx context.Context, ex execer, script, record string, args []interface{}) error {
+	for _, stmt := range statements(script) {
+		if _, err := ex.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	_, err := ex.ExecContext(ctx, record, args...)
+	return err
+}
@@ -0,0 +1,274 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	_ "modernc.org/sqlite" // This blank import is used for its init function
+)
+
+func testMigrations() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_create_tasks.up.sql":   {Data: []byte("-- tasks\nCREATE TABLE Tasks (id TEXT PRIMARY KEY);\n")},
+		"0001_create_tasks.down.sql": {Data: []byte("DROP TABLE Tasks;\n")},
+		"0002_add_title.up.sql": {Data: []byte(
+			"ALTER TABLE Tasks ADD COLUMN title TEXT;\nCREATE INDEX idx_tasks_title ON Tasks (title);\n",
+		)},
+		"0002_add_title.down.sql": {Data: []byte("DROP INDEX idx_tasks_title;\nALTER TABLE Tasks DROP COLUMN title;\n")},
+		"README.md":               {Data: []byte("not a migration")},
+	}
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db")+"?_txlock=immediate&_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func appliedVersions(t *testing.T, m *Migrator) []int {
+	t.Helper()
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	var versions []int
+	for _, s := range statuses {
+		if s.AppliedAt != nil {
+			versions = append(versions, s.Version)
+		}
+	}
+	return versions
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	patterns := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []int
+		wantErr bool
+	}{
+		{
+			name: "success: ordered by version",
+			fsys: testMigrations(),
+			want: []int{1, 2},
+		},
+		{
+			name: "Fail: missing down file",
+			fsys: fstest.MapFS{
+				"0001_create_tasks.up.sql": {Data: []byte("CREATE TABLE Tasks (id TEXT);")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fail: two names for a version",
+			fsys: fstest.MapFS{
+				"0001_create_tasks.up.sql":   {Data: []byte("CREATE TABLE Tasks (id TEXT);")},
+				"0001_create_users.down.sql": {Data: []byte("DROP TABLE Users;")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Load(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("want: %v migrations, got: %v", len(tt.want), len(got))
+			}
+			for i, m := range got {
+				if m.Version != tt.want[i] || m.Checksum == "" {
+					t.Errorf("want: version %v with a checksum, got: %+v", tt.want[i], m)
+				}
+			}
+		})
+	}
+}
+
+func Test_statements(t *testing.T) {
+	t.Parallel()
+
+	got := statements("-- comment\nCREATE TABLE A (\n  id INT\n);\n\nCREATE INDEX i ON A (id);\nSELECT 1")
+	want := []string{"CREATE TABLE A (\n  id INT\n);", "CREATE INDEX i ON A (id);", "SELECT 1"}
+	if len(got) != len(want) {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("want: %q, got: %q", want[i], got[i])
+		}
+	}
+}
+
+func TestDialect_Bind(t *testing.T) {
+	t.Parallel()
+
+	if got := Postgres.Bind("SELECT ? WHERE a = ?"); got != "SELECT $1 WHERE a = $2" {
+		t.Errorf("Postgres.Bind() = %q", got)
+	}
+	if got := MySQL.Bind("SELECT ?"); got != "SELECT ?" {
+		t.Errorf("MySQL.Bind() = %q", got)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openTestDB(t)
+	m, err := New(db, SQLite, testMigrations())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// Up
+	count, err := m.Up(ctx)
+	if err != nil || count != 2 {
+		t.Fatalf("Up() = %v, %v, want 2 migrations", count, err)
+	}
+	if _, err = db.ExecContext(ctx, "INSERT INTO Tasks (id, title) VALUES ('1', 'title')"); err != nil {
+		t.Errorf("want: the schema of the latest version, got: %v", err)
+	}
+	if count, err = m.Up(ctx); err != nil || count != 0 {
+		t.Errorf("Up() again = %v, %v, want nothing to do", count, err)
+	}
+
+	// Down
+	if count, err = m.Down(ctx); err != nil || count != 1 {
+		t.Fatalf("Down() = %v, %v, want 1 migration", count, err)
+	}
+	if got := appliedVersions(t, m); len(got) != 1 || got[0] != 1 {
+		t.Errorf("want: version 1 applied, got: %v", got)
+	}
+
+	// To
+	if count, err = m.To(ctx, 0); err != nil || count != 1 {
+		t.Fatalf("To(0) = %v, %v, want 1 migration", count, err)
+	}
+	if got := appliedVersions(t, m); len(got) != 0 {
+		t.Errorf("want: nothing applied, got: %v", got)
+	}
+	if count, err = m.Down(ctx); err != nil || count != 0 {
+		t.Errorf("Down() with nothing applied = %v, %v", count, err)
+	}
+	if count, err = m.To(ctx, 2); err != nil || count != 2 {
+		t.Fatalf("To(2) = %v, %v, want 2 migrations", count, err)
+	}
+	if _, err = m.To(ctx, 3); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("To(3) error = %v, want %v", err, ErrUnknownVersion)
+	}
+}
+
+func TestMigrator_failedMigrationRollsBack(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fsys := testMigrations()
+	fsys["0002_add_title.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE Tasks ADD COLUMN title TEXT;\nNOT SQL;\n")}
+	m, err := New(openTestDB(t), SQLite, fsys)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err = m.Up(ctx); err == nil {
+		t.Fatalf("Up() error = nil, want the error of migration 2")
+	}
+	if got := appliedVersions(t, m); len(got) != 1 || got[0] != 1 {
+		t.Errorf("want: only version 1 applied, got: %v", got)
+	}
+}
+
+func TestMigrator_verifiesAppliedMigrations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openTestDB(t)
+	m, err := New(db, SQLite, testMigrations())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	// an applied migration edited afterwards
+	modified := testMigrations()
+	modified["0001_create_tasks.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE Tasks (id INTEGER PRIMARY KEY);")}
+	mm, err := New(db, SQLite, modified)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err = mm.Up(ctx); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Up() error = %v, want %v", err, ErrChecksumMismatch)
+	}
+	statuses, err := mm.Status(ctx)
+	if err != nil || !statuses[0].Modified {
+		t.Errorf("want: version 1 reported as modified, got: %+v, %v", statuses, err)
+	}
+
+	// an older build that does not know migration 2
+	older := testMigrations()
+	delete(older, "0002_add_title.up.sql")
+	delete(older, "0002_add_title.down.sql")
+	om, err := New(db, SQLite, older)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err = om.Down(ctx); !errors.Is(err, ErrUnknownMigration) {
+		t.Errorf("Down() error = %v, want %v", err, ErrUnknownMigration)
+	}
+	statuses, err = om.Status(ctx)
+	if err != nil || len(statuses) != 2 || !statuses[1].Unknown {
+		t.Errorf("want: version 2 reported as unknown, got: %+v, %v", statuses, err)
+	}
+}
+
+func TestMigrator_concurrentUp(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	// instances starting at the same time apply every migration exactly once
+	var wg sync.WaitGroup
+	counts := make([]int, 4)
+	for i := range counts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m, err := New(db, SQLite, testMigrations())
+			if err != nil {
+				t.Errorf("New() error = %v", err)
+				return
+			}
+			if counts[i], err = m.Up(ctx); err != nil {
+				t.Errorf("Up() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if total != 2 {
+		t.Errorf("want: 2 migrations reported across instances, got: %v", total)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+
+	entity "github.com/tusmasoma/go-clean-arch/entity"
+	repository "github.com/tusmasoma/go-clean-arch/repository"
 )
 
 // MockAuthRepository is a mock of AuthRepository interface.
@@ -33,6 +36,21 @@ func (m *MockAuthRepository) EXPECT() *MockAuthRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GenerateRefreshToken mocks base method.
+func (m *MockAuthRepository) GenerateRefreshToken(userID, familyID string) (string, entity.RefreshToken) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRefreshToken", userID, familyID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(entity.RefreshToken)
+	return ret0, ret1
+}
+
+// GenerateRefreshToken indicates an expected call of GenerateRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) GenerateRefreshToken(userID, familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).GenerateRefreshToken), userID, familyID)
+}
+
 // GenerateToken mocks base method.
 func (m *MockAuthRepository) GenerateToken(userID, email string) (string, string) {
 	m.ctrl.T.Helper()
@@ -48,31 +66,31 @@ func (mr *MockAuthRepositoryMockRecorder) GenerateToken(userID, email interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthRepository)(nil).GenerateToken), userID, email)
 }
 
-// GetPayloadFromToken mocks base method.
-func (m *MockAuthRepository) GetPayloadFromToken(jwt string) (map[string]string, error) {
+// PublicKeys mocks base method.
+func (m *MockAuthRepository) PublicKeys() entity.JSONWebKeySet {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPayloadFromToken", jwt)
-	ret0, _ := ret[0].(map[string]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PublicKeys")
+	ret0, _ := ret[0].(entity.JSONWebKeySet)
+	return ret0
 }
 
-// GetPayloadFromToken indicates an expected call of GetPayloadFromToken.
-func (mr *MockAuthRepositoryMockRecorder) GetPayloadFromToken(jwt interface{}) *gomock.Call {
+// PublicKeys indicates an expected call of PublicKeys.
+func (mr *MockAuthRepositoryMockRecorder) PublicKeys() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayloadFromToken", reflect.TypeOf((*MockAuthRepository)(nil).GetPayloadFromToken), jwt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKeys", reflect.TypeOf((*MockAuthRepository)(nil).PublicKeys))
 }
 
-// ValidateAccessToken mocks base method.
-func (m *MockAuthRepository) ValidateAccessToken(jwt string) error {
+// ValidateAndParse mocks base method.
+func (m *MockAuthRepository) ValidateAndParse(jwt string) (*repository.TokenClaims, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateAccessToken", jwt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ValidateAndParse", jwt)
+	ret0, _ := ret[0].(*repository.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ValidateAccessToken indicates an expected call of ValidateAccessToken.
-func (mr *MockAuthRepositoryMockRecorder) ValidateAccessToken(jwt interface{}) *gomock.Call {
+// ValidateAndParse indicates an expected call of ValidateAndParse.
+func (mr *MockAuthRepositoryMockRecorder) ValidateAndParse(jwt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccessToken", reflect.TypeOf((*MockAuthRepository)(nil).ValidateAccessToken), jwt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAndParse", reflect.TypeOf((*MockAuthRepository)(nil).ValidateAndParse), jwt)
 }
@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: password.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPasswordRepository is a mock of PasswordRepository interface.
+type MockPasswordRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordRepositoryMockRecorder
+}
+
+// MockPasswordRepositoryMockRecorder is the mock recorder for MockPasswordRepository.
+type MockPasswordRepositoryMockRecorder struct {
+	mock *MockPasswordRepository
+}
+
+// NewMockPasswordRepository creates a new mock instance.
+func NewMockPasswordRepository(ctrl *gomock.Controller) *MockPasswordRepository {
+	mock := &MockPasswordRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordRepository) EXPECT() *MockPasswordRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockPasswordRepository) Compare(hash, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hash, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockPasswordRepositoryMockRecorder) Compare(hash, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockPasswordRepository)(nil).Compare), hash, password)
+}
+
+// Hash mocks base method.
+func (m *MockPasswordRepository) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockPasswordRepositoryMockRecorder) Hash(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordRepository)(nil).Hash), password)
+}
+
+// NeedsRehash mocks base method.
+func (m *MockPasswordRepository) NeedsRehash(hash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockPasswordRepositoryMockRecorder) NeedsRehash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockPasswordRepository)(nil).NeedsRehash), hash)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: revocation.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+
+	repository "github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// MockRevocationRepository is a mock of RevocationRepository interface.
+type MockRevocationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevocationRepositoryMockRecorder
+}
+
+// MockRevocationRepositoryMockRecorder is the mock recorder for MockRevocationRepository.
+type MockRevocationRepositoryMockRecorder struct {
+	mock *MockRevocationRepository
+}
+
+// NewMockRevocationRepository creates a new mock instance.
+func NewMockRevocationRepository(ctrl *gomock.Controller) *MockRevocationRepository {
+	mock := &MockRevocationRepository{ctrl: ctrl}
+	mock.recorder = &MockRevocationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevocationRepository) EXPECT() *MockRevocationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockRevocationRepository) IsRevoked(ctx context.Context, claims repository.TokenClaims) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, claims)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockRevocationRepositoryMockRecorder) IsRevoked(ctx, claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockRevocationRepository)(nil).IsRevoked), ctx, claims)
+}
+
+// RevokeToken mocks base method.
+func (m *MockRevocationRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, jti, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockRevocationRepositoryMockRecorder) RevokeToken(ctx, jti, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockRevocationRepository)(nil).RevokeToken), ctx, jti, expiresAt)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockRevocationRepository) RevokeUserTokens(ctx context.Context, userID string, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, userID, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockRevocationRepositoryMockRecorder) RevokeUserTokens(ctx, userID, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockRevocationRepository)(nil).RevokeUserTokens), ctx, userID, before)
+}
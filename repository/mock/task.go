@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	entity "github.com/tusmasoma/go-clean-arch/entity"
+	repository "github.com/tusmasoma/go-clean-arch/repository"
 )
 
 // MockTaskRepository is a mock of TaskRepository interface.
@@ -80,18 +81,19 @@ func (mr *MockTaskRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call
 }
 
 // List mocks base method.
-func (m *MockTaskRepository) List(ctx context.Context, userID string) ([]entity.Task, error) {
+func (m *MockTaskRepository) List(ctx context.Context, query repository.TaskQuery) ([]entity.Task, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret := m.ctrl.Call(m, "List", ctx, query)
 	ret0, _ := ret[0].([]entity.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // List indicates an expected call of List.
-func (mr *MockTaskRepositoryMockRecorder) List(ctx, userID interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) List(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTaskRepository)(nil).List), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTaskRepository)(nil).List), ctx, query)
 }
 
 // Update mocks base method.
@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/repositorytest"
+)
+
+func Test_TaskRepository_conformance(t *testing.T) {
+	if client == nil {
+		t.Skip("MongoDB is not available")
+	}
+	cli := &Client{cli: client, db: "goCleanArcTestDB"}
+	repositorytest.TestTaskRepository(t, func(*testing.T) (repository.TaskRepository, repository.TransactionRepository) {
+		return NewTaskRepository(cli), NewTransactionRepository(cli)
+	})
+}
+
+func Test_UserRepository_conformance(t *testing.T) {
+	if client == nil {
+		t.Skip("MongoDB is not available")
+	}
+	cli := &Client{cli: client, db: "goCleanArcTestDB"}
+	if err := createUserIndexes(context.Background(), client.Database(cli.db)); err != nil {
+		t.Fatal(err)
+	}
+	repositorytest.TestUserRepository(t, func(*testing.T) (repository.UserRepository, repository.TransactionRepository) {
+		return NewUserRepository(cli), NewTransactionRepository(cli)
+	})
+}
+
+func Test_TagRepository_conformance(t *testing.T) {
+	if client == nil {
+		t.Skip("MongoDB is not available")
+	}
+	cli := &Client{cli: client, db: "goCleanArcTestDB"}
+	if err := createTagIndexes(context.Background(), client.Database(cli.db)); err != nil {
+		t.Fatal(err)
+	}
+	repositorytest.TestTagRepository(t, func(*testing.T) (repository.TagRepository, repository.TaskRepository, repository.TransactionRepository) {
+		return NewTagRepository(cli), NewTaskRepository(cli), NewTransactionRepository(cli)
+	})
+}
+
+func Test_RefreshTokenRepository_conformance(t *testing.T) {
+	if client == nil {
+		t.Skip("MongoDB is not available")
+	}
+	cli := &Client{cli: client, db: "goCleanArcTestDB"}
+	if err := createRefreshTokenIndexes(context.Background(), client.Database(cli.db)); err != nil {
+		t.Fatal(err)
+	}
+	repositorytest.TestRefreshTokenRepository(t, func(*testing.T) (repository.RefreshTokenRepository, repository.TransactionRepository) {
+		return NewRefreshTokenRepository(cli), NewTransactionRepository(cli)
+	})
+}
@@ -7,17 +7,50 @@ import (
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 
 	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
-// TODO: Implement similar transaction handling for MongoDB using mongo.Session.
-
 type Client struct {
 	cli *mongo.Client
 	db  string
 }
 
+type transactionRepository struct {
+	client *Client
+}
+
+// NewTransactionRepository runs transactions in sessions, which needs MongoDB to be a replica set or sharded cluster.
+func NewTransactionRepository(client *Client) repository.TransactionRepository {
+	return &transactionRepository{
+		client: client,
+	}
+}
+
+// Transaction runs fn with a session context, so that the repositories of this package called with it
+// take part in the transaction. The whole of fn is retried on TransientTransactionError,
+// and the commit on UnknownTransactionCommitResult, so fn must be safe to run more than once.
+// A transaction already in ctx is joined rather than nested.
+func (tr *transactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if session := mongo.SessionFromContext(ctx); session != nil {
+		return fn(ctx)
+	}
+
+	session, err := tr.client.cli.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	}, options.Transaction().SetReadConcern(readconcern.Snapshot()).SetWriteConcern(writeconcern.Majority()))
+	return err
+}
+
 func NewMongoDB(ctx context.Context) (*Client, error) {
 	cfg, err := config.NewMongoDBConfig(ctx)
 	if err != nil {
@@ -44,6 +77,15 @@ func NewMongoDB(ctx context.Context) (*Client, error) {
 	if err = client.Ping(ctx, nil); err != nil {
 		return nil, fmt.Errorf("failed to ping MongoDB instance: %w", err)
 	}
+	if err = createUserIndexes(ctx, client.Database(cfg.Database)); err != nil {
+		return nil, fmt.Errorf("failed to create MongoDB indexes: %w", err)
+	}
+	if err = createRefreshTokenIndexes(ctx, client.Database(cfg.Database)); err != nil {
+		return nil, fmt.Errorf("failed to create MongoDB indexes: %w", err)
+	}
+	if err = createTagIndexes(ctx, client.Database(cfg.Database)); err != nil {
+		return nil, fmt.Errorf("failed to create MongoDB indexes: %w", err)
+	}
 
 	return &Client{
 		cli: client,
@@ -0,0 +1,83 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_TransactionRepository(t *testing.T) {
+	ctx := context.Background()
+
+	if client == nil {
+		t.Skip("MongoDB is not available")
+	}
+
+	var cli Client
+	cli.cli = client
+	cli.db = "goCleanArcTestDB"
+	err := createUserIndexes(ctx, client.Database(cli.db))
+	ValidateErr(t, err, nil)
+
+	txRepo := NewTransactionRepository(&cli)
+	taskRepo := NewTaskRepository(&cli)
+	userRepo := NewUserRepository(&cli)
+
+	user, err := entity.NewUser("transaction@gmail.com", "password")
+	ValidateErr(t, err, nil)
+	task, err := entity.NewTask(uuid.New().String(), "title", "description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+
+	// Rollback
+	errAbort := errors.New("abort")
+	err = txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err = userRepo.Create(ctx, *user); err != nil {
+			return err
+		}
+		if err = taskRepo.Create(ctx, *task); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+
+	_, err = userRepo.Get(ctx, user.ID)
+	ValidateErr(t, err, repository.ErrUserNotFound)
+	_, err = taskRepo.Get(ctx, task.ID)
+	ValidateErr(t, err, repository.ErrTaskNotFound)
+
+	// Commit
+	err = txRepo.Transaction(ctx, func(ctx context.Context) error {
+		exists, err := userRepo.LockUserByEmail(ctx, user.Email)
+		if err != nil {
+			return err
+		}
+		if exists {
+			t.Errorf("LockUserByEmail() = true before the user was created")
+		}
+		return userRepo.Create(ctx, *user)
+	})
+	ValidateErr(t, err, nil)
+
+	_, err = userRepo.Get(ctx, user.ID)
+	ValidateErr(t, err, nil)
+
+	// Nested transactions join the outer one
+	err = txRepo.Transaction(ctx, func(ctx context.Context) error {
+		return txRepo.Transaction(ctx, func(ctx context.Context) error {
+			return taskRepo.Create(ctx, *task)
+		})
+	})
+	ValidateErr(t, err, nil)
+
+	_, err = taskRepo.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+}
@@ -2,12 +2,14 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/ory/dockertest"
 	"github.com/ory/dockertest/docker"
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -49,9 +51,11 @@ func startMongoDB() (*mongo.Client, string, func(), error) {
 		return nil, "", nil, err
 	}
 
+	// Transactions need a replica set, so the container runs a single member one.
 	runOptions := &dockertest.RunOptions{
 		Repository: "mongo",
 		Tag:        "latest",
+		Cmd:        []string{"--replSet", replicaSet, "--bind_ip_all"},
 	}
 
 	resource, err := pool.RunWithOptions(runOptions,
@@ -77,14 +81,17 @@ func startMongoDB() (*mongo.Client, string, func(), error) {
 	port := resource.GetPort("27017/tcp")
 
 	err = pool.Retry(func() error {
-		uri := fmt.Sprintf("mongodb://localhost:%s", port)
+		uri := fmt.Sprintf("mongodb://localhost:%s/?directConnection=true", port)
 		clientOptions := options.Client().ApplyURI(uri)
 
 		client, err = mongo.Connect(context.Background(), clientOptions)
 		if err != nil {
 			return err
 		}
-		return client.Ping(context.Background(), nil)
+		if err = client.Ping(context.Background(), nil); err != nil {
+			return err
+		}
+		return initiateReplicaSet(context.Background(), client)
 	})
 	if err != nil {
 		log.Error("Could not connect to MongoDB: %s", err)
@@ -96,6 +103,30 @@ func startMongoDB() (*mongo.Client, string, func(), error) {
 	return client, port, func() { closeMongoDB(client, pool, resource) }, nil
 }
 
+const replicaSet = "rs0"
+
+// initiateReplicaSet succeeds once the member has become the primary, initiating the replica set first if needed.
+func initiateReplicaSet(ctx context.Context, client *mongo.Client) error {
+	admin := client.Database("admin")
+
+	var hello bson.M
+	if err := admin.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return err
+	}
+	if primary, _ := hello["isWritablePrimary"].(bool); primary {
+		return nil
+	}
+	if _, ok := hello["setName"]; !ok {
+		if err := admin.RunCommand(ctx, bson.D{{Key: "replSetInitiate", Value: bson.M{
+			"_id":     replicaSet,
+			"members": bson.A{bson.M{"_id": 0, "host": "localhost:27017"}},
+		}}}).Err(); err != nil {
+			return err
+		}
+	}
+	return errors.New("replica set has no primary yet")
+}
+
 func closeMongoDB(client *mongo.Client, pool *dockertest.Pool, resource *dockertest.Resource) {
 	if err := client.Disconnect(context.Background()); err != nil {
 		log.Error("Failed to close MongoDB connection: %v", err)
@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+const refreshTokensCollection = "RefreshTokens"
+
+type refreshTokenModel struct {
+	TokenHash string    `bson:"_id"`
+	FamilyID  string    `bson:"family_id"`
+	UserID    string    `bson:"user_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+	CreatedAt time.Time `bson:"created_at"`
+	Used      bool      `bson:"used"`
+	Revoked   bool      `bson:"revoked"`
+}
+
+type refreshTokenRepository struct {
+	client *Client
+	table  string
+}
+
+func NewRefreshTokenRepository(client *Client) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		client: client,
+		table:  refreshTokensCollection,
+	}
+}
+
+// createRefreshTokenIndexes indexes the fields that tokens are revoked by.
+func createRefreshTokenIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(refreshTokensCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "family_id", Value: 1}}, Options: options.Index().SetName("family_id")},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+	})
+	return err
+}
+
+func (rr *refreshTokenRepository) Get(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	collection := rr.client.cli.Database(rr.client.db).Collection(rr.table)
+
+	var rm refreshTokenModel
+	if err := collection.FindOne(ctx, bson.M{"_id": tokenHash}).Decode(&rm); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrRefreshTokenNotFound
+		}
+		return nil, err
+	}
+	return &entity.RefreshToken{
+		TokenHash: rm.TokenHash,
+		FamilyID:  rm.FamilyID,
+		UserID:    rm.UserID,
+		ExpiresAt: rm.ExpiresAt,
+		CreatedAt: rm.CreatedAt,
+		Used:      rm.Used,
+		Revoked:   rm.Revoked,
+	}, nil
+}
+
+func (rr *refreshTokenRepository) Create(ctx context.Context, token entity.RefreshToken) error {
+	collection := rr.client.cli.Database(rr.client.db).Collection(rr.table)
+
+	rm := refreshTokenModel{
+		TokenHash: token.TokenHash,
+		FamilyID:  token.FamilyID,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+		Used:      token.Used,
+		Revoked:   token.Revoked,
+	}
+
+	if _, err := collection.InsertOne(ctx, rm); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarkUsed matches only an unused and unrevoked token, so that of two concurrent calls only one updates it.
+func (rr *refreshTokenRepository) MarkUsed(ctx context.Context, tokenHash string) (bool, error) {
+	collection := rr.client.cli.Database(rr.client.db).Collection(rr.table)
+
+	filter := bson.M{"_id": tokenHash, "used": false, "revoked": false}
+	update := bson.M{"$set": bson.M{"used": true}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (rr *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return rr.revoke(ctx, bson.M{"family_id": familyID})
+}
+
+func (rr *refreshTokenRepository) RevokeUser(ctx context.Context, userID string) error {
+	return rr.revoke(ctx, bson.M{"user_id": userID})
+}
+
+func (rr *refreshTokenRepository) revoke(ctx context.Context, filter bson.M) error {
+	collection := rr.client.cli.Database(rr.client.db).Collection(rr.table)
+
+	if _, err := collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revoked": true}}); err != nil {
+		return err
+	}
+	return nil
+}
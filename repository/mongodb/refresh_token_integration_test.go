@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_RefreshTokenRepository(t *testing.T) {
+	ctx := context.Background()
+
+	if client == nil {
+		t.Skip("MongoDB is not available")
+	}
+
+	var cli Client
+	cli.cli = client
+	cli.db = "goCleanArcTestDB"
+	repo := NewRefreshTokenRepository(&cli)
+
+	now := time.Now().Truncate(time.Second)
+	familyID := uuid.New().String()
+	token1 := entity.RefreshToken{
+		TokenHash: entity.HashRefreshToken("token1"),
+		FamilyID:  familyID,
+		UserID:    uuid.New().String(),
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+	token2 := token1
+	token2.TokenHash = entity.HashRefreshToken("token2")
+
+	// Create
+	err := repo.Create(ctx, token1)
+	ValidateErr(t, err, nil)
+	err = repo.Create(ctx, token2)
+	ValidateErr(t, err, nil)
+
+	// Get
+	got, err := repo.Get(ctx, token1.TokenHash)
+	ValidateErr(t, err, nil)
+	if got.FamilyID != token1.FamilyID || got.UserID != token1.UserID || !got.ExpiresAt.Equal(token1.ExpiresAt) || got.Used || got.Revoked {
+		t.Errorf("want: %v, got: %v", token1, got)
+	}
+
+	_, err = repo.Get(ctx, entity.HashRefreshToken("unknown"))
+	ValidateErr(t, err, repository.ErrRefreshTokenNotFound)
+
+	// MarkUsed
+	marked, err := repo.MarkUsed(ctx, token1.TokenHash)
+	ValidateErr(t, err, nil)
+	if !marked {
+		t.Fatalf("Failed to mark token as used")
+	}
+	marked, err = repo.MarkUsed(ctx, token1.TokenHash)
+	ValidateErr(t, err, nil)
+	if marked {
+		t.Errorf("Marked a used token as used again")
+	}
+
+	// RevokeFamily
+	err = repo.RevokeFamily(ctx, familyID)
+	ValidateErr(t, err, nil)
+
+	got, err = repo.Get(ctx, token2.TokenHash)
+	ValidateErr(t, err, nil)
+	if !got.Revoked {
+		t.Errorf("Failed to revoke the token family")
+	}
+	marked, err = repo.MarkUsed(ctx, token2.TokenHash)
+	ValidateErr(t, err, nil)
+	if marked {
+		t.Errorf("Marked a revoked token as used")
+	}
+
+	// RevokeUser
+	token3 := entity.RefreshToken{
+		TokenHash: entity.HashRefreshToken("token3"),
+		FamilyID:  uuid.New().String(),
+		UserID:    token1.UserID,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+	err = repo.Create(ctx, token3)
+	ValidateErr(t, err, nil)
+	err = repo.RevokeUser(ctx, token1.UserID)
+	ValidateErr(t, err, nil)
+
+	got, err = repo.Get(ctx, token3.TokenHash)
+	ValidateErr(t, err, nil)
+	if !got.Revoked {
+		t.Errorf("Failed to revoke the tokens of the user")
+	}
+}
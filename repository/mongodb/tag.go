@@ -0,0 +1,135 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+const tagsCollection = "Tags"
+
+type tagModel struct {
+	ID        string    `bson:"_id,omitempty"`
+	UserID    string    `bson:"user_id"`
+	Name      string    `bson:"name"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type tagRepository struct {
+	client *Client
+	table  string
+}
+
+func NewTagRepository(client *Client) repository.TagRepository {
+	return &tagRepository{
+		client: client,
+		table:  tagsCollection,
+	}
+}
+
+// createTagIndexes makes the names of the tags of a user unique.
+func createTagIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(tagsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("user_id_name_unique"),
+	})
+	return err
+}
+
+func (tr *tagRepository) Get(ctx context.Context, id string) (*entity.Tag, error) {
+	collection := tr.client.cli.Database(tr.client.db).Collection(tr.table)
+
+	var tm tagModel
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&tm); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &entity.Tag{
+		ID:        tm.ID,
+		UserID:    tm.UserID,
+		Name:      tm.Name,
+		CreatedAt: tm.CreatedAt,
+	}, nil
+}
+
+func (tr *tagRepository) List(ctx context.Context, userID string) ([]entity.Tag, error) {
+	collection := tr.client.cli.Database(tr.client.db).Collection(tr.table)
+
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tms []tagModel
+	if err = cursor.All(ctx, &tms); err != nil {
+		return nil, err
+	}
+
+	tags := make([]entity.Tag, len(tms))
+	for i, tm := range tms {
+		tags[i] = entity.Tag{
+			ID:        tm.ID,
+			UserID:    tm.UserID,
+			Name:      tm.Name,
+			CreatedAt: tm.CreatedAt,
+		}
+	}
+	return tags, nil
+}
+
+func (tr *tagRepository) Create(ctx context.Context, tag entity.Tag) error {
+	collection := tr.client.cli.Database(tr.client.db).Collection(tr.table)
+
+	tm := tagModel{
+		ID:        tag.ID,
+		UserID:    tag.UserID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+	}
+
+	if _, err := collection.InsertOne(ctx, tm); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrDuplicateTagName
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr *tagRepository) Update(ctx context.Context, tag entity.Tag) error {
+	collection := tr.client.cli.Database(tr.client.db).Collection(tr.table)
+
+	update := bson.M{"$set": bson.M{"name": tag.Name}}
+
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": tag.ID}, update); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrDuplicateTagName
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete pulls the tag from the tasks before removing it. The two writes are atomic only inside a transaction.
+func (tr *tagRepository) Delete(ctx context.Context, id string) error {
+	db := tr.client.cli.Database(tr.client.db)
+
+	pull := bson.M{"$pull": bson.M{"tag_ids": id}}
+	if _, err := db.Collection(tasksCollection).UpdateMany(ctx, bson.M{"tag_ids": id}, pull); err != nil {
+		return err
+	}
+	if _, err := db.Collection(tr.table).DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return err
+	}
+	return nil
+}
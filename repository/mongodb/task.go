@@ -2,22 +2,32 @@ package mongodb
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
 	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
+const tasksCollection = "Tasks"
+
 type taskModel struct {
-	ID          string    `bson:"_id,omitempty"`
-	UserID      string    `bson:"user_id"`
-	Title       string    `bson:"title"`
-	Description string    `bson:"description"`
-	DueDate     time.Time `bson:"duedate"`
-	Priority    int       `bson:"priority"`
-	CreatedAt   time.Time `bson:"created_at"`
+	ID          string     `bson:"_id,omitempty"`
+	UserID      string     `bson:"user_id"`
+	Title       string     `bson:"title"`
+	Description string     `bson:"description"`
+	DueDate     time.Time  `bson:"duedate"`
+	Priority    int        `bson:"priority"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	Status      string     `bson:"status"`
+	CompletedAt *time.Time `bson:"completed_at"`
+	TagIDs      []string   `bson:"tag_ids,omitempty"`
 }
 
 type taskRepository struct {
@@ -28,7 +38,7 @@ type taskRepository struct {
 func NewTaskRepository(client *Client) repository.TaskRepository {
 	return &taskRepository{
 		client: client,
-		table:  "Tasks",
+		table:  tasksCollection,
 	}
 }
 
@@ -39,6 +49,9 @@ func (tr *taskRepository) Get(ctx context.Context, id string) (*entity.Task, err
 
 	var tm taskModel
 	if err := collection.FindOne(ctx, filter).Decode(&tm); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrTaskNotFound
+		}
 		return nil, err
 	}
 	return &entity.Task{
@@ -48,25 +61,30 @@ func (tr *taskRepository) Get(ctx context.Context, id string) (*entity.Task, err
 		Description: tm.Description,
 		DueDate:     tm.DueDate,
 		Priority:    tm.Priority,
+		Status:      tm.Status,
+		CompletedAt: tm.CompletedAt,
 		CreatedAt:   tm.CreatedAt,
+		TagIDs:      tm.TagIDs,
 	}, nil
 }
 
-func (tr *taskRepository) List(ctx context.Context, userID string) ([]entity.Task, error) {
+func (tr *taskRepository) List(ctx context.Context, q repository.TaskQuery) ([]entity.Task, string, error) {
 	collection := tr.client.cli.Database(tr.client.db).Collection(tr.table)
-	filter := bson.M{
-		"user_id": userID,
+
+	filter, opts, err := buildListTasksFilter(q)
+	if err != nil {
+		return nil, "", err
 	}
 
-	cursor, err := collection.Find(ctx, filter)
+	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer cursor.Close(ctx)
 
 	var tms []taskModel
 	if err = cursor.All(ctx, &tms); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	tasks := make([]entity.Task, len(tms))
@@ -78,10 +96,14 @@ func (tr *taskRepository) List(ctx context.Context, userID string) ([]entity.Tas
 			Description: tm.Description,
 			DueDate:     tm.DueDate,
 			Priority:    tm.Priority,
+			Status:      tm.Status,
+			CompletedAt: tm.CompletedAt,
 			CreatedAt:   tm.CreatedAt,
+			TagIDs:      tm.TagIDs,
 		}
 	}
-	return tasks, nil
+	page, next := q.Page(tasks)
+	return page, next, nil
 }
 
 func (tr *taskRepository) Create(ctx context.Context, task entity.Task) error {
@@ -95,6 +117,9 @@ func (tr *taskRepository) Create(ctx context.Context, task entity.Task) error {
 		DueDate:     task.DueDate,
 		Priority:    task.Priority,
 		CreatedAt:   task.CreatedAt,
+		Status:      task.Status,
+		CompletedAt: task.CompletedAt,
+		TagIDs:      repository.DistinctIDs(task.TagIDs),
 	}
 
 	if _, err := collection.InsertOne(ctx, tm); err != nil {
@@ -110,11 +135,13 @@ func (tr *taskRepository) Update(ctx context.Context, task entity.Task) error {
 
 	update := bson.M{
 		"$set": bson.M{
-			"title":       task.Title,
-			"description": task.Description,
-			"duedate":     task.DueDate,
-			"priority":    task.Priority,
-			"created_at":  task.CreatedAt,
+			"title":        task.Title,
+			"description":  task.Description,
+			"duedate":      task.DueDate,
+			"priority":     task.Priority,
+			"status":       task.Status,
+			"completed_at": task.CompletedAt,
+			"tag_ids":      repository.DistinctIDs(task.TagIDs),
 		},
 	}
 
@@ -134,3 +161,78 @@ func (tr *taskRepository) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+var taskSortFields = map[string]string{
+	repository.TaskSortDueDate:   "duedate",
+	repository.TaskSortPriority:  "priority",
+	repository.TaskSortCreatedAt: "created_at",
+}
+
+// buildListTasksFilter translates the query into a filter and find options using keyset pagination.
+// One extra document is fetched so that the caller can tell whether a next page exists.
+func buildListTasksFilter(q repository.TaskQuery) (bson.M, *options.FindOptions, error) {
+	cursor, err := q.DecodeCursor()
+	if err != nil {
+		return nil, nil, err
+	}
+	field, ok := taskSortFields[q.SortKey()]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid sort key: %s", q.SortKey())
+	}
+
+	conditions := bson.A{bson.M{"user_id": q.UserID}}
+	if q.MinPriority != 0 {
+		conditions = append(conditions, bson.M{"priority": bson.M{"$gte": q.MinPriority}})
+	}
+	if q.MaxPriority != 0 {
+		conditions = append(conditions, bson.M{"priority": bson.M{"$lte": q.MaxPriority}})
+	}
+	if !q.DueAfter.IsZero() {
+		conditions = append(conditions, bson.M{"duedate": bson.M{"$gte": q.DueAfter}})
+	}
+	if !q.DueBefore.IsZero() {
+		conditions = append(conditions, bson.M{"duedate": bson.M{"$lt": q.DueBefore}})
+	}
+	closed := bson.A{entity.StatusDone, entity.StatusArchived}
+	if q.Overdue != nil {
+		if *q.Overdue {
+			conditions = append(conditions, bson.M{
+				"duedate": bson.M{"$lt": q.Now},
+				"status":  bson.M{"$nin": closed},
+			})
+		} else {
+			conditions = append(conditions, bson.M{"$or": bson.A{
+				bson.M{"duedate": bson.M{"$gte": q.Now}},
+				bson.M{"status": bson.M{"$in": closed}},
+			}})
+		}
+	}
+	if q.TitlePrefix != "" {
+		conditions = append(conditions, bson.M{"title": bson.M{"$regex": "^" + regexp.QuoteMeta(q.TitlePrefix)}})
+	}
+
+	if ids := q.DistinctTagIDs(); len(ids) != 0 {
+		match := "$in"
+		if q.MatchAllTags {
+			match = "$all"
+		}
+		conditions = append(conditions, bson.M{"tag_ids": bson.M{match: ids}})
+	}
+
+	op, order := "$gt", 1
+	if q.SortDesc {
+		op, order = "$lt", -1
+	}
+	if cursor != nil {
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{field: bson.M{op: cursor.Value()}},
+			bson.M{field: cursor.Value(), "_id": bson.M{op: cursor.ID}},
+		}})
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: field, Value: order}, {Key: "_id", Value: order}})
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit + 1))
+	}
+	return bson.M{"$and": conditions}, opts, nil
+}
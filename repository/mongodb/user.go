@@ -0,0 +1,147 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+const usersCollection = "Users"
+
+type userModel struct {
+	ID           string `bson:"_id,omitempty"`
+	Name         string `bson:"name"`
+	Email        string `bson:"email"`
+	Password     string `bson:"password"`
+	DueSoonHours int    `bson:"due_soon_hours"`
+}
+
+type userRepository struct {
+	client *Client
+	table  string
+}
+
+func NewUserRepository(client *Client) repository.UserRepository {
+	return &userRepository{
+		client: client,
+		table:  usersCollection,
+	}
+}
+
+// createUserIndexes makes emails unique, which is what keeps two concurrent sign-ups
+// with the same new email apart, as there is no document yet for LockUserByEmail to hold.
+func createUserIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(usersCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("email_unique"),
+	})
+	return err
+}
+
+func (ur *userRepository) Get(ctx context.Context, id string) (*entity.User, error) {
+	return ur.findOne(ctx, bson.M{"_id": id})
+}
+
+func (ur *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return ur.findOne(ctx, bson.M{"email": email})
+}
+
+func (ur *userRepository) findOne(ctx context.Context, filter bson.M) (*entity.User, error) {
+	collection := ur.client.cli.Database(ur.client.db).Collection(ur.table)
+
+	var um userModel
+	if err := collection.FindOne(ctx, filter).Decode(&um); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &entity.User{
+		ID:           um.ID,
+		Name:         um.Name,
+		Email:        um.Email,
+		Password:     um.Password,
+		DueSoonHours: um.DueSoonHours,
+	}, nil
+}
+
+func (ur *userRepository) Create(ctx context.Context, user entity.User) error {
+	collection := ur.client.cli.Database(ur.client.db).Collection(ur.table)
+
+	um := userModel{
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Password:     user.Password,
+		DueSoonHours: user.DueSoonHours,
+	}
+
+	if _, err := collection.InsertOne(ctx, um); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrDuplicateEmail
+		}
+		return err
+	}
+	return nil
+}
+
+func (ur *userRepository) Update(ctx context.Context, user entity.User) error {
+	collection := ur.client.cli.Database(ur.client.db).Collection(ur.table)
+
+	filter := bson.M{"_id": user.ID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":           user.Name,
+			"email":          user.Email,
+			"password":       user.Password,
+			"due_soon_hours": user.DueSoonHours,
+		},
+	}
+
+	if _, err := collection.UpdateOne(ctx, filter, update); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrDuplicateEmail
+		}
+		return err
+	}
+	return nil
+}
+
+func (ur *userRepository) Delete(ctx context.Context, id string) error {
+	collection := ur.client.cli.Database(ur.client.db).Collection(ur.table)
+
+	filter := bson.M{"_id": id}
+
+	if _, err := collection.DeleteOne(ctx, filter); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LockUserByEmail writes to the user so that, inside a transaction, any other transaction
+// touching it conflicts until this one ends, which is how MongoDB takes the place of SELECT ... FOR UPDATE.
+func (ur *userRepository) LockUserByEmail(ctx context.Context, email string) (bool, error) {
+	collection := ur.client.cli.Database(ur.client.db).Collection(ur.table)
+
+	filter := bson.M{"email": email}
+	update := bson.M{"$set": bson.M{"locked_at": time.Now()}}
+
+	if err := collection.FindOneAndUpdate(ctx, filter, update).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Info("No user found with the provided email", log.Fstring("email", email))
+			return false, nil
+		}
+		log.Error("Failed to lock user", log.Ferror(err))
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_UserRepository(t *testing.T) {
+	ctx := context.Background()
+
+	if client == nil {
+		t.Skip("MongoDB is not available")
+	}
+
+	var cli Client
+	cli.cli = client
+	cli.db = "goCleanArcTestDB"
+	err := createUserIndexes(ctx, client.Database(cli.db))
+	ValidateErr(t, err, nil)
+	repo := NewUserRepository(&cli)
+
+	user, err := entity.NewUser(
+		"test@gmail.com",
+		"password",
+	)
+	ValidateErr(t, err, nil)
+
+	// Create
+	err = repo.Create(ctx, *user)
+	ValidateErr(t, err, nil)
+
+	duplicate, err := entity.NewUser(
+		"test@gmail.com",
+		"password",
+	)
+	ValidateErr(t, err, nil)
+	err = repo.Create(ctx, *duplicate)
+	ValidateErr(t, err, repository.ErrDuplicateEmail)
+
+	// Get
+	gotUser, err := repo.Get(ctx, user.ID)
+	ValidateErr(t, err, nil)
+	if !reflect.DeepEqual(user, gotUser) {
+		t.Errorf("want: %v, got: %v", user, gotUser)
+	}
+
+	// GetByEmail
+	gotUser, err = repo.GetByEmail(ctx, "test@gmail.com")
+	ValidateErr(t, err, nil)
+	if !reflect.DeepEqual(user, gotUser) {
+		t.Errorf("want: %v, got: %v", user, gotUser)
+	}
+
+	_, err = repo.GetByEmail(ctx, "unknown@gmail.com")
+	ValidateErr(t, err, repository.ErrUserNotFound)
+
+	// LockUserByEmail
+	exists, err := repo.LockUserByEmail(ctx, "test@gmail.com")
+	ValidateErr(t, err, nil)
+	if !exists {
+		t.Fatalf("Failed to get user by email")
+	}
+
+	// Update
+	gotUser.Name = "updatedName"
+	err = gotUser.SetDueSoonHours(48)
+	ValidateErr(t, err, nil)
+	err = repo.Update(ctx, *gotUser)
+	ValidateErr(t, err, nil)
+
+	updatedUser, err := repo.Get(ctx, user.ID)
+	ValidateErr(t, err, nil)
+	if !reflect.DeepEqual(gotUser, updatedUser) {
+		t.Errorf("want: %v, got: %v", gotUser, updatedUser)
+	}
+
+	// Delete
+	err = repo.Delete(ctx, user.ID)
+	ValidateErr(t, err, nil)
+
+	_, err = repo.Get(ctx, user.ID)
+	ValidateErr(t, err, repository.ErrUserNotFound)
+}
@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/repositorytest"
+)
+
+func Test_TaskRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	repositorytest.TestTaskRepository(t, func(*testing.T) (repository.TaskRepository, repository.TransactionRepository) {
+		return NewTaskRepository(db), NewTransactionRepository(db)
+	})
+}
+
+func Test_UserRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	repositorytest.TestUserRepository(t, func(*testing.T) (repository.UserRepository, repository.TransactionRepository) {
+		return NewUserRepository(db), NewTransactionRepository(db)
+	})
+}
+
+func Test_TagRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	repositorytest.TestTagRepository(t, func(*testing.T) (repository.TagRepository, repository.TaskRepository, repository.TransactionRepository) {
+		return NewTagRepository(db), NewTaskRepository(db), NewTransactionRepository(db)
+	})
+}
+
+func Test_RefreshTokenRepository_conformance(t *testing.T) {
+	if db == nil {
+		t.Skip("MySQL is not available")
+	}
+	repositorytest.TestRefreshTokenRepository(t, func(*testing.T) (repository.RefreshTokenRepository, repository.TransactionRepository) {
+		return NewRefreshTokenRepository(db), NewTransactionRepository(db)
+	})
+}
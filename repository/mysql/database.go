@@ -27,7 +27,13 @@ func NewTransactionRepository(db *sql.DB) repository.TransactionRepository {
 	}
 }
 
+// Transaction joins a transaction already in ctx rather than beginning another one, as that would
+// run on a second connection and commit independently of the outer transaction.
 func (tr *transactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if TxFromCtx(ctx) != nil {
+		return fn(ctx)
+	}
+
 	tx, err := tr.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
 		return err
@@ -79,6 +85,32 @@ func NewMySQLDB(ctx context.Context) (*sql.DB, error) {
 		return nil, err
 	}
 
+	db, err := Open(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.AutoMigrate {
+		if err = migrateUp(ctx, db); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// Connect connects to the database configured like for NewMySQLDB, without touching its schema.
+func Connect(ctx context.Context) (*sql.DB, error) {
+	conf, err := config.NewDBConfig(ctx, dbPrefix)
+	if err != nil {
+		log.Error("Failed to load database config", log.Ferror(err))
+		return nil, err
+	}
+	return Open(conf)
+}
+
+// Open connects to the database without touching its schema.
+func Open(conf *config.DBConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=true",
 		conf.User, conf.Password, conf.Host, conf.Port, conf.DBName)
 
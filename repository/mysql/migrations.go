@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/repository/migrate"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// NewMigrator returns the migrator of the MySQL schema, whose migrations are embedded in the binary.
+func NewMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(db, migrate.MySQL, fsys)
+}
+
+func migrateUp(ctx context.Context, db *sql.DB) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	applied, err := m.Up(ctx)
+	if err != nil {
+		log.Critical("Failed to migrate database", log.Ferror(err))
+		return err
+	}
+	log.Info("Database schema is up to date", log.Fint("applied", applied), log.Fint("version", m.Latest()))
+	return nil
+}
@@ -0,0 +1,7 @@
+-- IF NOT EXISTS adopts databases created by the former init/ddl.sql, whose schema this is.
+CREATE TABLE IF NOT EXISTS Users (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL
+);
@@ -0,0 +1,8 @@
+CREATE TABLE TaskTags (
+    task_id CHAR(36) NOT NULL,
+    tag_id CHAR(36) NOT NULL,
+    PRIMARY KEY (task_id, tag_id),
+    INDEX idx_task_tags_tag_id (tag_id),
+    CONSTRAINT fk_task_tags_task_id FOREIGN KEY (task_id) REFERENCES Tasks (id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_tags_tag_id FOREIGN KEY (tag_id) REFERENCES Tags (id) ON DELETE CASCADE
+);
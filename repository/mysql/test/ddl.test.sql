@@ -1,8 +1,11 @@
 CREATE DATABASE IF NOT EXISTS `goCleanArcTestDB` DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
 USE `goCleanArcTestDB`;
 
+DROP TABLE IF EXISTS TaskTags CASCADE;
 DROP TABLE IF EXISTS Tasks CASCADE;
 DROP TABLE IF EXISTS Users CASCADE;
+DROP TABLE IF EXISTS RefreshTokens CASCADE;
+DROP TABLE IF EXISTS Tags CASCADE;
 
 -- Tasks Table
 CREATE TABLE Tasks (
@@ -12,7 +15,9 @@ CREATE TABLE Tasks (
     description TEXT,
     duedate TIMESTAMP,
     priority INT,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    status VARCHAR(20) NOT NULL DEFAULT 'todo',
+    completed_at TIMESTAMP NULL
 );
 
 -- Users Table
@@ -20,5 +25,38 @@ CREATE TABLE Users (
     id CHAR(36) PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
     email VARCHAR(255) NOT NULL UNIQUE,
-    password VARCHAR(255) NOT NULL
-);
\ No newline at end of file
+    password VARCHAR(255) NOT NULL,
+    due_soon_hours INT NOT NULL DEFAULT 24
+);
+
+-- RefreshTokens Table
+CREATE TABLE RefreshTokens (
+    token_hash CHAR(64) PRIMARY KEY,
+    family_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    used BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    INDEX idx_refresh_tokens_family_id (family_id),
+    INDEX idx_refresh_tokens_user_id (user_id)
+);
+
+-- Tags Table
+CREATE TABLE Tags (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_tags_user_id_name (user_id, name)
+);
+
+-- TaskTags Table
+CREATE TABLE TaskTags (
+    task_id CHAR(36) NOT NULL,
+    tag_id CHAR(36) NOT NULL,
+    PRIMARY KEY (task_id, tag_id),
+    INDEX idx_task_tags_tag_id (tag_id),
+    CONSTRAINT fk_task_tags_task_id FOREIGN KEY (task_id) REFERENCES Tasks (id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_tags_tag_id FOREIGN KEY (tag_id) REFERENCES Tags (id) ON DELETE CASCADE
+);
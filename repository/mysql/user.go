@@ -5,17 +5,22 @@ import (
 	"database/sql"
 	"errors"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
 	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
+// duplicateEntry is the error number of a duplicate key.
+const duplicateEntry = 1062
+
 type userModel struct {
-	ID       string `db:"id"`
-	Name     string `db:"name"`
-	Email    string `db:"email"`
-	Password string `db:"password"`
+	ID           string `db:"id"`
+	Name         string `db:"name"`
+	Email        string `db:"email"`
+	Password     string `db:"password"`
+	DueSoonHours int    `db:"due_soon_hours"`
 }
 
 type userRepository struct {
@@ -47,14 +52,54 @@ func (ur *userRepository) Get(ctx context.Context, id string) (*entity.User, err
 		&um.Name,
 		&um.Email,
 		&um.Password,
+		&um.DueSoonHours,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &entity.User{
+		ID:           um.ID,
+		Name:         um.Name,
+		Email:        um.Email,
+		Password:     um.Password,
+		DueSoonHours: um.DueSoonHours,
+	}, nil
+}
+
+func (ur *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	executor := ur.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `SELECT *
+	FROM Users
+	WHERE email = ?
+	LIMIT 1`
+
+	row := executor.QueryRowContext(ctx, query, email)
+
+	var um userModel
+	if err := row.Scan(
+		&um.ID,
+		&um.Name,
+		&um.Email,
+		&um.Password,
+		&um.DueSoonHours,
 	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrUserNotFound
+		}
 		return nil, err
 	}
 	return &entity.User{
-		ID:       um.ID,
-		Name:     um.Name,
-		Email:    um.Email,
-		Password: um.Password,
+		ID:           um.ID,
+		Name:         um.Name,
+		Email:        um.Email,
+		Password:     um.Password,
+		DueSoonHours: um.DueSoonHours,
 	}, nil
 }
 
@@ -65,16 +110,17 @@ func (ur *userRepository) Create(ctx context.Context, user entity.User) error {
 	}
 
 	query := `INSERT INTO Users (
-	id, name, email, password
+	id, name, email, password, due_soon_hours
 	)
-	VALUES (?, ?, ?, ?)
+	VALUES (?, ?, ?, ?, ?)
 	`
 
 	um := userModel{
-		ID:       user.ID,
-		Name:     user.Name,
-		Email:    user.Email,
-		Password: user.Password,
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Password:     user.Password,
+		DueSoonHours: user.DueSoonHours,
 	}
 
 	if _, err := executor.ExecContext(
@@ -84,7 +130,11 @@ func (ur *userRepository) Create(ctx context.Context, user entity.User) error {
 		um.Name,
 		um.Email,
 		um.Password,
+		um.DueSoonHours,
 	); err != nil {
+		if isDuplicateEmail(err) {
+			return repository.ErrDuplicateEmail
+		}
 		return err
 	}
 	return nil
@@ -97,15 +147,16 @@ func (ur *userRepository) Update(ctx context.Context, user entity.User) error {
 	}
 
 	query := `UPDATE Users
-	SET name = ?, email = ?, password = ?
+	SET name = ?, email = ?, password = ?, due_soon_hours = ?
 	WHERE id = ?
 	`
 
 	um := userModel{
-		ID:       user.ID,
-		Name:     user.Name,
-		Email:    user.Email,
-		Password: user.Password,
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Password:     user.Password,
+		DueSoonHours: user.DueSoonHours,
 	}
 
 	if _, err := executor.ExecContext(
@@ -114,8 +165,12 @@ func (ur *userRepository) Update(ctx context.Context, user entity.User) error {
 		um.Name,
 		um.Email,
 		um.Password,
+		um.DueSoonHours,
 		um.ID,
 	); err != nil {
+		if isDuplicateEmail(err) {
+			return repository.ErrDuplicateEmail
+		}
 		return err
 	}
 	return nil
@@ -162,3 +217,10 @@ func (ur *userRepository) LockUserByEmail(ctx context.Context, email string) (bo
 	}
 	return true, nil
 }
+
+// isDuplicateEmail reports whether err is a duplicate key of Users, which is the email
+// because IDs are generated.
+func isDuplicateEmail(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
+package repository
+
+import "github.com/tusmasoma/go-clean-arch/entity"
+
+var ErrPasswordMismatch = entity.NewUnauthenticatedError("password does not match")
+
+type PasswordRepository interface {
+	Hash(password string) (string, error)
+	// Compare returns ErrPasswordMismatch when the password does not match the hash.
+	Compare(hash, password string) error
+	// NeedsRehash reports whether the hash was made with other parameters than the current ones.
+	NeedsRehash(hash string) bool
+}
@@ -3,7 +3,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"net"
+	"net/url"
 
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
@@ -27,7 +28,13 @@ func NewTransactionRepository(db *sql.DB) repository.TransactionRepository {
 	}
 }
 
+// Transaction joins a transaction already in ctx rather than beginning another one, as that would
+// run on a second connection and commit independently of the outer transaction.
 func (tr *transactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if TxFromCtx(ctx) != nil {
+		return fn(ctx)
+	}
+
 	tx, err := tr.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
 		return err
@@ -79,8 +86,39 @@ func NewPostgresDB(ctx context.Context) (*sql.DB, error) {
 		return nil, err
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=true",
-		conf.User, conf.Password, conf.Host, conf.Port, conf.DBName)
+	db, err := Open(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.AutoMigrate {
+		if err = migrateUp(ctx, db); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// Connect connects to the database configured like for NewPostgresDB, without touching its schema.
+func Connect(ctx context.Context) (*sql.DB, error) {
+	conf, err := config.NewDBConfig(ctx, dbPrefix)
+	if err != nil {
+		log.Error("Failed to load database config", log.Ferror(err))
+		return nil, err
+	}
+	return Open(conf)
+}
+
+// Open connects to the database without touching its schema.
+func Open(conf *config.DBConfig) (*sql.DB, error) {
+	dsn := (&url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(conf.User, conf.Password),
+		Host:     net.JoinHostPort(conf.Host, conf.Port),
+		Path:     conf.DBName,
+		RawQuery: "sslmode=disable",
+	}).String()
 
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
@@ -1,23 +1,4 @@
 CREATE DATABASE goCleanArcDB;
 
-\c goCleanArcDB;
-
-DROP TABLE IF EXISTS Tasks CASCADE;
-DROP TABLE IF EXISTS Users CASCADE;
-
-CREATE TABLE Tasks (
-    id CHAR(36) PRIMARY KEY,
-    user_id CHAR(36) NOT NULL,
-    title VARCHAR(255) NOT NULL,
-    description TEXT,
-    duedate TIMESTAMP,
-    priority INT,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE Users (
-    id CHAR(36) PRIMARY KEY,
-    name VARCHAR(255) NOT NULL,
-    email VARCHAR(255) NOT NULL UNIQUE,
-    password VARCHAR(255) NOT NULL
-);
\ No newline at end of file
+-- The tables are created by the versioned migrations in ../migrations, which are applied
+-- with `migrate up` or when the server starts with POSTGRES_AUTO_MIGRATE=true.
@@ -0,0 +1,10 @@
+-- IF NOT EXISTS adopts databases created by the former init/ddl.sql, whose schema this is.
+CREATE TABLE IF NOT EXISTS Tasks (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    duedate TIMESTAMP,
+    priority INT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
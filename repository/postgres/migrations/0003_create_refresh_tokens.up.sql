@@ -0,0 +1,11 @@
+CREATE TABLE RefreshTokens (
+    token_hash CHAR(64) PRIMARY KEY,
+    family_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    used BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX idx_refresh_tokens_family_id ON RefreshTokens (family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON RefreshTokens (user_id);
@@ -0,0 +1,3 @@
+ALTER TABLE Tasks
+    DROP COLUMN completed_at,
+    DROP COLUMN status;
@@ -0,0 +1,3 @@
+ALTER TABLE Tasks
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'todo',
+    ADD COLUMN completed_at TIMESTAMP NULL;
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type refreshTokenModel struct {
+	TokenHash string    `db:"token_hash"`
+	FamilyID  string    `db:"family_id"`
+	UserID    string    `db:"user_id"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	Used      bool      `db:"used"`
+	Revoked   bool      `db:"revoked"`
+}
+
+type refreshTokenRepository struct {
+	db SQLExecutor
+}
+
+func NewRefreshTokenRepository(db *sql.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+func (rr *refreshTokenRepository) Get(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `SELECT *
+	FROM RefreshTokens
+	WHERE token_hash = $1
+	LIMIT 1`
+
+	row := executor.QueryRowContext(ctx, query, tokenHash)
+
+	var rm refreshTokenModel
+	if err := row.Scan(
+		&rm.TokenHash,
+		&rm.FamilyID,
+		&rm.UserID,
+		&rm.ExpiresAt,
+		&rm.CreatedAt,
+		&rm.Used,
+		&rm.Revoked,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrRefreshTokenNotFound
+		}
+		return nil, err
+	}
+	return &entity.RefreshToken{
+		TokenHash: rm.TokenHash,
+		FamilyID:  rm.FamilyID,
+		UserID:    rm.UserID,
+		ExpiresAt: rm.ExpiresAt,
+		CreatedAt: rm.CreatedAt,
+		Used:      rm.Used,
+		Revoked:   rm.Revoked,
+	}, nil
+}
+
+func (rr *refreshTokenRepository) Create(ctx context.Context, token entity.RefreshToken) error {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `INSERT INTO RefreshTokens (
+	token_hash, family_id, user_id, expires_at, created_at, used, revoked
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if _, err := executor.ExecContext(
+		ctx,
+		query,
+		token.TokenHash,
+		token.FamilyID,
+		token.UserID,
+		token.ExpiresAt,
+		token.CreatedAt,
+		token.Used,
+		token.Revoked,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) MarkUsed(ctx context.Context, tokenHash string) (bool, error) {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `UPDATE RefreshTokens
+	SET used = TRUE
+	WHERE token_hash = $1 AND used = FALSE AND revoked = FALSE
+	`
+
+	result, err := executor.ExecContext(ctx, query, tokenHash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Error("Failed to get affected rows", log.Ferror(err))
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (rr *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `UPDATE RefreshTokens
+	SET revoked = TRUE
+	WHERE family_id = $1
+	`
+
+	if _, err := executor.ExecContext(ctx, query, familyID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) RevokeUser(ctx context.Context, userID string) error {
+	executor := rr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `UPDATE RefreshTokens
+	SET revoked = TRUE
+	WHERE user_id = $1
+	`
+
+	if _, err := executor.ExecContext(ctx, query, userID); err != nil {
+		return err
+	}
+	return nil
+}
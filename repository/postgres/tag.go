@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type tagModel struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type tagRepository struct {
+	db SQLExecutor
+	// txRepo makes Delete, which spans Tags and TaskTags, atomic outside a transaction too.
+	txRepo repository.TransactionRepository
+}
+
+func NewTagRepository(db *sql.DB) repository.TagRepository {
+	return &tagRepository{
+		db:     db,
+		txRepo: NewTransactionRepository(db),
+	}
+}
+
+func (tr *tagRepository) Get(ctx context.Context, id string) (*entity.Tag, error) {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `SELECT id, user_id, name, created_at
+	FROM Tags
+	WHERE id = $1
+	LIMIT 1
+	`
+
+	row := executor.QueryRowContext(ctx, query, id)
+
+	var tm tagModel
+	if err := row.Scan(&tm.ID, &tm.UserID, &tm.Name, &tm.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return &entity.Tag{
+		ID:        tm.ID,
+		UserID:    tm.UserID,
+		Name:      tm.Name,
+		CreatedAt: tm.CreatedAt,
+	}, nil
+}
+
+func (tr *tagRepository) List(ctx context.Context, userID string) ([]entity.Tag, error) {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `SELECT id, user_id, name, created_at
+	FROM Tags
+	WHERE user_id = $1
+	ORDER BY name
+	`
+
+	rows, err := executor.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []entity.Tag
+	for rows.Next() {
+		var tm tagModel
+		if err = rows.Scan(&tm.ID, &tm.UserID, &tm.Name, &tm.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, entity.Tag{
+			ID:        tm.ID,
+			UserID:    tm.UserID,
+			Name:      tm.Name,
+			CreatedAt: tm.CreatedAt,
+		})
+	}
+	return tags, rows.Err()
+}
+
+func (tr *tagRepository) Create(ctx context.Context, tag entity.Tag) error {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `INSERT INTO Tags (id, user_id, name, created_at)
+	VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err := executor.ExecContext(ctx, query, tag.ID, tag.UserID, tag.Name, tag.CreatedAt); err != nil {
+		if isDuplicateTagName(err) {
+			return repository.ErrDuplicateTagName
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr *tagRepository) Update(ctx context.Context, tag entity.Tag) error {
+	executor := tr.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `UPDATE Tags
+	SET name = $1
+	WHERE id = $2
+	`
+
+	if _, err := executor.ExecContext(ctx, query, tag.Name, tag.ID); err != nil {
+		if isDuplicateTagName(err) {
+			return repository.ErrDuplicateTagName
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr *tagRepository) Delete(ctx context.Context, id string) error {
+	return tr.txRepo.Transaction(ctx, func(ctx context.Context) error {
+		executor := TxFromCtx(ctx)
+
+		if _, err := executor.ExecContext(ctx, `DELETE FROM TaskTags WHERE tag_id = $1`, id); err != nil {
+			return err
+		}
+		if _, err := executor.ExecContext(ctx, `DELETE FROM Tags WHERE id = $1`, id); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// isDuplicateTagName reports whether err is a duplicate key of Tags, which is the name of a user
+// because IDs are generated.
+func isDuplicateTagName(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
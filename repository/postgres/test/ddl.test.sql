@@ -2,8 +2,11 @@ CREATE DATABASE goCleanArcTestDB;
 
 \c goCleanArcTestDB;
 
+DROP TABLE IF EXISTS TaskTags CASCADE;
 DROP TABLE IF EXISTS Tasks CASCADE;
 DROP TABLE IF EXISTS Users CASCADE;
+DROP TABLE IF EXISTS RefreshTokens CASCADE;
+DROP TABLE IF EXISTS Tags CASCADE;
 
 CREATE TABLE Tasks (
     id CHAR(36) PRIMARY KEY,
@@ -12,12 +15,42 @@ CREATE TABLE Tasks (
     description TEXT,
     duedate TIMESTAMP,
     priority INT,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    status VARCHAR(20) NOT NULL DEFAULT 'todo',
+    completed_at TIMESTAMP NULL
 );
 
 CREATE TABLE Users (
     id CHAR(36) PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
     email VARCHAR(255) NOT NULL UNIQUE,
-    password VARCHAR(255) NOT NULL
-);
\ No newline at end of file
+    password VARCHAR(255) NOT NULL,
+    due_soon_hours INT NOT NULL DEFAULT 24
+);
+
+CREATE TABLE RefreshTokens (
+    token_hash CHAR(64) PRIMARY KEY,
+    family_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    used BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX idx_refresh_tokens_family_id ON RefreshTokens (family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON RefreshTokens (user_id);
+
+CREATE TABLE Tags (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE TaskTags (
+    task_id CHAR(36) NOT NULL REFERENCES Tasks (id) ON DELETE CASCADE,
+    tag_id CHAR(36) NOT NULL REFERENCES Tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+CREATE INDEX idx_task_tags_tag_id ON TaskTags (tag_id);
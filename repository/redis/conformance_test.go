@@ -0,0 +1,39 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/repositorytest"
+)
+
+// Redis has no TransactionRepository, so the transaction cases are skipped.
+func Test_TaskRepository_conformance(t *testing.T) {
+	if client == nil {
+		t.Skip("Redis is not available")
+	}
+	repositorytest.TestTaskRepository(t, func(*testing.T) (repository.TaskRepository, repository.TransactionRepository) {
+		return NewTaskRepository(client), nil
+	})
+}
+
+func Test_CachedTaskRepository_conformance(t *testing.T) {
+	if client == nil {
+		t.Skip("Redis is not available")
+	}
+	conf := &config.TaskCacheConfig{Enabled: true, TTL: time.Minute, ListTTL: time.Minute}
+	repositorytest.TestTaskRepository(t, func(*testing.T) (repository.TaskRepository, repository.TransactionRepository) {
+		return NewCachedTaskRepository(client, NewTaskRepository(client), conf), nil
+	})
+}
+
+func Test_RefreshTokenRepository_conformance(t *testing.T) {
+	if client == nil {
+		t.Skip("Redis is not available")
+	}
+	repositorytest.TestRefreshTokenRepository(t, func(*testing.T) (repository.RefreshTokenRepository, repository.TransactionRepository) {
+		return NewRefreshTokenRepository(client), nil
+	})
+}
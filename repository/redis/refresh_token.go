@@ -0,0 +1,185 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// Refresh tokens are stored as JSON under refresh_token:<hash> and expire with the token.
+// The hashes of a family are kept in the set refresh_token_family:<family id>, and those of a user
+// in refresh_token_user:<user id>, so that they can be revoked at once.
+const (
+	refreshTokenKeyPrefix       = "refresh_token:"
+	refreshTokenFamilyKeyPrefix = "refresh_token_family:"
+	refreshTokenUserKeyPrefix   = "refresh_token_user:"
+)
+
+type refreshTokenRepository struct {
+	client *redis.Client
+}
+
+func NewRefreshTokenRepository(client *redis.Client) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		client: client,
+	}
+}
+
+func refreshTokenKey(tokenHash string) string {
+	return refreshTokenKeyPrefix + tokenHash
+}
+
+func refreshTokenFamilyKey(familyID string) string {
+	return refreshTokenFamilyKeyPrefix + familyID
+}
+
+func refreshTokenUserKey(userID string) string {
+	return refreshTokenUserKeyPrefix + userID
+}
+
+func (rr *refreshTokenRepository) Get(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	return rr.get(ctx, rr.client, tokenHash)
+}
+
+func (rr *refreshTokenRepository) get(ctx context.Context, cmd redis.Cmdable, tokenHash string) (*entity.RefreshToken, error) {
+	val, err := cmd.Get(ctx, refreshTokenKey(tokenHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, repository.ErrRefreshTokenNotFound
+	} else if err != nil {
+		log.Error("Failed to get refresh token", log.Ferror(err))
+		return nil, err
+	}
+	var token entity.RefreshToken
+	if err = json.Unmarshal([]byte(val), &token); err != nil {
+		log.Error("Failed to deserialize refresh token", log.Ferror(err))
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (rr *refreshTokenRepository) Create(ctx context.Context, token entity.RefreshToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		log.Error("Failed to serialize refresh token", log.Ferror(err))
+		return err
+	}
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		log.Warn("Refresh token already expired", log.Fstring("family_id", token.FamilyID))
+		return nil
+	}
+
+	familyKey := refreshTokenFamilyKey(token.FamilyID)
+	userKey := refreshTokenUserKey(token.UserID)
+	if _, err = rr.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, refreshTokenKey(token.TokenHash), data, ttl)
+		pipe.SAdd(ctx, familyKey, token.TokenHash)
+		pipe.SAdd(ctx, userKey, token.TokenHash)
+		// later tokens expire later, so the sets live as long as their newest token
+		pipe.ExpireAt(ctx, familyKey, token.ExpiresAt)
+		pipe.ExpireAt(ctx, userKey, token.ExpiresAt)
+		return nil
+	}); err != nil {
+		log.Error("Failed to set refresh token", log.Ferror(err))
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) MarkUsed(ctx context.Context, tokenHash string) (bool, error) {
+	marked := false
+	err := rr.update(ctx, tokenHash, func(token *entity.RefreshToken) bool {
+		if token.Used || token.Revoked {
+			return false
+		}
+		token.Used = true
+		marked = true
+		return true
+	})
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) || errors.Is(err, redis.TxFailedErr) {
+		// the token expired or was changed by a concurrent refresh or revocation
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return marked, nil
+}
+
+func (rr *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return rr.revokeSet(ctx, refreshTokenFamilyKey(familyID))
+}
+
+func (rr *refreshTokenRepository) RevokeUser(ctx context.Context, userID string) error {
+	return rr.revokeSet(ctx, refreshTokenUserKey(userID))
+}
+
+// revokeSet revokes every token whose hash is a member of the set.
+func (rr *refreshTokenRepository) revokeSet(ctx context.Context, setKey string) error {
+	hashes, err := rr.client.SMembers(ctx, setKey).Result()
+	if err != nil {
+		log.Error("Failed to get refresh token set", log.Fstring("key", setKey), log.Ferror(err))
+		return err
+	}
+	revoke := func(token *entity.RefreshToken) bool {
+		if token.Revoked {
+			return false
+		}
+		token.Revoked = true
+		return true
+	}
+	for _, hash := range hashes {
+		// retry when a concurrent refresh marked the token as used in between
+		for {
+			err = rr.update(ctx, hash, revoke)
+			if !errors.Is(err, redis.TxFailedErr) {
+				break
+			}
+		}
+		if err != nil && !errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// update applies fn to the stored token under optimistic locking and keeps its remaining lifetime.
+// fn returns false when nothing has to be written.
+// It fails with redis.TxFailedErr when the token was changed concurrently.
+func (rr *refreshTokenRepository) update(ctx context.Context, tokenHash string, fn func(token *entity.RefreshToken) bool) error {
+	key := refreshTokenKey(tokenHash)
+	return rr.client.Watch(ctx, func(tx *redis.Tx) error {
+		token, err := rr.get(ctx, tx, tokenHash)
+		if err != nil {
+			return err
+		}
+		if !fn(token) {
+			return nil
+		}
+		ttl, err := tx.PTTL(ctx, key).Result()
+		if err != nil {
+			log.Error("Failed to get refresh token ttl", log.Ferror(err))
+			return err
+		}
+		if ttl <= 0 {
+			return repository.ErrRefreshTokenNotFound
+		}
+		data, err := json.Marshal(token)
+		if err != nil {
+			log.Error("Failed to serialize refresh token", log.Ferror(err))
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, ttl)
+			return nil
+		})
+		return err
+	}, key)
+}
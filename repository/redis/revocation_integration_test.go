@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_RevocationRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRevocationRepository(client, &config.AuthConfig{AccessTokenTTL: 15 * time.Minute}, entity.NewClock())
+	// the task repository lists every key, so do not leave revocations behind
+	defer client.FlushDB(ctx)
+
+	now := time.Now()
+	userID := uuid.New().String()
+	old := repository.TokenClaims{JTI: uuid.New().String(), UserID: userID, IssuedAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)}
+	current := repository.TokenClaims{JTI: uuid.New().String(), UserID: userID, IssuedAt: now.Add(time.Second), ExpiresAt: now.Add(time.Hour)}
+
+	revoked, err := repo.IsRevoked(ctx, old)
+	ValidateErr(t, err, nil)
+	if revoked {
+		t.Fatalf("Token revoked before any revocation")
+	}
+
+	// RevokeToken
+	err = repo.RevokeToken(ctx, current.JTI, current.ExpiresAt)
+	ValidateErr(t, err, nil)
+	revoked, err = repo.IsRevoked(ctx, current)
+	ValidateErr(t, err, nil)
+	if !revoked {
+		t.Errorf("Failed to revoke the token")
+	}
+
+	// RevokeUserTokens
+	err = repo.RevokeUserTokens(ctx, userID, now)
+	ValidateErr(t, err, nil)
+	revoked, err = repo.IsRevoked(ctx, old)
+	ValidateErr(t, err, nil)
+	if !revoked {
+		t.Errorf("Failed to revoke a token issued before the cutoff")
+	}
+	revoked, err = repo.IsRevoked(ctx, repository.TokenClaims{JTI: uuid.New().String(), UserID: userID, IssuedAt: now.Add(time.Second)})
+	ValidateErr(t, err, nil)
+	if revoked {
+		t.Errorf("Revoked a token issued after the cutoff")
+	}
+
+	// a login in the second of the cutoff, whose iat is truncated to the second
+	issuedAt := now.Truncate(time.Second)
+	err = repo.RevokeUserTokens(ctx, userID, issuedAt.Add(500*time.Millisecond))
+	ValidateErr(t, err, nil)
+	revoked, err = repo.IsRevoked(ctx, repository.TokenClaims{JTI: uuid.New().String(), UserID: userID, IssuedAt: issuedAt})
+	ValidateErr(t, err, nil)
+	if revoked {
+		t.Errorf("Revoked a token issued in the second of the cutoff")
+	}
+}
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type cachedTagRepository struct {
+	next  repository.TagRepository
+	tasks *cachedTaskRepository
+}
+
+// NewCachedTagRepository keeps the task cache of tasks in front of the store consistent with next.
+// Deleting a tag detaches it from its tasks in the store without going through the TaskRepository,
+// so the cached tasks of the tag and the pages of its owner are invalidated once the deletion has committed.
+func NewCachedTagRepository(next repository.TagRepository, tasks CachedTaskRepository) repository.TagRepository {
+	cr, ok := tasks.(*cachedTaskRepository)
+	if !ok {
+		return next
+	}
+	return &cachedTagRepository{
+		next:  next,
+		tasks: cr,
+	}
+}
+
+func (cr *cachedTagRepository) Get(ctx context.Context, id string) (*entity.Tag, error) {
+	return cr.next.Get(ctx, id)
+}
+
+func (cr *cachedTagRepository) List(ctx context.Context, userID string) ([]entity.Tag, error) {
+	return cr.next.List(ctx, userID)
+}
+
+func (cr *cachedTagRepository) Create(ctx context.Context, tag entity.Tag) error {
+	return cr.next.Create(ctx, tag)
+}
+
+// Update leaves the cache alone, since cached tasks refer to their tags by ID only.
+func (cr *cachedTagRepository) Update(ctx context.Context, tag entity.Tag) error {
+	return cr.next.Update(ctx, tag)
+}
+
+// Delete reads the tasks of the tag from the store first, since they are the ones to invalidate.
+func (cr *cachedTagRepository) Delete(ctx context.Context, id string) error {
+	tag, err := cr.next.Get(ctx, id)
+	if errors.Is(err, repository.ErrTagNotFound) {
+		return cr.next.Delete(ctx, id)
+	} else if err != nil {
+		return err
+	}
+	tasks, _, err := cr.tasks.next.List(ctx, repository.TaskQuery{UserID: tag.UserID, TagIDs: []string{id}})
+	if err != nil {
+		return err
+	}
+	keys := []string{taskListCacheKey(tag.UserID)}
+	for _, task := range tasks {
+		keys = append(keys, taskCacheKey(task.ID))
+	}
+
+	if err = cr.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	repository.AfterCommit(ctx, func(ctx context.Context) {
+		cr.tasks.invalidate(ctx, keys...)
+	})
+	return nil
+}
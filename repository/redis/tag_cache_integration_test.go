@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/mock"
+)
+
+func Test_CachedTagRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	tasks, next := newCachedTaskRepositoryForTest(t)
+	tgr := mock.NewMockTagRepository(gomock.NewController(t))
+	repo := NewCachedTagRepository(tgr, tasks)
+
+	userID := uuid.New().String()
+	tag := entity.Tag{ID: uuid.New().String(), UserID: userID, Name: "backend"}
+	task, err := entity.NewTask(userID, "Task", "Description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+	task.TagIDs = []string{tag.ID}
+	untagged := *task
+	untagged.TagIDs = nil
+	query := repository.TaskQuery{UserID: userID, Limit: 10}
+
+	// cache the task and a page of its owner
+	next.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil).Times(1)
+	next.EXPECT().List(gomock.Any(), query).Return([]entity.Task{*task}, "", nil).Times(1)
+	_, err = tasks.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+	_, _, err = tasks.List(ctx, query)
+	ValidateErr(t, err, nil)
+
+	// Delete invalidates the tasks of the tag, which are read from the store, and the pages of the owner
+	tgr.EXPECT().Get(gomock.Any(), tag.ID).Return(&tag, nil)
+	next.EXPECT().List(gomock.Any(), repository.TaskQuery{UserID: userID, TagIDs: []string{tag.ID}}).Return([]entity.Task{*task}, "", nil)
+	tgr.EXPECT().Delete(gomock.Any(), tag.ID).Return(nil)
+	err = repo.Delete(ctx, tag.ID)
+	ValidateErr(t, err, nil)
+
+	next.EXPECT().Get(gomock.Any(), task.ID).Return(&untagged, nil)
+	got, err := tasks.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+	if len(got.TagIDs) != 0 {
+		t.Errorf("want: no tags, got: %v", got.TagIDs)
+	}
+	next.EXPECT().List(gomock.Any(), query).Return([]entity.Task{untagged}, "", nil)
+	gottasks, _, err := tasks.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || len(gottasks[0].TagIDs) != 0 {
+		t.Errorf("want: the task without tags, got: %v", gottasks)
+	}
+
+	// deleting a missing tag is left to the store
+	tgr.EXPECT().Get(gomock.Any(), "missing").Return(nil, repository.ErrTagNotFound)
+	tgr.EXPECT().Delete(gomock.Any(), "missing").Return(nil)
+	err = repo.Delete(ctx, "missing")
+	ValidateErr(t, err, nil)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
@@ -12,9 +14,69 @@ import (
 	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
-// By using userID as a key when saving tasks in Redis, you can efficiently manage tasks associated with each user.
-// For example, by setting a key in the format userID:taskID, you can easily retrieve and manipulate related tasks for each user.
-// This makes task management scalable and simplifies queries on a per-user basis.
+// Tasks are stored as JSON under task:<id>. The IDs of a user's tasks are kept in the sorted set
+// task_user:<user id>, scored by due date in Unix milliseconds, so that List reads only the tasks of
+// one user and can narrow a due date range in Redis.
+const (
+	taskKeyPrefix     = "task:"
+	taskUserKeyPrefix = "task_user:"
+)
+
+// createTaskScript stores a task and adds its ID to the index of its owner, unless the ID is taken.
+// KEYS: task key, index key of the owner. ARGV: task JSON, due date score, task ID.
+var createTaskScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[3])
+return 1
+`)
+
+// updateTaskScript overwrites an existing task of the expected owner, keeping its owner and creation
+// time, and updates its score in the index of the owner. It returns 0 for a missing task and -1 when
+// the task belongs to someone else.
+// KEYS: task key, index key of the owner. ARGV: task JSON, due date score, task ID, owner ID.
+var updateTaskScript = redis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+if not old then
+	return 0
+end
+local prev = cjson.decode(old)
+if prev['user_id'] ~= ARGV[4] then
+	return -1
+end
+local task = cjson.decode(ARGV[1])
+task['user_id'] = prev['user_id']
+task['created_at'] = prev['created_at']
+redis.call('SET', KEYS[1], cjson.encode(task))
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[3])
+return 1
+`)
+
+// deleteTaskScript removes a task of the expected owner and its ID from the index of the owner.
+// It returns 0 for a missing task and -1 when the task belongs to someone else.
+// KEYS: task key, index key of the owner. ARGV: task ID, owner ID.
+var deleteTaskScript = redis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+if not old then
+	return 0
+end
+if cjson.decode(old)['user_id'] ~= ARGV[2] then
+	return -1
+end
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('DEL', KEYS[1])
+return 1
+`)
+
+// taskOwnerMismatch is returned by the scripts when the task belongs to someone else than expected.
+const taskOwnerMismatch = -1
+
+var (
+	errDuplicateTaskID  = errors.New("task with this id already exists")
+	errTaskOwnerChanged = errors.New("task was replaced by a task of another user")
+)
 
 type taskRepository struct {
 	client *redis.Client
@@ -26,92 +88,163 @@ func NewTaskRepository(client *redis.Client) repository.TaskRepository {
 	}
 }
 
+func taskKey(id string) string {
+	return taskKeyPrefix + id
+}
+
+func taskUserKey(userID string) string {
+	return taskUserKeyPrefix + userID
+}
+
+func taskScore(task entity.Task) float64 {
+	return float64(task.DueDate.UnixMilli())
+}
+
 func (tr *taskRepository) Get(ctx context.Context, id string) (*entity.Task, error) {
-	val, err := tr.client.Get(ctx, id).Result()
+	val, err := tr.client.Get(ctx, taskKey(id)).Result()
 	if errors.Is(err, redis.Nil) {
-		log.Warn("Cache miss", log.Fstring("key", id))
-		return nil, errors.New("cache: key not found")
+		return nil, repository.ErrTaskNotFound
 	} else if err != nil {
-		log.Error("Failed to get cache", log.Ferror(err))
+		log.Error("Failed to get task", log.Fstring("id", id), log.Ferror(err))
 		return nil, err
 	}
-	tasks, err := tr.deserialize(val)
-	if err != nil {
-		log.Error("Failed to deserialize task", log.Ferror(err))
-		return nil, err
-	}
-	log.Info("Cache hit", log.Fstring("key", id))
-	return tasks, nil
+	return tr.deserialize(val)
 }
 
-func (tr *taskRepository) List(ctx context.Context, userID string) ([]entity.Task, error) {
-	ids, err := tr.client.Keys(ctx, "*").Result()
+// List reads the IDs from the user's index, narrowed to the due date range of the query,
+// fetches the tasks in one pipeline and evaluates the rest of the query in memory.
+func (tr *taskRepository) List(ctx context.Context, q repository.TaskQuery) ([]entity.Task, string, error) {
+	// the range is inclusive on both ends, the exact bounds are applied by ApplyTaskQuery
+	rng := &redis.ZRangeBy{Min: "-inf", Max: "+inf"}
+	if !q.DueAfter.IsZero() {
+		rng.Min = strconv.FormatInt(q.DueAfter.UnixMilli(), 10)
+	}
+	if !q.DueBefore.IsZero() {
+		rng.Max = strconv.FormatInt(q.DueBefore.UnixMilli(), 10)
+	}
+	ids, err := tr.client.ZRangeByScore(ctx, taskUserKey(q.UserID), rng).Result()
 	if err != nil {
-		log.Error("Failed to get keys", log.Ferror(err))
-		return nil, err
+		log.Error("Failed to get task index", log.Fstring("user_id", q.UserID), log.Ferror(err))
+		return nil, "", err
 	}
-	var tasks []entity.Task
-	for _, id := range ids {
-		val, err := tr.client.Get(ctx, id).Result() //nolint: govet // This is a false positive
-		if err != nil {
-			log.Error("Failed to get cache", log.Ferror(err))
-			return nil, err
+	if len(ids) == 0 {
+		return repository.ApplyTaskQuery(nil, q)
+	}
+
+	cmds, err := tr.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.Get(ctx, taskKey(id))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Error("Failed to get tasks", log.Ferror(err))
+		return nil, "", err
+	}
+
+	tasks := make([]entity.Task, 0, len(cmds))
+	for i, cmd := range cmds {
+		val, err := cmd.(*redis.StringCmd).Result() //nolint: govet // shadowing is intended
+		if errors.Is(err, redis.Nil) {
+			log.Warn("Task index refers to a missing task", log.Fstring("id", ids[i]))
+			continue
+		} else if err != nil {
+			log.Error("Failed to get task", log.Fstring("id", ids[i]), log.Ferror(err))
+			return nil, "", err
 		}
 		task, err := tr.deserialize(val)
 		if err != nil {
-			log.Error("Failed to deserialize task", log.Ferror(err))
-			return nil, err
-		}
-		if task.UserID != userID {
-			continue
+			return nil, "", err
 		}
 		tasks = append(tasks, *task)
-		log.Info("Cache hit", log.Fstring("key", id))
 	}
-	return tasks, nil
+	return repository.ApplyTaskQuery(tasks, q)
 }
 
 func (tr *taskRepository) Create(ctx context.Context, task entity.Task) error {
-	serializeTask, err := tr.serialize(task)
+	data, err := tr.serialize(task)
 	if err != nil {
-		log.Error("Failed to serialize Task", log.Ferror(err))
 		return err
 	}
-	if err = tr.client.Set(ctx, task.ID, serializeTask, 0).Err(); err != nil {
-		log.Error("Failed to set cache", log.Ferror(err))
+	keys := []string{taskKey(task.ID), taskUserKey(task.UserID)}
+	created, err := createTaskScript.Run(ctx, tr.client, keys, data, taskScore(task), task.ID).Int()
+	if err != nil {
+		log.Error("Failed to create task", log.Fstring("id", task.ID), log.Ferror(err))
 		return err
 	}
-	log.Info("Cache set successfully", log.Fstring("key", task.ID))
+	if created == 0 {
+		return errDuplicateTaskID
+	}
 	return nil
 }
 
+// Update runs the script with the owner of the task as the caller sees it. As the owner of a stored
+// task is kept, a different owner is looked up and the script runs once more.
 func (tr *taskRepository) Update(ctx context.Context, task entity.Task) error {
-	serializeTask, err := tr.serialize(task)
+	data, err := tr.serialize(task)
 	if err != nil {
-		log.Error("Failed to serialize Task", log.Ferror(err))
 		return err
 	}
-	if err = tr.client.Set(ctx, task.ID, serializeTask, 0).Err(); err != nil {
-		log.Error("Failed to set cache", log.Ferror(err))
+	update := func(owner string) (int, error) {
+		keys := []string{taskKey(task.ID), taskUserKey(owner)}
+		return updateTaskScript.Run(ctx, tr.client, keys, data, taskScore(task), task.ID, owner).Int()
+	}
+
+	updated, err := update(task.UserID)
+	if err == nil && updated == taskOwnerMismatch {
+		var owner string
+		var found bool
+		if owner, found, err = tr.owner(ctx, task.ID); err != nil || !found {
+			return err
+		}
+		updated, err = update(owner)
+	}
+	if err != nil {
+		log.Error("Failed to update task", log.Fstring("id", task.ID), log.Ferror(err))
 		return err
 	}
-	log.Info("Cache updated successfully", log.Fstring("key", task.ID))
+	if updated == taskOwnerMismatch {
+		return errTaskOwnerChanged
+	}
 	return nil
 }
 
+// Delete looks up the owner of the task first, as the script has to be given the index key.
 func (tr *taskRepository) Delete(ctx context.Context, id string) error {
-	if err := tr.client.Del(ctx, id).Err(); err != nil {
-		log.Error("Failed to delete cache", log.Ferror(err))
+	owner, found, err := tr.owner(ctx, id)
+	if err != nil || !found {
 		return err
 	}
-	log.Info("Cache deleted successfully", log.Fstring("key", id))
+	keys := []string{taskKey(id), taskUserKey(owner)}
+	deleted, err := deleteTaskScript.Run(ctx, tr.client, keys, id, owner).Int()
+	if err != nil {
+		log.Error("Failed to delete task", log.Fstring("id", id), log.Ferror(err))
+		return err
+	}
+	if deleted == taskOwnerMismatch {
+		return errTaskOwnerChanged
+	}
 	return nil
 }
 
-func (tr *taskRepository) serialize(tasks entity.Task) (string, error) {
-	data, err := json.Marshal(tasks)
+// owner returns the ID of the user that the task belongs to, and false when the task is missing.
+func (tr *taskRepository) owner(ctx context.Context, id string) (string, bool, error) {
+	task, err := tr.Get(ctx, id)
+	if errors.Is(err, repository.ErrTaskNotFound) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	return task.UserID, true, nil
+}
+
+// serialize stores the tags of the task as its sorted TagIDs only.
+func (tr *taskRepository) serialize(task entity.Task) (string, error) {
+	task.TagIDs = repository.DistinctIDs(task.TagIDs)
+	task.Tags = nil
+	data, err := json.Marshal(task)
 	if err != nil {
-		log.Error("Failed to serialize tasks", log.Ferror(err))
+		log.Error("Failed to serialize task", log.Ferror(err))
 		return "", err
 	}
 	return string(data), nil
@@ -120,8 +253,84 @@ func (tr *taskRepository) serialize(tasks entity.Task) (string, error) {
 func (tr *taskRepository) deserialize(data string) (*entity.Task, error) {
 	var task entity.Task
 	if err := json.Unmarshal([]byte(data), &task); err != nil {
-		log.Error("Failed to deserialize tasks", log.Ferror(err))
+		log.Error("Failed to deserialize task", log.Ferror(err))
 		return nil, err
 	}
 	return &task, nil
 }
+
+// taskLayoutVersionKey marks that tasks written under their bare ID have been migrated.
+const taskLayoutVersionKey = "task_layout_version"
+
+// MigrateTaskKeys moves tasks stored under their bare ID, as earlier versions did, to task:<id>
+// and adds them to the index of their owner. It runs once per database and returns the number of
+// migrated tasks. Keys are walked with SCAN, so Redis keeps serving other clients meanwhile.
+func MigrateTaskKeys(ctx context.Context, client *redis.Client) (int, error) {
+	done, err := client.Exists(ctx, taskLayoutVersionKey).Result()
+	if err != nil {
+		log.Error("Failed to get task layout version", log.Ferror(err))
+		return 0, err
+	}
+	if done != 0 {
+		return 0, nil
+	}
+
+	tr := &taskRepository{client: client}
+	migrated := 0
+	iter := client.Scan(ctx, 0, "*", 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		// every key of the current layouts is namespaced, an old task key is a bare UUID
+		if strings.Contains(key, ":") || key == taskLayoutVersionKey {
+			continue
+		}
+		ok, err := tr.migrateTask(ctx, key) //nolint: govet // shadowing is intended
+		if err != nil {
+			return migrated, err
+		}
+		if ok {
+			migrated++
+		}
+	}
+	if err = iter.Err(); err != nil {
+		log.Error("Failed to scan keys", log.Ferror(err))
+		return migrated, err
+	}
+
+	if err = client.Set(ctx, taskLayoutVersionKey, 2, 0).Err(); err != nil {
+		log.Error("Failed to set task layout version", log.Ferror(err))
+		return migrated, err
+	}
+	if migrated != 0 {
+		log.Info("Migrated tasks to the indexed layout", log.Fint("count", migrated))
+	}
+	return migrated, nil
+}
+
+// migrateTask moves the task under key to the current layout. It reports false and leaves the key
+// as it is when the key does not hold a task.
+func (tr *taskRepository) migrateTask(ctx context.Context, key string) (bool, error) {
+	val, err := tr.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	} else if err != nil {
+		// WRONGTYPE for keys holding sets or hashes
+		log.Warn("Skipped a key that is not a task", log.Fstring("key", key), log.Ferror(err))
+		return false, nil //nolint:nilerr // the key belongs to something else
+	}
+	var task entity.Task
+	if err = json.Unmarshal([]byte(val), &task); err != nil || task.ID != key || task.UserID == "" {
+		log.Warn("Skipped a key that is not a task", log.Fstring("key", key))
+		return false, nil //nolint:nilerr // the key belongs to something else
+	}
+	if _, err = tr.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, taskKey(task.ID), val, 0)
+		pipe.ZAdd(ctx, taskUserKey(task.UserID), &redis.Z{Score: taskScore(task), Member: task.ID})
+		pipe.Del(ctx, key)
+		return nil
+	}); err != nil {
+		log.Error("Failed to migrate task", log.Fstring("id", task.ID), log.Ferror(err))
+		return false, err
+	}
+	return true, nil
+}
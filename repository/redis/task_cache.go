@@ -0,0 +1,261 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// Cached tasks are stored as JSON under task_cache:<id>. Cached pages of List are fields of the hash
+// task_cache_list:<user id>, keyed by a digest of the query, so that every page of a user is
+// invalidated at once. The hash expires ListTTL after its first page was cached.
+const (
+	taskCacheKeyPrefix     = "task_cache:"
+	taskListCacheKeyPrefix = "task_cache_list:"
+)
+
+// setTaskPageScript caches a page without extending the lifetime of the pages cached before it.
+// KEYS: list cache key. ARGV: query digest, page JSON, TTL in milliseconds.
+var setTaskPageScript = redis.NewScript(`
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+end
+return 1
+`)
+
+// taskFlightTimeout bounds a read of the store that is shared by concurrent callers.
+const taskFlightTimeout = 10 * time.Second
+
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// CachedTaskRepository is a TaskRepository that reports how often its cache answered.
+type CachedTaskRepository interface {
+	repository.TaskRepository
+	Stats() CacheStats
+}
+
+type cachedTaskRepository struct {
+	client *redis.Client
+	next   repository.TaskRepository
+	conf   *config.TaskCacheConfig
+	group  singleflight.Group
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewCachedTaskRepository caches the results of Get and List of next in Redis.
+// Writes invalidate the cache once their transaction has committed, which requires the
+// TransactionRepository to be wrapped with repository.WithCommitHooks.
+// A page read right before a commit can still be cached after the invalidation, so the TTLs
+// bound how long results may be stale.
+// The cache is best effort: when Redis fails, the calls are served by next.
+func NewCachedTaskRepository(
+	client *redis.Client,
+	next repository.TaskRepository,
+	conf *config.TaskCacheConfig,
+) CachedTaskRepository {
+	return &cachedTaskRepository{
+		client: client,
+		next:   next,
+		conf:   conf,
+	}
+}
+
+func taskCacheKey(id string) string {
+	return taskCacheKeyPrefix + id
+}
+
+func taskListCacheKey(userID string) string {
+	return taskListCacheKeyPrefix + userID
+}
+
+type taskPage struct {
+	Tasks []entity.Task `json:"tasks"`
+	Next  string        `json:"next"`
+}
+
+func (cr *cachedTaskRepository) Stats() CacheStats {
+	return CacheStats{
+		Hits:   cr.hits.Load(),
+		Misses: cr.misses.Load(),
+	}
+}
+
+func (cr *cachedTaskRepository) Get(ctx context.Context, id string) (*entity.Task, error) {
+	// reads in a transaction have to see its own writes
+	if repository.InTransaction(ctx) {
+		return cr.next.Get(ctx, id)
+	}
+
+	key := taskCacheKey(id)
+	var task entity.Task
+	if cr.load(ctx, cr.client.Get(ctx, key), &task) {
+		return &task, nil
+	}
+
+	v, err := cr.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		t, err := cr.next.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		cr.store(t, func(data []byte) error {
+			return cr.client.Set(ctx, key, data, cr.conf.TTL).Err()
+		})
+		return *t, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	task = v.(entity.Task) //nolint:errcheck // the function above only returns entity.Task
+	return &task, nil
+}
+
+func (cr *cachedTaskRepository) List(ctx context.Context, q repository.TaskQuery) ([]entity.Task, string, error) {
+	// the result of an overdue filter depends on q.Now, which differs on every call
+	if repository.InTransaction(ctx) || q.Overdue != nil {
+		return cr.next.List(ctx, q)
+	}
+
+	key := taskListCacheKey(q.UserID)
+	field := taskQueryDigest(q)
+	var page taskPage
+	if cr.load(ctx, cr.client.HGet(ctx, key, field), &page) {
+		return page.Tasks, page.Next, nil
+	}
+
+	v, err := cr.do(ctx, key+":"+field, func(ctx context.Context) (interface{}, error) {
+		tasks, next, err := cr.next.List(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		p := taskPage{Tasks: tasks, Next: next}
+		cr.store(p, func(data []byte) error {
+			return setTaskPageScript.Run(ctx, cr.client, []string{key}, field, data, cr.conf.ListTTL.Milliseconds()).Err()
+		})
+		return p, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	page = v.(taskPage) //nolint:errcheck // the function above only returns taskPage
+	// callers sharing a flight must not share the backing array
+	return append([]entity.Task(nil), page.Tasks...), page.Next, nil
+}
+
+// do runs fn once for concurrent callers of the same key. The flight is shared, so it runs
+// detached from the context of the caller that started it, bounded by taskFlightTimeout,
+// and every caller stops waiting when its own context is done.
+func (cr *cachedTaskRepository) do(
+	ctx context.Context,
+	key string,
+	fn func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	ch := cr.group.DoChan(key, func() (interface{}, error) {
+		fctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), taskFlightTimeout)
+		defer cancel()
+		return fn(fctx)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		return res.Val, res.Err
+	}
+}
+
+func (cr *cachedTaskRepository) Create(ctx context.Context, task entity.Task) error {
+	if err := cr.next.Create(ctx, task); err != nil {
+		return err
+	}
+	repository.AfterCommit(ctx, func(ctx context.Context) {
+		cr.invalidate(ctx, taskListCacheKey(task.UserID))
+	})
+	return nil
+}
+
+func (cr *cachedTaskRepository) Update(ctx context.Context, task entity.Task) error {
+	if err := cr.next.Update(ctx, task); err != nil {
+		return err
+	}
+	repository.AfterCommit(ctx, func(ctx context.Context) {
+		cr.invalidate(ctx, taskCacheKey(task.ID), taskListCacheKey(task.UserID))
+	})
+	return nil
+}
+
+// Delete reads the task first, since the pages to invalidate are those of its owner.
+func (cr *cachedTaskRepository) Delete(ctx context.Context, id string) error {
+	keys := []string{taskCacheKey(id)}
+	task, err := cr.next.Get(ctx, id)
+	if err == nil {
+		keys = append(keys, taskListCacheKey(task.UserID))
+	} else if !errors.Is(err, repository.ErrTaskNotFound) {
+		return err
+	}
+	if err = cr.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	repository.AfterCommit(ctx, func(ctx context.Context) {
+		cr.invalidate(ctx, keys...)
+	})
+	return nil
+}
+
+// load decodes a cached value into v and counts the lookup.
+// It reports false on a miss, including when Redis fails.
+func (cr *cachedTaskRepository) load(ctx context.Context, cmd *redis.StringCmd, v interface{}) bool {
+	data, err := cmd.Bytes()
+	if err == nil {
+		if err = json.Unmarshal(data, v); err == nil {
+			cr.hits.Add(1)
+			return true
+		}
+		log.Warn("Failed to deserialize cached task", log.Ferror(err))
+	} else if !errors.Is(err, redis.Nil) {
+		log.Warn("Failed to get cached task", log.Ferror(err))
+	}
+	cr.misses.Add(1)
+	return false
+}
+
+func (cr *cachedTaskRepository) store(v interface{}, set func(data []byte) error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Warn("Failed to serialize task for the cache", log.Ferror(err))
+		return
+	}
+	if err = set(data); err != nil {
+		log.Warn("Failed to cache task", log.Ferror(err))
+	}
+}
+
+func (cr *cachedTaskRepository) invalidate(ctx context.Context, keys ...string) {
+	if err := cr.client.Del(ctx, keys...).Err(); err != nil {
+		log.Warn("Failed to invalidate task cache", log.Fany("keys", keys), log.Ferror(err))
+	}
+}
+
+// taskQueryDigest identifies a query within the pages of its user. q.Now is left out,
+// since it only matters for overdue filters, which are not cached.
+func taskQueryDigest(q repository.TaskQuery) string {
+	q.Now = time.Time{}
+	data, _ := json.Marshal(q)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,207 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/mock"
+)
+
+type inlineTransactionRepository struct{}
+
+func (inlineTransactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func newCachedTaskRepositoryForTest(t *testing.T) (CachedTaskRepository, *mock.MockTaskRepository) {
+	t.Helper()
+	next := mock.NewMockTaskRepository(gomock.NewController(t))
+	conf := &config.TaskCacheConfig{Enabled: true, TTL: time.Minute, ListTTL: time.Minute}
+	return NewCachedTaskRepository(client, next, conf), next
+}
+
+func Test_CachedTaskRepository_Get(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newCachedTaskRepositoryForTest(t)
+
+	task, err := entity.NewTask(uuid.New().String(), "Task", "Description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+
+	// concurrent misses share one read of the store
+	next.EXPECT().Get(gomock.Any(), task.ID).DoAndReturn(func(context.Context, string) (*entity.Task, error) {
+		time.Sleep(50 * time.Millisecond)
+		return task, nil
+	}).Times(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := repo.Get(ctx, task.ID) //nolint: govet // shadowing is intended
+			if err != nil || got.ID != task.ID {
+				t.Errorf("want: %v, got: %v, %v", task.ID, got, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := repo.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+	if got.Title != task.Title {
+		t.Errorf("want: %v, got: %v", task.Title, got.Title)
+	}
+	if stats := repo.Stats(); stats.Hits == 0 || stats.Hits+stats.Misses != 6 {
+		t.Errorf("want: 6 lookups with at least one hit, got: %+v", stats)
+	}
+
+	// Update invalidates the cached task
+	task.Title = "Updated Task"
+	next.EXPECT().Update(gomock.Any(), *task).Return(nil)
+	err = repo.Update(ctx, *task)
+	ValidateErr(t, err, nil)
+
+	next.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil)
+	got, err = repo.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+	if got.Title != "Updated Task" {
+		t.Errorf("want: %v, got: %v", "Updated Task", got.Title)
+	}
+
+	// errors of the store are not cached
+	next.EXPECT().Get(gomock.Any(), "missing").Return(nil, repository.ErrTaskNotFound).Times(2)
+	_, err = repo.Get(ctx, "missing")
+	ValidateErr(t, err, repository.ErrTaskNotFound)
+	_, err = repo.Get(ctx, "missing")
+	ValidateErr(t, err, repository.ErrTaskNotFound)
+
+	// a caller giving up neither cancels the shared read nor fails the others
+	other, err := entity.NewTask(uuid.New().String(), "Other Task", "Description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+	next.EXPECT().Get(gomock.Any(), other.ID).DoAndReturn(func(ctx context.Context, _ string) (*entity.Task, error) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+			return other, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}).Times(1)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	var gotErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, gotErr = repo.Get(cancelCtx, other.ID)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	got, err = repo.Get(ctx, other.ID)
+	ValidateErr(t, err, nil)
+	if got.ID != other.ID {
+		t.Errorf("want: %v, got: %v", other.ID, got.ID)
+	}
+	wg.Wait()
+	ValidateErr(t, gotErr, context.Canceled)
+}
+
+func Test_CachedTaskRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newCachedTaskRepositoryForTest(t)
+
+	userID := uuid.New().String()
+	task, err := entity.NewTask(userID, "Task", "Description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+	query := repository.TaskQuery{UserID: userID, Limit: 10, Now: time.Now()}
+
+	next.EXPECT().List(gomock.Any(), gomock.Any()).Return([]entity.Task{*task}, "", nil).Times(1)
+	for i := 0; i < 2; i++ {
+		// Now differs between calls, but does not affect the result
+		query.Now = query.Now.Add(time.Second)
+		gottasks, _, err := repo.List(ctx, query) //nolint: govet // shadowing is intended
+		ValidateErr(t, err, nil)
+		if len(gottasks) != 1 || gottasks[0].ID != task.ID {
+			t.Errorf("want: %v, got: %v", task.ID, gottasks)
+		}
+	}
+
+	// overdue filters are not cached
+	overdue := true
+	overdueQuery := query
+	overdueQuery.Overdue = &overdue
+	next.EXPECT().List(gomock.Any(), overdueQuery).Return(nil, "", nil)
+	_, _, err = repo.List(ctx, overdueQuery)
+	ValidateErr(t, err, nil)
+
+	// Delete invalidates the pages of the owner
+	next.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil)
+	next.EXPECT().Delete(gomock.Any(), task.ID).Return(nil)
+	err = repo.Delete(ctx, task.ID)
+	ValidateErr(t, err, nil)
+
+	next.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, "", nil)
+	gottasks, _, err := repo.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 0 {
+		t.Errorf("want: no tasks, got: %v", gottasks)
+	}
+}
+
+func Test_CachedTaskRepository_transaction(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newCachedTaskRepositoryForTest(t)
+	tr := repository.WithCommitHooks(inlineTransactionRepository{})
+
+	task, err := entity.NewTask(uuid.New().String(), "Task", "Description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+
+	next.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil)
+	_, err = repo.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+
+	// a rolled back update keeps the cache, and the transaction reads from the store
+	next.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	next.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil)
+	errRollback := errors.New("rollback")
+	err = tr.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Update(ctx, *task); err != nil { //nolint: govet // shadowing is intended
+			return err
+		}
+		if _, err := repo.Get(ctx, task.ID); err != nil { //nolint: govet // shadowing is intended
+			return err
+		}
+		return errRollback
+	})
+	ValidateErr(t, err, errRollback)
+	_, err = repo.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+
+	// a committed update invalidates the cache once the transaction is over
+	next.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	err = tr.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Update(ctx, *task); err != nil { //nolint: govet // shadowing is intended
+			return err
+		}
+		n, err := client.Exists(ctx, taskCacheKey(task.ID)).Result()
+		if err != nil {
+			return err
+		}
+		if n != 1 {
+			t.Errorf("want: cache kept until commit, got: %v keys", n)
+		}
+		return nil
+	})
+	ValidateErr(t, err, nil)
+	n, err := client.Exists(ctx, taskCacheKey(task.ID)).Result()
+	ValidateErr(t, err, nil)
+	if n != 0 {
+		t.Errorf("want: cache invalidated after commit, got: %v keys", n)
+	}
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
 func Test_TaskRepository(t *testing.T) {
@@ -24,6 +26,7 @@ func Test_TaskRepository(t *testing.T) {
 		"First Description",
 		time.Now().Add(24*time.Hour),
 		3,
+		time.Now(),
 	)
 	ValidateErr(t, err, nil)
 	task2, err := entity.NewTask(
@@ -32,6 +35,7 @@ func Test_TaskRepository(t *testing.T) {
 		"Second Description",
 		time.Now().Add(48*time.Hour),
 		4,
+		time.Now(),
 	)
 	ValidateErr(t, err, nil)
 
@@ -49,29 +53,147 @@ func Test_TaskRepository(t *testing.T) {
 	}
 
 	// List
-	gottasks, err := repo.List(ctx, userID)
+	gottasks, next, err := repo.List(ctx, repository.TaskQuery{UserID: userID})
 	ValidateErr(t, err, nil)
-	if len(gottasks) != 2 {
-		t.Errorf("want: %v, got: %v", 2, len(gottasks))
+	if len(gottasks) != 2 || next != "" {
+		t.Errorf("want: %v tasks and no cursor, got: %v tasks and cursor %q", 2, len(gottasks), next)
+	}
+
+	// List sorted by due date in descending order, one task per page
+	query := repository.TaskQuery{
+		UserID:      userID,
+		MinPriority: entity.Medium,
+		SortBy:      repository.TaskSortDueDate,
+		SortDesc:    true,
+		Limit:       1,
+	}
+	gottasks, next, err = repo.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task2.ID || next == "" {
+		t.Fatalf("want: first page with %v and a cursor, got: %v and cursor %q", task2.ID, gottasks, next)
+	}
+	query.Cursor = next
+	gottasks, next, err = repo.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task1.ID || next != "" {
+		t.Errorf("want: last page with %v, got: %v and cursor %q", task1.ID, gottasks, next)
+	}
+
+	// List by title prefix
+	gottasks, _, err = repo.List(ctx, repository.TaskQuery{UserID: userID, TitlePrefix: "Second"})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task2.ID {
+		t.Errorf("want: %v, got: %v", task2.ID, gottasks)
 	}
 
 	// Update
 	gottask.Title = "Updated First Task"
+	err = gottask.SetStatus(entity.StatusDone, time.Now())
+	ValidateErr(t, err, nil)
 	err = repo.Update(ctx, *gottask)
 	ValidateErr(t, err, nil)
 
 	updatedtask, err := repo.Get(ctx, task1.ID)
 	ValidateErr(t, err, nil)
-	if d := cmp.Diff(gottask, updatedtask, cmpopts.IgnoreFields(entity.Task{}, "CreatedAt")); len(d) != 0 {
+	if d := cmp.Diff(gottask, updatedtask, cmpopts.IgnoreFields(entity.Task{}, "CreatedAt", "CompletedAt")); len(d) != 0 {
 		t.Errorf("differs: (-want +got)\n%s", d)
 	}
+	if updatedtask.CompletedAt == nil {
+		t.Errorf("want: completed_at to be set, got: nil")
+	}
 
 	// Delete
 	err = repo.Delete(ctx, task1.ID)
 	ValidateErr(t, err, nil)
 
 	_, err = repo.Get(ctx, task1.ID)
-	if err == nil {
-		t.Errorf("want: %v, got: %v", nil, err)
+	ValidateErr(t, err, repository.ErrTaskNotFound)
+}
+
+func Test_TaskRepository_index(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTaskRepository(client)
+
+	task, err := entity.NewTask(uuid.New().String(), "Task", "Description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+	err = repo.Create(ctx, *task)
+	ValidateErr(t, err, nil)
+
+	// Update keeps the task in the index of its owner, under its new due date
+	updated := *task
+	updated.UserID = uuid.New().String()
+	updated.DueDate = task.DueDate.Add(-time.Hour)
+	err = repo.Update(ctx, updated)
+	ValidateErr(t, err, nil)
+
+	gottasks, _, err := repo.List(ctx, repository.TaskQuery{UserID: updated.UserID})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 0 {
+		t.Errorf("want: no tasks for another user, got: %v", gottasks)
+	}
+	gottasks, _, err = repo.List(ctx, repository.TaskQuery{UserID: task.UserID, DueBefore: task.DueDate})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task.ID {
+		t.Errorf("want: %v, got: %v", task.ID, gottasks)
+	}
+
+	// List narrows the due date range in the index
+	gottasks, _, err = repo.List(ctx, repository.TaskQuery{UserID: task.UserID, DueAfter: task.DueDate.Add(time.Hour)})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 0 {
+		t.Errorf("want: no tasks due after the range, got: %v", gottasks)
+	}
+
+	// Delete removes the task from the index
+	err = repo.Delete(ctx, task.ID)
+	ValidateErr(t, err, nil)
+	n, err := client.ZCard(ctx, taskUserKey(task.UserID)).Result()
+	ValidateErr(t, err, nil)
+	if n != 0 {
+		t.Errorf("want: empty index, got: %v members", n)
+	}
+}
+
+func Test_MigrateTaskKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTaskRepository(client)
+
+	err := client.Del(ctx, taskLayoutVersionKey).Err()
+	ValidateErr(t, err, nil)
+
+	task, err := entity.NewTask(uuid.New().String(), "Old Task", "Old Description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+	data, err := json.Marshal(task)
+	ValidateErr(t, err, nil)
+	err = client.Set(ctx, task.ID, data, 0).Err()
+	ValidateErr(t, err, nil)
+	// a bare key that is not a task is left alone
+	err = client.Set(ctx, "not-a-task", "value", 0).Err()
+	ValidateErr(t, err, nil)
+
+	migrated, err := MigrateTaskKeys(ctx, client)
+	ValidateErr(t, err, nil)
+	if migrated != 1 {
+		t.Errorf("want: %v migrated tasks, got: %v", 1, migrated)
+	}
+
+	gottasks, _, err := repo.List(ctx, repository.TaskQuery{UserID: task.UserID})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task.ID {
+		t.Errorf("want: %v, got: %v", task.ID, gottasks)
+	}
+	n, err := client.Exists(ctx, task.ID, "not-a-task").Result()
+	ValidateErr(t, err, nil)
+	if n != 1 {
+		t.Errorf("want: only the key that is not a task to remain, got: %v keys", n)
+	}
+
+	// the migration runs only once
+	err = client.Set(ctx, task.ID, data, 0).Err()
+	ValidateErr(t, err, nil)
+	migrated, err = MigrateTaskKeys(ctx, client)
+	ValidateErr(t, err, nil)
+	if migrated != 0 {
+		t.Errorf("want: no migrated tasks on the second run, got: %v", migrated)
 	}
 }
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+var ErrRefreshTokenNotFound = entity.NewNotFoundError("refresh token not found")
+
+type RefreshTokenRepository interface {
+	// Get returns ErrRefreshTokenNotFound when no token has the hash.
+	Get(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	Create(ctx context.Context, token entity.RefreshToken) error
+	// MarkUsed marks an unused and unrevoked token as used in a single atomic step.
+	// It returns false when the token was already used or revoked, so that two concurrent
+	// refreshes with the same token cannot both succeed.
+	MarkUsed(ctx context.Context, tokenHash string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeUser(ctx context.Context, userID string) error
+}
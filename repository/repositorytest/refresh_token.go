@@ -0,0 +1,230 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// RefreshTokenFactory returns the RefreshTokenRepository under test and the TransactionRepository of its backend,
+// or nil when the backend has no transactions. It is called like TaskFactory.
+type RefreshTokenFactory func(t *testing.T) (repository.RefreshTokenRepository, repository.TransactionRepository)
+
+// TestRefreshTokenRepository runs the conformance cases of RefreshTokenRepository against the repositories of newRepo.
+func TestRefreshTokenRepository(t *testing.T, newRepo RefreshTokenFactory) {
+	patterns := []struct {
+		name string
+		run  func(t *testing.T, repo repository.RefreshTokenRepository, txRepo repository.TransactionRepository)
+	}{
+		{name: "Create and Get", run: testRefreshTokenCreateAndGet},
+		{name: "Get a missing token", run: testRefreshTokenGetMissing},
+		{name: "MarkUsed", run: testRefreshTokenMarkUsed},
+		{name: "MarkUsed concurrently", run: testRefreshTokenMarkUsedConcurrently},
+		{name: "RevokeFamily", run: testRefreshTokenRevokeFamily},
+		{name: "RevokeUser", run: testRefreshTokenRevokeUser},
+		{name: "Transaction rollback", run: testRefreshTokenTransactionRollback},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, txRepo := newRepo(t)
+			tt.run(t, repo, txRepo)
+		})
+	}
+}
+
+// newRefreshToken returns a token that is valid for an hour, as backends may drop expired tokens.
+func newRefreshToken(familyID, userID string) entity.RefreshToken {
+	now := time.Now().UTC().Truncate(time.Second)
+	return entity.RefreshToken{
+		TokenHash: entity.HashRefreshToken(uuid.New().String()),
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+}
+
+func createRefreshTokens(ctx context.Context, t *testing.T, repo repository.RefreshTokenRepository, tokens ...entity.RefreshToken) {
+	t.Helper()
+	for _, token := range tokens {
+		if err := repo.Create(ctx, token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+}
+
+// diffRefreshToken compares tokens by instant rather than by location.
+func diffRefreshToken(want, got interface{}) string {
+	return cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) }))
+}
+
+func assertRefreshToken(ctx context.Context, t *testing.T, repo repository.RefreshTokenRepository, want entity.RefreshToken) {
+	t.Helper()
+	got, err := repo.Get(ctx, want.TokenHash)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if d := diffRefreshToken(&want, got); len(d) != 0 {
+		t.Errorf("Get() differs: (-want +got)\n%s", d)
+	}
+}
+
+func assertRefreshTokenNotFound(ctx context.Context, t *testing.T, repo repository.RefreshTokenRepository, tokenHash string) {
+	t.Helper()
+	if _, err := repo.Get(ctx, tokenHash); !errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, repository.ErrRefreshTokenNotFound)
+	}
+}
+
+func assertMarkUsed(ctx context.Context, t *testing.T, repo repository.RefreshTokenRepository, tokenHash string, want bool) {
+	t.Helper()
+	marked, err := repo.MarkUsed(ctx, tokenHash)
+	if err != nil {
+		t.Fatalf("MarkUsed() error = %v", err)
+	}
+	if marked != want {
+		t.Errorf("MarkUsed() = %v, want %v", marked, want)
+	}
+}
+
+func testRefreshTokenCreateAndGet(t *testing.T, repo repository.RefreshTokenRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	token := newRefreshToken(uuid.New().String(), uuid.New().String())
+	createRefreshTokens(ctx, t, repo, token)
+	assertRefreshToken(ctx, t, repo, token)
+}
+
+func testRefreshTokenGetMissing(t *testing.T, repo repository.RefreshTokenRepository, _ repository.TransactionRepository) {
+	assertRefreshTokenNotFound(context.Background(), t, repo, entity.HashRefreshToken(uuid.New().String()))
+}
+
+func testRefreshTokenMarkUsed(t *testing.T, repo repository.RefreshTokenRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	token := newRefreshToken(uuid.New().String(), uuid.New().String())
+	createRefreshTokens(ctx, t, repo, token)
+
+	assertMarkUsed(ctx, t, repo, token.TokenHash, true)
+	want := token
+	want.Used = true
+	assertRefreshToken(ctx, t, repo, want)
+
+	// a used token cannot be used again
+	assertMarkUsed(ctx, t, repo, token.TokenHash, false)
+	// neither can a missing one
+	assertMarkUsed(ctx, t, repo, entity.HashRefreshToken(uuid.New().String()), false)
+}
+
+func testRefreshTokenMarkUsedConcurrently(t *testing.T, repo repository.RefreshTokenRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	token := newRefreshToken(uuid.New().String(), uuid.New().String())
+	createRefreshTokens(ctx, t, repo, token)
+
+	const n = 10
+	var marked atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.MarkUsed(ctx, token.TokenHash)
+			if err != nil {
+				t.Errorf("MarkUsed() error = %v", err)
+			}
+			if ok {
+				marked.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := marked.Load(); got != 1 {
+		t.Errorf("MarkUsed() succeeded %d times, want once", got)
+	}
+}
+
+func testRefreshTokenRevokeFamily(t *testing.T, repo repository.RefreshTokenRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	familyID, userID := uuid.New().String(), uuid.New().String()
+	used := newRefreshToken(familyID, userID)
+	unused := newRefreshToken(familyID, userID)
+	other := newRefreshToken(uuid.New().String(), userID)
+	createRefreshTokens(ctx, t, repo, used, unused, other)
+	assertMarkUsed(ctx, t, repo, used.TokenHash, true)
+
+	if err := repo.RevokeFamily(ctx, familyID); err != nil {
+		t.Fatalf("RevokeFamily() error = %v", err)
+	}
+
+	wantUsed := used
+	wantUsed.Used, wantUsed.Revoked = true, true
+	assertRefreshToken(ctx, t, repo, wantUsed)
+	wantUnused := unused
+	wantUnused.Revoked = true
+	assertRefreshToken(ctx, t, repo, wantUnused)
+	assertRefreshToken(ctx, t, repo, other)
+
+	// a revoked token cannot be used
+	assertMarkUsed(ctx, t, repo, unused.TokenHash, false)
+}
+
+func testRefreshTokenRevokeUser(t *testing.T, repo repository.RefreshTokenRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	first := newRefreshToken(uuid.New().String(), userID)
+	second := newRefreshToken(uuid.New().String(), userID)
+	other := newRefreshToken(uuid.New().String(), uuid.New().String())
+	createRefreshTokens(ctx, t, repo, first, second, other)
+
+	if err := repo.RevokeUser(ctx, userID); err != nil {
+		t.Fatalf("RevokeUser() error = %v", err)
+	}
+
+	for _, token := range []entity.RefreshToken{first, second} {
+		want := token
+		want.Revoked = true
+		assertRefreshToken(ctx, t, repo, want)
+	}
+	assertRefreshToken(ctx, t, repo, other)
+
+	// revoking a user without tokens is not an error
+	if err := repo.RevokeUser(ctx, uuid.New().String()); err != nil {
+		t.Errorf("RevokeUser() error = %v", err)
+	}
+}
+
+func testRefreshTokenTransactionRollback(t *testing.T, repo repository.RefreshTokenRepository, txRepo repository.TransactionRepository) {
+	if txRepo == nil {
+		t.Skip("the backend has no transactions")
+	}
+	ctx := context.Background()
+	existing := newRefreshToken(uuid.New().String(), uuid.New().String())
+	createRefreshTokens(ctx, t, repo, existing)
+
+	created := newRefreshToken(uuid.New().String(), uuid.New().String())
+	err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, created); err != nil {
+			return err
+		}
+		if _, err := repo.MarkUsed(ctx, existing.TokenHash); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+
+	assertRefreshTokenNotFound(ctx, t, repo, created.TokenHash)
+	assertRefreshToken(ctx, t, repo, existing)
+}
@@ -0,0 +1,391 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// TagFactory returns the TagRepository under test, the TaskRepository that shares its storage,
+// and the TransactionRepository of its backend, or nil when the backend has no transactions.
+// It is called like TaskFactory.
+type TagFactory func(t *testing.T) (repository.TagRepository, repository.TaskRepository, repository.TransactionRepository)
+
+// TestTagRepository runs the conformance cases of TagRepository against the repositories of newRepo.
+func TestTagRepository(t *testing.T, newRepo TagFactory) {
+	patterns := []struct {
+		name string
+		run  func(t *testing.T, repo repository.TagRepository, taskRepo repository.TaskRepository, txRepo repository.TransactionRepository)
+	}{
+		{name: "Create and Get", run: testTagCreateAndGet},
+		{name: "Get a missing tag", run: testTagGetMissing},
+		{name: "Create a taken name", run: testTagCreateTakenName},
+		{name: "List", run: testTagList},
+		{name: "Update", run: testTagUpdate},
+		{name: "Update a missing tag", run: testTagUpdateMissing},
+		{name: "Delete", run: testTagDelete},
+		{name: "Task tags", run: testTagTaskTags},
+		{name: "List tasks by tags", run: testTagListTasks},
+		{name: "Transaction rollback", run: testTagTransactionRollback},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, taskRepo, txRepo := newRepo(t)
+			tt.run(t, repo, taskRepo, txRepo)
+		})
+	}
+}
+
+func newTag(userID, name string) entity.Tag {
+	return entity.Tag{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: base,
+	}
+}
+
+func createTags(ctx context.Context, t *testing.T, repo repository.TagRepository, tags ...entity.Tag) {
+	t.Helper()
+	for _, tag := range tags {
+		if err := repo.Create(ctx, tag); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+}
+
+// diffTag compares tags by instant rather than by location.
+func diffTag(want, got interface{}) string {
+	return cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) }))
+}
+
+func assertTag(ctx context.Context, t *testing.T, repo repository.TagRepository, want entity.Tag) {
+	t.Helper()
+	got, err := repo.Get(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if d := diffTag(&want, got); len(d) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", d)
+	}
+}
+
+func assertTagNotFound(ctx context.Context, t *testing.T, repo repository.TagRepository, id string) {
+	t.Helper()
+	if _, err := repo.Get(ctx, id); !errors.Is(err, repository.ErrTagNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, repository.ErrTagNotFound)
+	}
+}
+
+func assertTags(ctx context.Context, t *testing.T, repo repository.TagRepository, userID string, want []entity.Tag) {
+	t.Helper()
+	got, err := repo.List(ctx, userID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(want) == 0 && len(got) == 0 {
+		return
+	}
+	if d := diffTag(want, got); len(d) != 0 {
+		t.Errorf("List() differs: (-want +got)\n%s", d)
+	}
+}
+
+func testTagCreateAndGet(t *testing.T, repo repository.TagRepository, _ repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	tag := newTag(uuid.New().String(), "backend")
+	createTags(ctx, t, repo, tag)
+	assertTag(ctx, t, repo, tag)
+}
+
+func testTagGetMissing(t *testing.T, repo repository.TagRepository, _ repository.TaskRepository, _ repository.TransactionRepository) {
+	assertTagNotFound(context.Background(), t, repo, uuid.New().String())
+}
+
+func testTagCreateTakenName(t *testing.T, repo repository.TagRepository, _ repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	tag := newTag(uuid.New().String(), "backend")
+	createTags(ctx, t, repo, tag)
+
+	taken := newTag(tag.UserID, tag.Name)
+	if err := repo.Create(ctx, taken); !errors.Is(err, repository.ErrDuplicateTagName) {
+		t.Errorf("Create() error = %v, want %v", err, repository.ErrDuplicateTagName)
+	}
+	assertTagNotFound(ctx, t, repo, taken.ID)
+
+	// names are unique per user only
+	other := newTag(uuid.New().String(), tag.Name)
+	createTags(ctx, t, repo, other)
+	assertTag(ctx, t, repo, other)
+}
+
+func testTagList(t *testing.T, repo repository.TagRepository, _ repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	urgent := newTag(userID, "urgent")
+	backend := newTag(userID, "backend")
+	frontend := newTag(userID, "frontend")
+	createTags(ctx, t, repo, urgent, backend, frontend, newTag(uuid.New().String(), "other"))
+
+	assertTags(ctx, t, repo, userID, []entity.Tag{backend, frontend, urgent})
+	assertTags(ctx, t, repo, uuid.New().String(), nil)
+}
+
+func testTagUpdate(t *testing.T, repo repository.TagRepository, _ repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	tag := newTag(userID, "backend")
+	taken := newTag(userID, "frontend")
+	createTags(ctx, t, repo, tag, taken)
+
+	// the owner and the creation time cannot be changed
+	updated := tag
+	updated.UserID = uuid.New().String()
+	updated.CreatedAt = base.Add(time.Hour)
+	updated.Name = "server"
+	if err := repo.Update(ctx, updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := tag
+	want.Name = updated.Name
+	assertTag(ctx, t, repo, want)
+
+	renamed := want
+	renamed.Name = taken.Name
+	if err := repo.Update(ctx, renamed); !errors.Is(err, repository.ErrDuplicateTagName) {
+		t.Errorf("Update() error = %v, want %v", err, repository.ErrDuplicateTagName)
+	}
+	assertTag(ctx, t, repo, want)
+
+	// keeping the name is not a conflict with the tag itself
+	if err := repo.Update(ctx, want); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+	assertTag(ctx, t, repo, want)
+}
+
+func testTagUpdateMissing(t *testing.T, repo repository.TagRepository, _ repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	tag := newTag(uuid.New().String(), "backend")
+
+	if err := repo.Update(ctx, tag); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertTagNotFound(ctx, t, repo, tag.ID)
+	assertTags(ctx, t, repo, tag.UserID, nil)
+}
+
+func testTagDelete(t *testing.T, repo repository.TagRepository, taskRepo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	deleted := newTag(userID, "deleted")
+	kept := newTag(userID, "kept")
+	createTags(ctx, t, repo, deleted, kept)
+
+	task := newTask(userID, "Tagged", base.Add(24*time.Hour), entity.Medium, base)
+	task.SetTags([]entity.Tag{deleted, kept})
+	task.Tags = nil
+	createTasks(ctx, t, taskRepo, task)
+
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	assertTagNotFound(ctx, t, repo, deleted.ID)
+	assertTags(ctx, t, repo, userID, []entity.Tag{kept})
+
+	// the tag is detached from its tasks
+	want := task
+	want.TagIDs = []string{kept.ID}
+	assertTask(ctx, t, taskRepo, want)
+	assertIDs(t, nil, listIDs(ctx, t, taskRepo, repository.TaskQuery{UserID: userID, TagIDs: []string{deleted.ID}}))
+
+	// deleting a missing tag is not an error
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
+
+// createTagIDs creates n tags of the user and returns their IDs in ascending order.
+func createTagIDs(ctx context.Context, t *testing.T, repo repository.TagRepository, userID string, n int) []string {
+	t.Helper()
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		tag := newTag(userID, fmt.Sprintf("tag%d", i))
+		createTags(ctx, t, repo, tag)
+		ids = append(ids, tag.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func testTagTaskTags(t *testing.T, tagRepo repository.TagRepository, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	ids := createTagIDs(ctx, t, tagRepo, userID, 3)
+	task := newTask(userID, "Tagged", base.Add(24*time.Hour), entity.Medium, base)
+	task.TagIDs = []string{ids[2], ids[0]}
+	// the names are resolved on read, so they are not stored
+	task.Tags = []entity.Tag{{ID: ids[0], Name: "backend"}}
+	createTasks(ctx, t, repo, task)
+
+	want := task
+	want.TagIDs = []string{ids[0], ids[2]}
+	want.Tags = nil
+	assertTask(ctx, t, repo, want)
+
+	// updating replaces the tags
+	want.TagIDs = []string{ids[1], ids[2]}
+	if err := repo.Update(ctx, want); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertTask(ctx, t, repo, want)
+
+	want.TagIDs = nil
+	if err := repo.Update(ctx, want); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertTask(ctx, t, repo, want)
+
+	// neither updating a missing task nor deleting a task leaves tags behind for its ID
+	missing := newTask(task.UserID, "Missing", base.Add(24*time.Hour), entity.Medium, base)
+	missing.TagIDs = []string{ids[0]}
+	if err := repo.Update(ctx, missing); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	missing.TagIDs = nil
+	createTasks(ctx, t, repo, missing)
+	assertTask(ctx, t, repo, missing)
+
+	deleted := newTask(task.UserID, "Deleted", base.Add(24*time.Hour), entity.Medium, base)
+	deleted.TagIDs = []string{ids[0]}
+	createTasks(ctx, t, repo, deleted)
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	deleted.TagIDs = nil
+	createTasks(ctx, t, repo, deleted)
+	assertTask(ctx, t, repo, deleted)
+}
+
+func testTagListTasks(t *testing.T, tagRepo repository.TagRepository, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	ids := createTagIDs(ctx, t, tagRepo, userID, 3)
+	a, b, unused := ids[0], ids[1], ids[2]
+
+	onlyA := newTask(userID, "A", base.Add(24*time.Hour), entity.Medium, base)
+	onlyA.TagIDs = []string{a}
+	both := newTask(userID, "A and B", base.Add(24*time.Hour), entity.Medium, base.Add(time.Second))
+	both.TagIDs = []string{a, b}
+	onlyB := newTask(userID, "B", base.Add(24*time.Hour), entity.Medium, base.Add(2*time.Second))
+	onlyB.TagIDs = []string{b}
+	untagged := newTask(userID, "Untagged", base.Add(24*time.Hour), entity.Medium, base.Add(3*time.Second))
+	createTasks(ctx, t, repo, onlyA, both, onlyB, untagged)
+
+	patterns := []struct {
+		name  string
+		query repository.TaskQuery
+		want  []string
+	}{
+		{
+			name:  "no tags",
+			query: repository.TaskQuery{},
+			want:  []string{onlyA.ID, both.ID, onlyB.ID, untagged.ID},
+		},
+		{
+			name:  "any of the tags",
+			query: repository.TaskQuery{TagIDs: []string{b, unused}},
+			want:  []string{both.ID, onlyB.ID},
+		},
+		{
+			name:  "any of the tags, listed twice",
+			query: repository.TaskQuery{TagIDs: []string{a, a}},
+			want:  []string{onlyA.ID, both.ID},
+		},
+		{
+			name:  "all of the tags",
+			query: repository.TaskQuery{TagIDs: []string{a, b}, MatchAllTags: true},
+			want:  []string{both.ID},
+		},
+		{
+			name:  "all of the tags, listed twice",
+			query: repository.TaskQuery{TagIDs: []string{b, b}, MatchAllTags: true},
+			want:  []string{both.ID, onlyB.ID},
+		},
+		{
+			name:  "all of the tags, one unused",
+			query: repository.TaskQuery{TagIDs: []string{a, unused}, MatchAllTags: true},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt.query.UserID = userID
+		got := listIDs(ctx, t, repo, tt.query)
+		if d := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); len(d) != 0 {
+			t.Errorf("%s differs: (-want +got)\n%s", tt.name, d)
+		}
+	}
+
+	// the listed tasks carry their tags, and the filter holds across pages
+	q := repository.TaskQuery{UserID: userID, TagIDs: []string{a}, Limit: 1}
+	tasks, next, err := repo.List(ctx, q)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if d := diffTask([]entity.Task{onlyA}, tasks); len(d) != 0 {
+		t.Errorf("first page differs: (-want +got)\n%s", d)
+	}
+	q.Cursor = next
+	tasks, next, err = repo.List(ctx, q)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if d := diffTask([]entity.Task{both}, tasks); len(d) != 0 {
+		t.Errorf("second page differs: (-want +got)\n%s", d)
+	}
+	if next != "" {
+		t.Errorf("want: no cursor, got: %q", next)
+	}
+}
+
+func testTagTransactionRollback(t *testing.T, repo repository.TagRepository, _ repository.TaskRepository, txRepo repository.TransactionRepository) {
+	if txRepo == nil {
+		t.Skip("the backend has no transactions")
+	}
+	ctx := context.Background()
+	userID := uuid.New().String()
+	existing := newTag(userID, "existing")
+	createTags(ctx, t, repo, existing)
+
+	created := newTag(userID, "created")
+	err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, created); err != nil {
+			return err
+		}
+		renamed := existing
+		renamed.Name = "renamed"
+		if err := repo.Update(ctx, renamed); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+
+	assertTagNotFound(ctx, t, repo, created.ID)
+	assertTags(ctx, t, repo, userID, []entity.Tag{existing})
+}
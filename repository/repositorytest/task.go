@@ -0,0 +1,447 @@
+// Package repositorytest provides conformance tests for the implementations of the repository
+// interfaces, so that every backend keeps the same semantics.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// TaskFactory returns the TaskRepository under test and the TransactionRepository of its backend,
+// or nil when the backend has no transactions. It is called for every case. The cases use fresh IDs,
+// so the repositories may share their storage with other cases.
+type TaskFactory func(t *testing.T) (repository.TaskRepository, repository.TransactionRepository)
+
+// base is the time the cases are built around. It has no fraction of a second,
+// which some backends do not store.
+var base = time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+var errAbort = errors.New("abort")
+
+// TestTaskRepository runs the conformance cases of TaskRepository against the repositories of newRepo.
+func TestTaskRepository(t *testing.T, newRepo TaskFactory) {
+	patterns := []struct {
+		name string
+		run  func(t *testing.T, repo repository.TaskRepository, txRepo repository.TransactionRepository)
+	}{
+		{name: "Create and Get", run: testTaskCreateAndGet},
+		{name: "Get a missing task", run: testTaskGetMissing},
+		{name: "Create an existing ID", run: testTaskCreateExisting},
+		{name: "Update", run: testTaskUpdate},
+		{name: "Update a missing task", run: testTaskUpdateMissing},
+		{name: "Delete", run: testTaskDelete},
+		{name: "List order and pages", run: testTaskListOrder},
+		{name: "List filters", run: testTaskListFilters},
+		{name: "List with an invalid cursor", run: testTaskListInvalidCursor},
+		{name: "Transaction rollback", run: testTaskTransactionRollback},
+		{name: "Nested transaction rollback", run: testTaskNestedTransactionRollback},
+		{name: "Transaction commit", run: testTaskTransactionCommit},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, txRepo := newRepo(t)
+			tt.run(t, repo, txRepo)
+		})
+	}
+}
+
+func newTask(userID, title string, dueDate time.Time, priority int, createdAt time.Time) entity.Task {
+	return entity.Task{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Title:       title,
+		Description: title + " description",
+		DueDate:     dueDate,
+		Priority:    priority,
+		Status:      entity.StatusTodo,
+		CreatedAt:   createdAt,
+	}
+}
+
+func createTasks(ctx context.Context, t *testing.T, repo repository.TaskRepository, tasks ...entity.Task) {
+	t.Helper()
+	for _, task := range tasks {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+}
+
+// diffTask compares tasks by instant rather than by location, does not tell no tags from an empty list,
+// and ignores the fields derived on read.
+func diffTask(want, got interface{}) string {
+	return cmp.Diff(want, got,
+		cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) }),
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(entity.Task{}, "IsOverdue", "IsDueSoon"),
+	)
+}
+
+func assertTask(ctx context.Context, t *testing.T, repo repository.TaskRepository, want entity.Task) {
+	t.Helper()
+	got, err := repo.Get(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if d := diffTask(&want, got); len(d) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", d)
+	}
+}
+
+func assertTaskNotFound(ctx context.Context, t *testing.T, repo repository.TaskRepository, id string) {
+	t.Helper()
+	if _, err := repo.Get(ctx, id); !errors.Is(err, repository.ErrTaskNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, repository.ErrTaskNotFound)
+	}
+}
+
+func listIDs(ctx context.Context, t *testing.T, repo repository.TaskRepository, q repository.TaskQuery) []string {
+	t.Helper()
+	tasks, next, err := repo.List(ctx, q)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if next != "" {
+		t.Errorf("want: no cursor, got: %q", next)
+	}
+	return taskIDs(tasks)
+}
+
+func taskIDs(tasks []entity.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, want, got []string) {
+	t.Helper()
+	if d := cmp.Diff(want, got, cmpopts.EquateEmpty()); len(d) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", d)
+	}
+}
+
+func testTaskCreateAndGet(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	todo := newTask(userID, "Todo", base.Add(24*time.Hour), entity.Medium, base)
+	done := newTask(userID, "Done", base.Add(48*time.Hour), entity.High, base.Add(time.Second))
+	completedAt := base.Add(time.Hour)
+	done.Status = entity.StatusDone
+	done.CompletedAt = &completedAt
+	createTasks(ctx, t, repo, todo, done)
+
+	assertTask(ctx, t, repo, todo)
+	assertTask(ctx, t, repo, done)
+}
+
+func testTaskGetMissing(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	assertTaskNotFound(context.Background(), t, repo, uuid.New().String())
+}
+
+func testTaskCreateExisting(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	task := newTask(uuid.New().String(), "Original", base.Add(24*time.Hour), entity.Medium, base)
+	createTasks(ctx, t, repo, task)
+
+	duplicate := task
+	duplicate.Title = "Duplicate"
+	if err := repo.Create(ctx, duplicate); err == nil {
+		t.Errorf("Create() error = nil, want an error for an existing ID")
+	}
+	assertTask(ctx, t, repo, task)
+}
+
+func testTaskUpdate(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	task := newTask(userID, "Title", base.Add(24*time.Hour), entity.Medium, base)
+	createTasks(ctx, t, repo, task)
+
+	// the owner and the creation time cannot be changed
+	updated := task
+	updated.UserID = uuid.New().String()
+	updated.CreatedAt = base.Add(time.Hour)
+	updated.Title = "Updated Title"
+	updated.Description = "Updated Description"
+	updated.DueDate = base.Add(72 * time.Hour)
+	updated.Priority = entity.High
+	updated.Status = entity.StatusDone
+	completedAt := base.Add(2 * time.Hour)
+	updated.CompletedAt = &completedAt
+	if err := repo.Update(ctx, updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	want := updated
+	want.UserID = task.UserID
+	want.CreatedAt = task.CreatedAt
+	assertTask(ctx, t, repo, want)
+	assertIDs(t, []string{task.ID}, listIDs(ctx, t, repo, repository.TaskQuery{UserID: userID}))
+	assertIDs(t, nil, listIDs(ctx, t, repo, repository.TaskQuery{UserID: updated.UserID}))
+
+	// reopening clears the completion time
+	want.Status = entity.StatusInProgress
+	want.CompletedAt = nil
+	if err := repo.Update(ctx, want); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertTask(ctx, t, repo, want)
+}
+
+func testTaskUpdateMissing(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	task := newTask(uuid.New().String(), "Missing", base.Add(24*time.Hour), entity.Medium, base)
+
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertTaskNotFound(ctx, t, repo, task.ID)
+	assertIDs(t, nil, listIDs(ctx, t, repo, repository.TaskQuery{UserID: task.UserID}))
+}
+
+func testTaskDelete(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	deleted := newTask(userID, "Deleted", base.Add(24*time.Hour), entity.Medium, base)
+	kept := newTask(userID, "Kept", base.Add(48*time.Hour), entity.Medium, base.Add(time.Second))
+	createTasks(ctx, t, repo, deleted, kept)
+
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	assertTaskNotFound(ctx, t, repo, deleted.ID)
+	assertTask(ctx, t, repo, kept)
+	assertIDs(t, []string{kept.ID}, listIDs(ctx, t, repo, repository.TaskQuery{UserID: userID}))
+
+	// deleting a missing task is not an error
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
+
+func testTaskListOrder(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	// priorities and creation times repeat, so that ties are broken by ID
+	tasks := []entity.Task{
+		newTask(userID, "A", base.Add(5*time.Hour), entity.Low, base),
+		newTask(userID, "B", base.Add(1*time.Hour), entity.High, base.Add(time.Second)),
+		newTask(userID, "C", base.Add(3*time.Hour), entity.Medium, base.Add(time.Second)),
+		newTask(userID, "D", base.Add(2*time.Hour), entity.High, base.Add(2*time.Second)),
+		newTask(userID, "E", base.Add(4*time.Hour), entity.Medium, base),
+	}
+	createTasks(ctx, t, repo, tasks...)
+	createTasks(ctx, t, repo, newTask(uuid.New().String(), "Other", base, entity.Medium, base))
+
+	compares := map[string]func(a, b entity.Task) int{
+		"":                           func(a, b entity.Task) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		repository.TaskSortCreatedAt: func(a, b entity.Task) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		repository.TaskSortDueDate:   func(a, b entity.Task) int { return a.DueDate.Compare(b.DueDate) },
+		repository.TaskSortPriority:  func(a, b entity.Task) int { return a.Priority - b.Priority },
+	}
+	for sortBy, compare := range compares {
+		for _, desc := range []bool{false, true} {
+			want := append([]entity.Task(nil), tasks...)
+			sort.Slice(want, func(i, j int) bool {
+				c := compare(want[i], want[j])
+				if c == 0 {
+					c = strings.Compare(want[i].ID, want[j].ID)
+				}
+				if desc {
+					c = -c
+				}
+				return c < 0
+			})
+
+			q := repository.TaskQuery{UserID: userID, SortBy: sortBy, SortDesc: desc}
+			got, next, err := repo.List(ctx, q)
+			if err != nil {
+				t.Fatalf("List(%q, desc=%v) error = %v", sortBy, desc, err)
+			}
+			if d := diffTask(want, got); len(d) != 0 || next != "" {
+				t.Errorf("List(%q, desc=%v) differs: (-want +got)\n%s cursor %q", sortBy, desc, d, next)
+			}
+
+			// the pages together are the whole list
+			q.Limit = 2
+			var paged []string
+			for page := 0; page == 0 || q.Cursor != ""; page++ {
+				if page > len(tasks) {
+					t.Fatalf("List(%q, desc=%v) does not end", sortBy, desc)
+				}
+				got, q.Cursor, err = repo.List(ctx, q)
+				if err != nil {
+					t.Fatalf("List(%q, desc=%v) error = %v", sortBy, desc, err)
+				}
+				if len(got) > q.Limit || (q.Cursor != "" && len(got) != q.Limit) {
+					t.Errorf("List(%q, desc=%v) returned %v tasks with cursor %q", sortBy, desc, len(got), q.Cursor)
+				}
+				paged = append(paged, taskIDs(got)...)
+			}
+			assertIDs(t, taskIDs(want), paged)
+		}
+	}
+}
+
+func testTaskListFilters(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	overdue := newTask(userID, "50% done", base.Add(-time.Hour), entity.Low, base)
+	done := newTask(userID, "50 percent", base.Add(-time.Hour), entity.Medium, base.Add(time.Second))
+	completedAt := base.Add(-2 * time.Hour)
+	done.Status = entity.StatusDone
+	done.CompletedAt = &completedAt
+	upcoming := newTask(userID, "Shopping", base.Add(time.Hour), entity.High, base.Add(2*time.Second))
+	createTasks(ctx, t, repo, overdue, done, upcoming)
+
+	yes, no := true, false
+	patterns := []struct {
+		name  string
+		query repository.TaskQuery
+		want  []string
+	}{
+		{
+			name:  "priority range",
+			query: repository.TaskQuery{MinPriority: entity.Medium, MaxPriority: entity.MediumHigh},
+			want:  []string{done.ID},
+		},
+		{
+			name:  "due after is inclusive",
+			query: repository.TaskQuery{DueAfter: base.Add(time.Hour)},
+			want:  []string{upcoming.ID},
+		},
+		{
+			name:  "due before is exclusive",
+			query: repository.TaskQuery{DueBefore: base.Add(time.Hour)},
+			want:  []string{overdue.ID, done.ID},
+		},
+		{
+			name:  "overdue",
+			query: repository.TaskQuery{Overdue: &yes, Now: base},
+			want:  []string{overdue.ID},
+		},
+		{
+			name:  "not overdue",
+			query: repository.TaskQuery{Overdue: &no, Now: base},
+			want:  []string{done.ID, upcoming.ID},
+		},
+		{
+			name:  "title prefix matches wildcards literally",
+			query: repository.TaskQuery{TitlePrefix: "50%"},
+			want:  []string{overdue.ID},
+		},
+		{
+			name:  "title prefix",
+			query: repository.TaskQuery{TitlePrefix: "50"},
+			want:  []string{overdue.ID, done.ID},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt.query.UserID = userID
+		got := listIDs(ctx, t, repo, tt.query)
+		if d := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); len(d) != 0 {
+			t.Errorf("%s differs: (-want +got)\n%s", tt.name, d)
+		}
+	}
+}
+
+func testTaskListInvalidCursor(t *testing.T, repo repository.TaskRepository, _ repository.TransactionRepository) {
+	_, _, err := repo.List(context.Background(), repository.TaskQuery{UserID: uuid.New().String(), Cursor: "invalid"})
+	if !errors.Is(err, repository.ErrInvalidCursor) {
+		t.Errorf("List() error = %v, want %v", err, repository.ErrInvalidCursor)
+	}
+}
+
+func testTaskTransactionRollback(t *testing.T, repo repository.TaskRepository, txRepo repository.TransactionRepository) {
+	if txRepo == nil {
+		t.Skip("the backend has no transactions")
+	}
+	ctx := context.Background()
+	userID := uuid.New().String()
+	existing := newTask(userID, "Existing", base.Add(24*time.Hour), entity.Medium, base)
+	createTasks(ctx, t, repo, existing)
+
+	created := newTask(userID, "Created", base.Add(48*time.Hour), entity.Medium, base)
+	err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, created); err != nil {
+			return err
+		}
+		updated := existing
+		updated.Title = "Updated"
+		if err := repo.Update(ctx, updated); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+
+	assertTaskNotFound(ctx, t, repo, created.ID)
+	assertTask(ctx, t, repo, existing)
+	assertIDs(t, []string{existing.ID}, listIDs(ctx, t, repo, repository.TaskQuery{UserID: userID}))
+}
+
+// testTaskNestedTransactionRollback checks that a nested transaction joins the outer one,
+// so its writes are rolled back with it.
+func testTaskNestedTransactionRollback(t *testing.T, repo repository.TaskRepository, txRepo repository.TransactionRepository) {
+	if txRepo == nil {
+		t.Skip("the backend has no transactions")
+	}
+	ctx := context.Background()
+	task := newTask(uuid.New().String(), "Created", base.Add(24*time.Hour), entity.Medium, base)
+
+	err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+			return repo.Create(ctx, task)
+		}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+
+	assertTaskNotFound(ctx, t, repo, task.ID)
+}
+
+func testTaskTransactionCommit(t *testing.T, repo repository.TaskRepository, txRepo repository.TransactionRepository) {
+	if txRepo == nil {
+		t.Skip("the backend has no transactions")
+	}
+	ctx := context.Background()
+	task := newTask(uuid.New().String(), "Created", base.Add(24*time.Hour), entity.Medium, base)
+
+	err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, task); err != nil {
+			return err
+		}
+		// the transaction reads its own writes
+		assertTask(ctx, t, repo, task)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	assertTask(ctx, t, repo, task)
+}
@@ -0,0 +1,233 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+// UserFactory returns the UserRepository under test and the TransactionRepository of its backend,
+// or nil when the backend has no transactions. It is called like TaskFactory.
+type UserFactory func(t *testing.T) (repository.UserRepository, repository.TransactionRepository)
+
+// TestUserRepository runs the conformance cases of UserRepository against the repositories of newRepo.
+func TestUserRepository(t *testing.T, newRepo UserFactory) {
+	patterns := []struct {
+		name string
+		run  func(t *testing.T, repo repository.UserRepository, txRepo repository.TransactionRepository)
+	}{
+		{name: "Create and Get", run: testUserCreateAndGet},
+		{name: "Get a missing user", run: testUserGetMissing},
+		{name: "Create a taken email", run: testUserCreateTakenEmail},
+		{name: "Update", run: testUserUpdate},
+		{name: "Update to a taken email", run: testUserUpdateTakenEmail},
+		{name: "Update a missing user", run: testUserUpdateMissing},
+		{name: "Delete", run: testUserDelete},
+		{name: "LockUserByEmail", run: testUserLockByEmail},
+		{name: "Transaction rollback", run: testUserTransactionRollback},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo, txRepo := newRepo(t)
+			tt.run(t, repo, txRepo)
+		})
+	}
+}
+
+func newUser() entity.User {
+	id := uuid.New().String()
+	return entity.User{
+		ID:           id,
+		Name:         "user",
+		Email:        id + "@example.com",
+		Password:     "hashed password",
+		DueSoonHours: 24,
+	}
+}
+
+func createUsers(ctx context.Context, t *testing.T, repo repository.UserRepository, users ...entity.User) {
+	t.Helper()
+	for _, user := range users {
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+}
+
+// assertUser checks that want is found both by its ID and by its email.
+func assertUser(ctx context.Context, t *testing.T, repo repository.UserRepository, want entity.User) {
+	t.Helper()
+	got, err := repo.Get(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if d := cmp.Diff(&want, got); len(d) != 0 {
+		t.Errorf("Get() differs: (-want +got)\n%s", d)
+	}
+	got, err = repo.GetByEmail(ctx, want.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+	if d := cmp.Diff(&want, got); len(d) != 0 {
+		t.Errorf("GetByEmail() differs: (-want +got)\n%s", d)
+	}
+}
+
+func assertUserNotFound(ctx context.Context, t *testing.T, repo repository.UserRepository, id, email string) {
+	t.Helper()
+	if _, err := repo.Get(ctx, id); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, repository.ErrUserNotFound)
+	}
+	if _, err := repo.GetByEmail(ctx, email); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("GetByEmail() error = %v, want %v", err, repository.ErrUserNotFound)
+	}
+}
+
+func testUserCreateAndGet(t *testing.T, repo repository.UserRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	user := newUser()
+	createUsers(ctx, t, repo, user)
+	assertUser(ctx, t, repo, user)
+}
+
+func testUserGetMissing(t *testing.T, repo repository.UserRepository, _ repository.TransactionRepository) {
+	user := newUser()
+	assertUserNotFound(context.Background(), t, repo, user.ID, user.Email)
+}
+
+func testUserCreateTakenEmail(t *testing.T, repo repository.UserRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	user := newUser()
+	createUsers(ctx, t, repo, user)
+
+	other := newUser()
+	other.Email = user.Email
+	if err := repo.Create(ctx, other); !errors.Is(err, repository.ErrDuplicateEmail) {
+		t.Errorf("Create() error = %v, want %v", err, repository.ErrDuplicateEmail)
+	}
+	assertUser(ctx, t, repo, user)
+	if _, err := repo.Get(ctx, other.ID); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, repository.ErrUserNotFound)
+	}
+}
+
+func testUserUpdate(t *testing.T, repo repository.UserRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	user := newUser()
+	createUsers(ctx, t, repo, user)
+
+	// zero values are stored too
+	updated := user
+	updated.Name = "updated"
+	updated.Email = "updated-" + user.Email
+	updated.Password = "updated password"
+	updated.DueSoonHours = 0
+	if err := repo.Update(ctx, updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertUser(ctx, t, repo, updated)
+	if _, err := repo.GetByEmail(ctx, user.Email); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("GetByEmail() error = %v, want %v", err, repository.ErrUserNotFound)
+	}
+}
+
+func testUserUpdateTakenEmail(t *testing.T, repo repository.UserRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	user, other := newUser(), newUser()
+	createUsers(ctx, t, repo, user, other)
+
+	updated := user
+	updated.Email = other.Email
+	if err := repo.Update(ctx, updated); !errors.Is(err, repository.ErrDuplicateEmail) {
+		t.Errorf("Update() error = %v, want %v", err, repository.ErrDuplicateEmail)
+	}
+	assertUser(ctx, t, repo, user)
+	assertUser(ctx, t, repo, other)
+}
+
+func testUserUpdateMissing(t *testing.T, repo repository.UserRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	user := newUser()
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertUserNotFound(ctx, t, repo, user.ID, user.Email)
+}
+
+func testUserDelete(t *testing.T, repo repository.UserRepository, _ repository.TransactionRepository) {
+	ctx := context.Background()
+	user := newUser()
+	createUsers(ctx, t, repo, user)
+
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	assertUserNotFound(ctx, t, repo, user.ID, user.Email)
+
+	// deleting a missing user is not an error
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+
+	// the email can be taken again
+	other := newUser()
+	other.Email = user.Email
+	createUsers(ctx, t, repo, other)
+	assertUser(ctx, t, repo, other)
+}
+
+func testUserLockByEmail(t *testing.T, repo repository.UserRepository, txRepo repository.TransactionRepository) {
+	ctx := context.Background()
+	user := newUser()
+	createUsers(ctx, t, repo, user)
+
+	lock := func(ctx context.Context) error {
+		if exists, err := repo.LockUserByEmail(ctx, user.Email); err != nil || !exists {
+			t.Errorf("LockUserByEmail(taken) = %v, %v, want true", exists, err)
+		}
+		if exists, err := repo.LockUserByEmail(ctx, "free-"+user.Email); err != nil || exists {
+			t.Errorf("LockUserByEmail(free) = %v, %v, want false", exists, err)
+		}
+		return nil
+	}
+	if txRepo == nil {
+		_ = lock(ctx)
+		return
+	}
+	if err := txRepo.Transaction(ctx, lock); err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+}
+
+func testUserTransactionRollback(t *testing.T, repo repository.UserRepository, txRepo repository.TransactionRepository) {
+	if txRepo == nil {
+		t.Skip("the backend has no transactions")
+	}
+	ctx := context.Background()
+	existing, created := newUser(), newUser()
+	createUsers(ctx, t, repo, existing)
+
+	err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, created); err != nil {
+			return err
+		}
+		if err := repo.Delete(ctx, existing.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+
+	assertUserNotFound(ctx, t, repo, created.ID, created.Email)
+	assertUser(ctx, t, repo, existing)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type RevocationRepository interface {
+	// RevokeToken revokes the access token with the jti. The entry is kept until the token expires on its own.
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeUserTokens revokes every access token of the user issued before the given time.
+	RevokeUserTokens(ctx context.Context, userID string, before time.Time) error
+	// IsRevoked reports whether the token was revoked by its jti or by a cutoff of its user.
+	IsRevoked(ctx context.Context, claims TokenClaims) (bool, error)
+}
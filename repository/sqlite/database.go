@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/repository"
+
+	_ "modernc.org/sqlite" // This blank import is used for its init function
+)
+
+type SQLExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type transactionRepository struct {
+	db *sql.DB
+}
+
+func NewTransactionRepository(db *sql.DB) repository.TransactionRepository {
+	return &transactionRepository{
+		db: db,
+	}
+}
+
+// Transaction takes the write lock of the database when it begins (see Open),
+// so transactions are serializable and LockUserByEmail needs no row lock.
+func (tr *transactionRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if TxFromCtx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := tr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx = context.WithValue(ctx, CtxTxKey(), tx)
+
+	defer func() {
+		if p := recover(); p != nil || err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Error("Failed to rollback transaction: %v", rollbackErr)
+			}
+			if p != nil {
+				panic(p)
+			}
+		}
+	}()
+
+	if err = fn(ctx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type TxKey string
+
+func CtxTxKey() TxKey {
+	return "tx"
+}
+
+func TxFromCtx(ctx context.Context) *sql.Tx {
+	tx, ok := ctx.Value(CtxTxKey()).(*sql.Tx)
+	if !ok {
+		return nil
+	}
+	return tx
+}
+
+const (
+	dbPrefix = "SQLITE_"
+)
+
+// NewSQLiteDB opens the database file and applies the pending migrations,
+// since the file belongs to this application alone.
+func NewSQLiteDB(ctx context.Context) (*sql.DB, error) {
+	conf, err := config.NewSQLiteConfig(ctx, dbPrefix)
+	if err != nil {
+		log.Error("Failed to load database config", log.Ferror(err))
+		return nil, err
+	}
+
+	db, err := Open(conf.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = migrateUp(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Connect opens the database file configured like for NewSQLiteDB, without touching its schema.
+func Connect(ctx context.Context) (*sql.DB, error) {
+	conf, err := config.NewSQLiteConfig(ctx, dbPrefix)
+	if err != nil {
+		log.Error("Failed to load database config", log.Ferror(err))
+		return nil, err
+	}
+	return Open(conf.Path)
+}
+
+// Open opens the database file at path, which is created when it does not exist, without touching its schema.
+//
+// The journal is kept in WAL mode, so readers do not block the writer. Transactions begin
+// IMMEDIATE, taking the write lock up front instead of failing when a read turns into a write,
+// and wait up to busy_timeout for a concurrent writer. Foreign keys are enforced, which SQLite
+// leaves to every connection. Times are written in a sortable text format, which the repositories
+// rely on by always writing them in UTC.
+func Open(path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "synchronous(NORMAL)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Set("_txlock", "immediate")
+	params.Set("_time_format", "sqlite")
+
+	db, err := sql.Open("sqlite", path+"?"+params.Encode())
+	if err != nil {
+		log.Critical("Failed to open database", log.Ferror(err))
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		log.Critical("Failed to ping database", log.Ferror(err))
+		db.Close()
+		return nil, err
+	}
+
+	log.Info("Successfully opened database", log.Fstring("path", path))
+	return db, nil
+}
@@ -0,0 +1,209 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_TransactionRepository(t *testing.T) {
+	ctx := context.Background()
+	txRepo := NewTransactionRepository(db)
+	taskRepo := NewTaskRepository(db)
+	userRepo := NewUserRepository(db)
+
+	user, err := entity.NewUser("transaction@gmail.com", "password")
+	ValidateErr(t, err, nil)
+	task, err := entity.NewTask(uuid.New().String(), "title", "description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+
+	// Rollback
+	errAbort := errors.New("abort")
+	err = txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err = userRepo.Create(ctx, *user); err != nil {
+			return err
+		}
+		if err = taskRepo.Create(ctx, *task); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+
+	_, err = userRepo.Get(ctx, user.ID)
+	ValidateErr(t, err, repository.ErrUserNotFound)
+	_, err = taskRepo.Get(ctx, task.ID)
+	ValidateErr(t, err, repository.ErrTaskNotFound)
+
+	// Nested transactions join the outer one
+	err = txRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := userRepo.Create(ctx, *user); err != nil { //nolint: govet // shadowing is intended
+			return err
+		}
+		return txRepo.Transaction(ctx, func(ctx context.Context) error {
+			return taskRepo.Create(ctx, *task)
+		})
+	})
+	ValidateErr(t, err, nil)
+
+	_, err = userRepo.Get(ctx, user.ID)
+	ValidateErr(t, err, nil)
+	_, err = taskRepo.Get(ctx, task.ID)
+	ValidateErr(t, err, nil)
+}
+
+func Test_TransactionRepository_concurrentSignUp(t *testing.T) {
+	ctx := context.Background()
+	txRepo := NewTransactionRepository(db)
+	userRepo := NewUserRepository(db)
+
+	email := uuid.New().String() + "@gmail.com"
+
+	// the transactions are serialized, so exactly one of them sees the email as free
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created := 0
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := txRepo.Transaction(ctx, func(ctx context.Context) error {
+				exists, err := userRepo.LockUserByEmail(ctx, email)
+				if err != nil || exists {
+					return err
+				}
+				user, err := entity.NewUser(email, "password")
+				if err != nil {
+					return err
+				}
+				if err = userRepo.Create(ctx, *user); err != nil {
+					return err
+				}
+				mu.Lock()
+				created++
+				mu.Unlock()
+				return nil
+			})
+			ValidateErr(t, err, nil)
+		}()
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("want: 1 user created, got: %v", created)
+	}
+}
+
+func Test_TaskRepository_List_timeZones(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTaskRepository(db)
+
+	userID := uuid.New().String()
+	tokyo := time.FixedZone("JST", 9*60*60)
+	dueDate := time.Date(2030, 1, 10, 9, 0, 0, 0, tokyo) // 00:00 UTC
+
+	task, err := entity.NewTask(userID, "100% done_", "description", dueDate, 3, time.Now())
+	ValidateErr(t, err, nil)
+	err = repo.Create(ctx, *task)
+	ValidateErr(t, err, nil)
+
+	patterns := []struct {
+		name  string
+		query repository.TaskQuery
+		want  int
+	}{
+		{
+			name:  "due before an instant given in another zone",
+			query: repository.TaskQuery{UserID: userID, DueBefore: time.Date(2030, 1, 10, 0, 0, 1, 0, time.UTC)},
+			want:  1,
+		},
+		{
+			name:  "due after an instant given in another zone",
+			query: repository.TaskQuery{UserID: userID, DueAfter: time.Date(2030, 1, 9, 23, 0, 1, 0, time.FixedZone("", -60*60))},
+			want:  0,
+		},
+		{
+			name:  "title prefix with wildcard characters",
+			query: repository.TaskQuery{UserID: userID, TitlePrefix: "100% done_"},
+			want:  1,
+		},
+		{
+			name:  "wildcard characters match literally",
+			query: repository.TaskQuery{UserID: userID, TitlePrefix: "1_0"},
+			want:  0,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := repo.List(ctx, tt.query)
+			ValidateErr(t, err, nil)
+			if len(got) != tt.want {
+				t.Errorf("want: %v tasks, got: %v", tt.want, got)
+			}
+			for _, task := range got {
+				if !task.DueDate.Equal(dueDate) {
+					t.Errorf("want: due date %v, got: %v", dueDate, task.DueDate)
+				}
+			}
+		})
+	}
+}
+
+func Test_TaskTags_foreignKeys(t *testing.T) {
+	ctx := context.Background()
+	taskRepo := NewTaskRepository(db)
+	tagRepo := NewTagRepository(db)
+
+	userID := uuid.New().String()
+	tag, err := entity.NewTag(userID, "foreign", time.Now())
+	ValidateErr(t, err, nil)
+	err = tagRepo.Create(ctx, *tag)
+	ValidateErr(t, err, nil)
+	task, err := entity.NewTask(userID, "title", "description", time.Now().Add(24*time.Hour), 3, time.Now())
+	ValidateErr(t, err, nil)
+	task.TagIDs = []string{tag.ID}
+	err = taskRepo.Create(ctx, *task)
+	ValidateErr(t, err, nil)
+
+	countTaskTags := func() int {
+		t.Helper()
+		var n int
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM TaskTags WHERE task_id = ?`, task.ID).Scan(&n); err != nil {
+			t.Fatalf("Failed to count task tags: %v", err)
+		}
+		return n
+	}
+
+	// a task cannot refer to a missing tag
+	_, err = db.ExecContext(ctx, `INSERT INTO TaskTags (task_id, tag_id) VALUES (?, ?)`, task.ID, uuid.New().String())
+	if err == nil {
+		t.Errorf("want: an error attaching a missing tag, got: nil")
+	}
+
+	// deleting the tag or the task detaches them
+	_, err = db.ExecContext(ctx, `DELETE FROM Tags WHERE id = ?`, tag.ID)
+	ValidateErr(t, err, nil)
+	if n := countTaskTags(); n != 0 {
+		t.Errorf("want: no tags after deleting the tag, got: %v", n)
+	}
+	err = tagRepo.Create(ctx, *tag)
+	ValidateErr(t, err, nil)
+	err = taskRepo.Update(ctx, *task)
+	ValidateErr(t, err, nil)
+	_, err = db.ExecContext(ctx, `DELETE FROM Tasks WHERE id = ?`, task.ID)
+	ValidateErr(t, err, nil)
+	if n := countTaskTags(); n != 0 {
+		t.Errorf("want: no tags after deleting the task, got: %v", n)
+	}
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+)
+
+var db *sql.DB
+
+// TestMain runs the suite against a database file in a temporary directory,
+// so that it needs neither Docker nor a database server.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "sqlite")
+	if err != nil {
+		log.Error("Failed to create temporary directory", log.Ferror(err))
+		os.Exit(1)
+	}
+
+	db, err = Open(filepath.Join(dir, "goCleanArcTest.db"))
+	if err == nil {
+		err = migrateUp(context.Background(), db)
+	}
+	if err != nil {
+		log.Error("Failed to open SQLite", log.Ferror(err))
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	if err = db.Close(); err != nil {
+		log.Error("Failed to close SQLite connection", log.Ferror(err))
+	}
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func ValidateErr(t *testing.T, err error, wantErr error) {
+	if (err != nil) != (wantErr != nil) {
+		t.Errorf("error = %v, wantErr %v", err, wantErr)
+	} else if err != nil && wantErr != nil && err.Error() != wantErr.Error() {
+		t.Errorf("error = %v, wantErr %v", err, wantErr)
+	}
+}
@@ -0,0 +1,10 @@
+CREATE TABLE Tasks (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    duedate TIMESTAMP,
+    priority INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_tasks_user_id ON Tasks (user_id);
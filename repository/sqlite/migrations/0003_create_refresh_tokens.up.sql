@@ -0,0 +1,11 @@
+CREATE TABLE RefreshTokens (
+    token_hash TEXT PRIMARY KEY,
+    family_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    used BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX idx_refresh_tokens_family_id ON RefreshTokens (family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON RefreshTokens (user_id);
@@ -0,0 +1,6 @@
+CREATE TABLE TaskTags (
+    task_id TEXT NOT NULL REFERENCES Tasks (id) ON DELETE CASCADE,
+    tag_id TEXT NOT NULL REFERENCES Tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+CREATE INDEX idx_task_tags_tag_id ON TaskTags (tag_id);
@@ -0,0 +1,2 @@
+ALTER TABLE Tasks DROP COLUMN completed_at;
+ALTER TABLE Tasks DROP COLUMN status;
@@ -0,0 +1,2 @@
+ALTER TABLE Tasks ADD COLUMN status TEXT NOT NULL DEFAULT 'todo';
+ALTER TABLE Tasks ADD COLUMN completed_at TIMESTAMP NULL;
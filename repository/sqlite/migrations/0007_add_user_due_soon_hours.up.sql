@@ -0,0 +1 @@
+ALTER TABLE Users ADD COLUMN due_soon_hours INTEGER NOT NULL DEFAULT 24;
@@ -0,0 +1,439 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type taskModel struct {
+	ID          string       `db:"id"`
+	UserID      string       `db:"user_id"`
+	Title       string       `db:"title"`
+	Description string       `db:"description"`
+	DueDate     time.Time    `db:"duedate"`
+	Priority    int          `db:"priority"`
+	CreatedAt   time.Time    `db:"created_at"`
+	Status      string       `db:"status"`
+	CompletedAt sql.NullTime `db:"completed_at"`
+}
+
+type taskRepository struct {
+	db SQLExecutor
+	// txRepo makes the writes that span Tasks and TaskTags atomic outside a transaction too.
+	txRepo repository.TransactionRepository
+}
+
+func NewTaskRepository(db *sql.DB) repository.TaskRepository {
+	return &taskRepository{
+		db:     db,
+		txRepo: NewTransactionRepository(db),
+	}
+}
+
+func (ur *taskRepository) Get(ctx context.Context, id string) (*entity.Task, error) {
+	executor := ur.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query := `SELECT *
+	FROM Tasks
+	WHERE id = ?
+	LIMIT 1
+	`
+
+	row := executor.QueryRowContext(ctx, query, id)
+
+	var tm taskModel
+	if err := row.Scan(
+		&tm.ID,
+		&tm.UserID,
+		&tm.Title,
+		&tm.Description,
+		&tm.DueDate,
+		&tm.Priority,
+		&tm.CreatedAt,
+		&tm.Status,
+		&tm.CompletedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	tagIDs, err := listTaskTagIDs(ctx, executor, []string{tm.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Task{
+		ID:          tm.ID,
+		UserID:      tm.UserID,
+		Title:       tm.Title,
+		Description: tm.Description,
+		DueDate:     tm.DueDate,
+		Priority:    tm.Priority,
+		Status:      tm.Status,
+		CompletedAt: nullTimeToPtr(tm.CompletedAt),
+		CreatedAt:   tm.CreatedAt,
+		TagIDs:      tagIDs[tm.ID],
+	}, nil
+}
+
+func (ur *taskRepository) List(ctx context.Context, q repository.TaskQuery) ([]entity.Task, string, error) {
+	executor := ur.db
+	if tx := TxFromCtx(ctx); tx != nil {
+		executor = tx
+	}
+
+	query, args, err := buildListTasksQuery(q)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var tms []taskModel
+	for rows.Next() {
+		var tm taskModel
+		if err = rows.Scan(
+			&tm.ID,
+			&tm.UserID,
+			&tm.Title,
+			&tm.Description,
+			&tm.DueDate,
+			&tm.Priority,
+			&tm.CreatedAt,
+			&tm.Status,
+			&tm.CompletedAt,
+		); err != nil {
+			return nil, "", err
+		}
+		tms = append(tms, tm)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	tasks := make([]entity.Task, len(tms))
+	for i, tm := range tms {
+		tasks[i] = entity.Task{
+			ID:          tm.ID,
+			UserID:      tm.UserID,
+			Title:       tm.Title,
+			Description: tm.Description,
+			DueDate:     tm.DueDate,
+			Priority:    tm.Priority,
+			Status:      tm.Status,
+			CompletedAt: nullTimeToPtr(tm.CompletedAt),
+			CreatedAt:   tm.CreatedAt,
+		}
+	}
+
+	page, next := q.Page(tasks)
+	tagIDs, err := listTaskTagIDs(ctx, executor, taskIDs(page))
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range page {
+		page[i].TagIDs = tagIDs[page[i].ID]
+	}
+	return page, next, nil
+}
+
+func (ur *taskRepository) Create(ctx context.Context, task entity.Task) error {
+	return ur.txRepo.Transaction(ctx, func(ctx context.Context) error {
+		executor := TxFromCtx(ctx)
+		if err := ur.create(ctx, executor, task); err != nil {
+			return err
+		}
+		return insertTaskTags(ctx, executor, task.ID, task.TagIDs)
+	})
+}
+
+func (ur *taskRepository) create(ctx context.Context, executor SQLExecutor, task entity.Task) error {
+	query := `INSERT INTO Tasks (
+	id, user_id, title, description, duedate, priority, created_at, status, completed_at
+	)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	tm := taskModel{
+		ID:          task.ID,
+		UserID:      task.UserID,
+		Title:       task.Title,
+		Description: task.Description,
+		DueDate:     task.DueDate.UTC(),
+		Priority:    task.Priority,
+		CreatedAt:   task.CreatedAt.UTC(),
+		Status:      task.Status,
+		CompletedAt: ptrToNullTime(task.CompletedAt),
+	}
+
+	if _, err := executor.ExecContext(
+		ctx,
+		query,
+		tm.ID,
+		tm.UserID,
+		tm.Title,
+		tm.Description,
+		tm.DueDate,
+		tm.Priority,
+		tm.CreatedAt,
+		tm.Status,
+		tm.CompletedAt,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update replaces the tags of the task. It adds none when no task has the ID.
+func (ur *taskRepository) Update(ctx context.Context, task entity.Task) error {
+	return ur.txRepo.Transaction(ctx, func(ctx context.Context) error {
+		executor := TxFromCtx(ctx)
+		if err := ur.update(ctx, executor, task); err != nil {
+			return err
+		}
+		if err := deleteTaskTags(ctx, executor, task.ID); err != nil {
+			return err
+		}
+		return insertTaskTags(ctx, executor, task.ID, task.TagIDs)
+	})
+}
+
+func (ur *taskRepository) update(ctx context.Context, executor SQLExecutor, task entity.Task) error {
+	query := `UPDATE Tasks
+	SET title = ?, description = ?, duedate = ?, priority = ?, status = ?, completed_at = ?
+	WHERE id = ?
+	`
+
+	tm := taskModel{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		DueDate:     task.DueDate.UTC(),
+		Priority:    task.Priority,
+		Status:      task.Status,
+		CompletedAt: ptrToNullTime(task.CompletedAt),
+	}
+
+	if _, err := executor.ExecContext(
+		ctx,
+		query,
+		tm.Title,
+		tm.Description,
+		tm.DueDate,
+		tm.Priority,
+		tm.Status,
+		tm.CompletedAt,
+		tm.ID,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *taskRepository) Delete(ctx context.Context, id string) error {
+	return ur.txRepo.Transaction(ctx, func(ctx context.Context) error {
+		executor := TxFromCtx(ctx)
+
+		query := `DELETE FROM Tasks
+		WHERE id = ?
+		`
+
+		if _, err := executor.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+		return deleteTaskTags(ctx, executor, id)
+	})
+}
+
+// listTaskTagIDs returns the tag IDs of the tasks in ascending order, by task ID.
+func listTaskTagIDs(ctx context.Context, executor SQLExecutor, ids []string) (map[string][]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := fmt.Sprintf(`SELECT task_id, tag_id
+	FROM TaskTags
+	WHERE task_id IN (%s)
+	ORDER BY task_id, tag_id
+	`, strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", "))
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tagIDs := make(map[string][]string, len(ids))
+	for rows.Next() {
+		var taskID, tagID string
+		if err = rows.Scan(&taskID, &tagID); err != nil {
+			return nil, err
+		}
+		tagIDs[taskID] = append(tagIDs[taskID], tagID)
+	}
+	return tagIDs, rows.Err()
+}
+
+// insertTaskTags attaches the tags to the task, if the task exists.
+func insertTaskTags(ctx context.Context, executor SQLExecutor, taskID string, tagIDs []string) error {
+	query := `INSERT INTO TaskTags (task_id, tag_id)
+	SELECT id, ? FROM Tasks WHERE id = ?
+	`
+
+	for _, tagID := range repository.DistinctIDs(tagIDs) {
+		if _, err := executor.ExecContext(ctx, query, tagID, taskID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteTaskTags(ctx context.Context, executor SQLExecutor, taskID string) error {
+	query := `DELETE FROM TaskTags
+	WHERE task_id = ?
+	`
+
+	if _, err := executor.ExecContext(ctx, query, taskID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func taskIDs(tasks []entity.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+var taskSortColumns = map[string]string{
+	repository.TaskSortDueDate:   "duedate",
+	repository.TaskSortPriority:  "priority",
+	repository.TaskSortCreatedAt: "created_at",
+}
+
+// buildListTasksQuery translates the query into SQL using keyset pagination.
+// One extra row is fetched so that the caller can tell whether a next page exists.
+func buildListTasksQuery(q repository.TaskQuery) (string, []interface{}, error) {
+	cursor, err := q.DecodeCursor()
+	if err != nil {
+		return "", nil, err
+	}
+	column, ok := taskSortColumns[q.SortKey()]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid sort key: %s", q.SortKey())
+	}
+
+	var args []interface{}
+	arg := func(v interface{}) string {
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC()
+		}
+		args = append(args, v)
+		return "?"
+	}
+
+	conditions := []string{"user_id = " + arg(q.UserID)}
+	if q.MinPriority != 0 {
+		conditions = append(conditions, "priority >= "+arg(q.MinPriority))
+	}
+	if q.MaxPriority != 0 {
+		conditions = append(conditions, "priority <= "+arg(q.MaxPriority))
+	}
+	if !q.DueAfter.IsZero() {
+		conditions = append(conditions, "duedate >= "+arg(q.DueAfter))
+	}
+	if !q.DueBefore.IsZero() {
+		conditions = append(conditions, "duedate < "+arg(q.DueBefore))
+	}
+	if q.Overdue != nil {
+		if *q.Overdue {
+			conditions = append(conditions, fmt.Sprintf(
+				"duedate < %s AND status NOT IN (%s, %s)",
+				arg(q.Now), arg(entity.StatusDone), arg(entity.StatusArchived),
+			))
+		} else {
+			conditions = append(conditions, fmt.Sprintf(
+				"(duedate >= %s OR status IN (%s, %s))",
+				arg(q.Now), arg(entity.StatusDone), arg(entity.StatusArchived),
+			))
+		}
+	}
+	if q.TitlePrefix != "" {
+		conditions = append(conditions, "title LIKE "+arg(escapeLike(q.TitlePrefix)+"%")+` ESCAPE '\'`)
+	}
+
+	if ids := q.DistinctTagIDs(); len(ids) != 0 {
+		placeholders := make([]string, len(ids))
+		for i, id := range ids {
+			placeholders[i] = arg(id)
+		}
+		tagged := fmt.Sprintf("SELECT task_id FROM TaskTags WHERE tag_id IN (%s)", strings.Join(placeholders, ", "))
+		if q.MatchAllTags {
+			tagged += " GROUP BY task_id HAVING COUNT(*) = " + arg(len(ids))
+		}
+		conditions = append(conditions, "id IN ("+tagged+")")
+	}
+
+	op, order := ">", "ASC"
+	if q.SortDesc {
+		op, order = "<", "DESC"
+	}
+	if cursor != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"(%[1]s %[2]s %[3]s OR (%[1]s = %[4]s AND id %[2]s %[5]s))",
+			column, op, arg(cursor.Value()), arg(cursor.Value()), arg(cursor.ID),
+		))
+	}
+
+	query := fmt.Sprintf(`SELECT *
+	FROM Tasks
+	WHERE %s
+	ORDER BY %s %s, id %s
+	`, strings.Join(conditions, " AND "), column, order, order)
+	if q.Limit > 0 {
+		query += "LIMIT " + arg(q.Limit+1)
+	}
+	return query, args, nil
+}
+
+// escapeLike escapes the wildcard characters of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func ptrToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func nullTimeToPtr(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	t := nt.Time
+	return &t
+}
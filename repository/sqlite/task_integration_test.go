@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+func Test_TaskRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTaskRepository(db)
+
+	userID := uuid.New().String()
+
+	task1, err := entity.NewTask(
+		userID,
+		"First Task",
+		"First Description",
+		time.Now().Add(24*time.Hour),
+		3,
+		time.Now(),
+	)
+	ValidateErr(t, err, nil)
+	task2, err := entity.NewTask(
+		userID,
+		"Second Task",
+		"Second Description",
+		time.Now().Add(48*time.Hour),
+		4,
+		time.Now(),
+	)
+	ValidateErr(t, err, nil)
+
+	// Create
+	err = repo.Create(ctx, *task1)
+	ValidateErr(t, err, nil)
+	err = repo.Create(ctx, *task2)
+	ValidateErr(t, err, nil)
+
+	// Get
+	gottask, err := repo.Get(ctx, task1.ID)
+	ValidateErr(t, err, nil)
+	if d := cmp.Diff(task1, gottask, cmpopts.IgnoreFields(entity.Task{}, "DueDate", "CreatedAt")); len(d) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", d)
+	}
+
+	// List
+	gottasks, next, err := repo.List(ctx, repository.TaskQuery{UserID: userID})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 2 || next != "" {
+		t.Errorf("want: %v tasks and no cursor, got: %v tasks and cursor %q", 2, len(gottasks), next)
+	}
+
+	// List sorted by due date in descending order, one task per page
+	query := repository.TaskQuery{
+		UserID:      userID,
+		MinPriority: entity.Medium,
+		SortBy:      repository.TaskSortDueDate,
+		SortDesc:    true,
+		Limit:       1,
+	}
+	gottasks, next, err = repo.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task2.ID || next == "" {
+		t.Fatalf("want: first page with %v and a cursor, got: %v and cursor %q", task2.ID, gottasks, next)
+	}
+	query.Cursor = next
+	gottasks, next, err = repo.List(ctx, query)
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task1.ID || next != "" {
+		t.Errorf("want: last page with %v, got: %v and cursor %q", task1.ID, gottasks, next)
+	}
+
+	// List by title prefix
+	gottasks, _, err = repo.List(ctx, repository.TaskQuery{UserID: userID, TitlePrefix: "Second"})
+	ValidateErr(t, err, nil)
+	if len(gottasks) != 1 || gottasks[0].ID != task2.ID {
+		t.Errorf("want: %v, got: %v", task2.ID, gottasks)
+	}
+
+	// Update
+	gottask.Title = "Updated First Task"
+	err = gottask.SetStatus(entity.StatusDone, time.Now())
+	ValidateErr(t, err, nil)
+	err = repo.Update(ctx, *gottask)
+	ValidateErr(t, err, nil)
+
+	updatedtask, err := repo.Get(ctx, task1.ID)
+	ValidateErr(t, err, nil)
+	if d := cmp.Diff(gottask, updatedtask, cmpopts.IgnoreFields(entity.Task{}, "CreatedAt", "CompletedAt")); len(d) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", d)
+	}
+	if updatedtask.CompletedAt == nil {
+		t.Errorf("want: completed_at to be set, got: nil")
+	}
+
+	// Delete
+	err = repo.Delete(ctx, task1.ID)
+	ValidateErr(t, err, nil)
+
+	_, err = repo.Get(ctx, task1.ID)
+	ValidateErr(t, err, repository.ErrTaskNotFound)
+}
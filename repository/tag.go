@@ -0,0 +1,28 @@
+//go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+var (
+	ErrTagNotFound = entity.NewNotFoundError("tag not found")
+	// ErrDuplicateTagName is returned when another tag of the same user has the name.
+	ErrDuplicateTagName = entity.NewConflictError("tag with this name already exists")
+)
+
+type TagRepository interface {
+	// Get returns ErrTagNotFound when no tag has the ID.
+	Get(ctx context.Context, id string) (*entity.Tag, error)
+	// List returns the tags of the user ordered by name.
+	List(ctx context.Context, userID string) ([]entity.Tag, error)
+	// Create returns ErrDuplicateTagName when another tag of the user has the name.
+	Create(ctx context.Context, tag entity.Tag) error
+	// Update renames the tag, keeping its owner and creation time. It returns ErrDuplicateTagName
+	// when another tag of the user has the name, and does nothing when no tag has the ID.
+	Update(ctx context.Context, tag entity.Tag) error
+	// Delete detaches the tag from every task before removing it, and does nothing when no tag has the ID.
+	Delete(ctx context.Context, id string) error
+}
@@ -3,14 +3,59 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
 )
 
+var ErrTaskNotFound = entity.NewNotFoundError("task not found")
+
+// TaskRepository stores the tags of a task as its TagIDs, which Get and List return in ascending order.
+// Tags is not stored.
 type TaskRepository interface {
+	// Get returns ErrTaskNotFound when no task has the ID.
 	Get(ctx context.Context, id string) (*entity.Task, error)
-	List(ctx context.Context, userID string) ([]entity.Task, error)
+	// List returns the tasks matching the query and an opaque cursor for the next page.
+	// The cursor is empty when there are no more tasks.
+	List(ctx context.Context, query TaskQuery) ([]entity.Task, string, error)
+	// Create fails when a task already has the ID.
 	Create(ctx context.Context, task entity.Task) error
+	// Update keeps the owner and the creation time of the task, and does nothing when no task has the ID.
 	Update(ctx context.Context, task entity.Task) error
+	// Delete does nothing when no task has the ID.
 	Delete(ctx context.Context, id string) error
 }
+
+const (
+	TaskSortDueDate   = "due_date"
+	TaskSortPriority  = "priority"
+	TaskSortCreatedAt = "created_at"
+)
+
+var ValidTaskSortKeys = map[string]bool{
+	TaskSortDueDate:   true,
+	TaskSortPriority:  true,
+	TaskSortCreatedAt: true,
+}
+
+// TaskQuery describes which tasks of a user List returns and in which order.
+// Zero values mean "no condition", except SortBy which defaults to TaskSortCreatedAt.
+type TaskQuery struct {
+	UserID      string
+	MinPriority int
+	MaxPriority int
+	DueAfter    time.Time
+	DueBefore   time.Time
+	// Overdue keeps only overdue tasks when true and only tasks that are not overdue when false.
+	// Whether a task is overdue is decided against Now.
+	Overdue     *bool
+	Now         time.Time
+	TitlePrefix string
+	// TagIDs keeps only the tasks with any of the tags, or with all of them when MatchAllTags is true.
+	TagIDs       []string
+	MatchAllTags bool
+	SortBy       string
+	SortDesc     bool
+	Limit        int
+	Cursor       string
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+var ErrInvalidCursor = entity.NewFieldValidationError("cursor", "invalid cursor")
+
+// TaskCursor is the position after which the next page starts.
+// It carries the sort key of the last task of the previous page and its ID as a tie-breaker,
+// so that pages stay stable while tasks are created or deleted.
+type TaskCursor struct {
+	SortBy    string    `json:"s"`
+	SortDesc  bool      `json:"o"`
+	ID        string    `json:"id"`
+	DueDate   time.Time `json:"d"`
+	Priority  int       `json:"p"`
+	CreatedAt time.Time `json:"c"`
+}
+
+// Value returns the sort key of the last task of the previous page.
+func (c TaskCursor) Value() interface{} {
+	switch c.SortBy {
+	case TaskSortDueDate:
+		return c.DueDate
+	case TaskSortPriority:
+		return c.Priority
+	default:
+		return c.CreatedAt
+	}
+}
+
+func (q TaskQuery) SortKey() string {
+	if q.SortBy == "" {
+		return TaskSortCreatedAt
+	}
+	return q.SortBy
+}
+
+// DecodeCursor parses q.Cursor. It returns nil when the query starts from the first page.
+func (q TaskQuery) DecodeCursor() (*TaskCursor, error) {
+	if q.Cursor == "" {
+		return nil, nil //nolint:nilnil // no cursor means the first page
+	}
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		log.Warn("Failed to decode cursor", log.Ferror(err))
+		return nil, ErrInvalidCursor
+	}
+	var c TaskCursor
+	if err = json.Unmarshal(data, &c); err != nil {
+		log.Warn("Failed to unmarshal cursor", log.Ferror(err))
+		return nil, ErrInvalidCursor
+	}
+	if c.ID == "" || c.SortBy != q.SortKey() || c.SortDesc != q.SortDesc {
+		log.Warn("Cursor does not match the query", log.Fstring("sort_by", c.SortBy))
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// EncodeCursor returns the cursor that continues the query after the given task.
+func (q TaskQuery) EncodeCursor(last entity.Task) string {
+	data, _ := json.Marshal(TaskCursor{
+		SortBy:    q.SortKey(),
+		SortDesc:  q.SortDesc,
+		ID:        last.ID,
+		DueDate:   last.DueDate,
+		Priority:  last.Priority,
+		CreatedAt: last.CreatedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Match reports whether the task satisfies the filters of the query, ignoring the cursor.
+func (q TaskQuery) Match(task entity.Task) bool {
+	if task.UserID != q.UserID {
+		return false
+	}
+	if q.MinPriority != 0 && task.Priority < q.MinPriority {
+		return false
+	}
+	if q.MaxPriority != 0 && task.Priority > q.MaxPriority {
+		return false
+	}
+	if !q.DueAfter.IsZero() && task.DueDate.Before(q.DueAfter) {
+		return false
+	}
+	if !q.DueBefore.IsZero() && !task.DueDate.Before(q.DueBefore) {
+		return false
+	}
+	if q.Overdue != nil && task.CheckOverdue(q.Now) != *q.Overdue {
+		return false
+	}
+	if q.TitlePrefix != "" && !strings.HasPrefix(task.Title, q.TitlePrefix) {
+		return false
+	}
+	return task.HasTags(q.TagIDs, q.MatchAllTags)
+}
+
+// DistinctTagIDs returns q.TagIDs without repetitions, in ascending order.
+func (q TaskQuery) DistinctTagIDs() []string {
+	return DistinctIDs(q.TagIDs)
+}
+
+// DistinctIDs returns ids without repetitions, in ascending order, or nil when ids is empty.
+func DistinctIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	var distinct []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			distinct = append(distinct, id)
+		}
+	}
+	sort.Strings(distinct)
+	return distinct
+}
+
+// compareTasks orders a and b by the sort key of the query and then by ID.
+func (q TaskQuery) compareTasks(a, b TaskCursor) int {
+	var c int
+	switch q.SortKey() {
+	case TaskSortDueDate:
+		c = a.DueDate.Compare(b.DueDate)
+	case TaskSortPriority:
+		c = a.Priority - b.Priority
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if q.SortDesc {
+		return -c
+	}
+	return c
+}
+
+func taskPosition(task entity.Task) TaskCursor {
+	return TaskCursor{
+		ID:        task.ID,
+		DueDate:   task.DueDate,
+		Priority:  task.Priority,
+		CreatedAt: task.CreatedAt,
+	}
+}
+
+// ApplyTaskQuery filters, sorts and pages tasks in memory.
+// It is meant for stores that cannot evaluate the query natively.
+func ApplyTaskQuery(tasks []entity.Task, q TaskQuery) ([]entity.Task, string, error) {
+	cursor, err := q.DecodeCursor()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var matched []entity.Task
+	for _, task := range tasks {
+		if !q.Match(task) {
+			continue
+		}
+		if cursor != nil && q.compareTasks(taskPosition(task), *cursor) <= 0 {
+			continue
+		}
+		matched = append(matched, task)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return q.compareTasks(taskPosition(matched[i]), taskPosition(matched[j])) < 0
+	})
+
+	page, next := q.Page(matched)
+	return page, next, nil
+}
+
+// Page trims tasks, which must already be filtered and sorted, to q.Limit.
+// Stores should fetch q.Limit+1 tasks so that Page can tell whether a next page exists.
+func (q TaskQuery) Page(tasks []entity.Task) ([]entity.Task, string) {
+	if q.Limit <= 0 || len(tasks) <= q.Limit {
+		return tasks, ""
+	}
+	page := tasks[:q.Limit]
+	return page, q.EncodeCursor(page[len(page)-1])
+}
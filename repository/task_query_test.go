@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+func TestRepository_ApplyTaskQuery(t *testing.T) {
+	t.Parallel()
+
+	userID := "user"
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	overdue := true
+
+	task1 := entity.Task{ID: "1", UserID: userID, Title: "Write docs", DueDate: now.AddDate(0, 0, -1), Priority: 1, Status: entity.StatusTodo, CreatedAt: now.AddDate(0, 0, -5), TagIDs: []string{"a"}}
+	task2 := entity.Task{ID: "2", UserID: userID, Title: "Write tests", DueDate: now.AddDate(0, 0, 1), Priority: 3, Status: entity.StatusTodo, CreatedAt: now.AddDate(0, 0, -4), TagIDs: []string{"a", "b"}}
+	task3 := entity.Task{ID: "3", UserID: userID, Title: "Review", DueDate: now.AddDate(0, 0, -2), Priority: 5, Status: entity.StatusDone, CreatedAt: now.AddDate(0, 0, -3)}
+	task4 := entity.Task{ID: "4", UserID: userID, Title: "Deploy", DueDate: now.AddDate(0, 0, 2), Priority: 3, Status: entity.StatusInProgress, CreatedAt: now.AddDate(0, 0, -2), TagIDs: []string{"b"}}
+	other := entity.Task{ID: "5", UserID: "other", Title: "Write", DueDate: now, Priority: 3, Status: entity.StatusTodo, CreatedAt: now}
+	tasks := []entity.Task{task4, other, task2, task1, task3}
+
+	patterns := []struct {
+		name  string
+		query TaskQuery
+		want  struct {
+			tasks []entity.Task
+			err   error
+		}
+	}{
+		{
+			name:  "success: default sort by created_at",
+			query: TaskQuery{UserID: userID},
+			want: struct {
+				tasks []entity.Task
+				err   error
+			}{
+				tasks: []entity.Task{task1, task2, task3, task4},
+			},
+		},
+		{
+			name:  "success: priority range sorted by priority descending",
+			query: TaskQuery{UserID: userID, MinPriority: 3, MaxPriority: 5, SortBy: TaskSortPriority, SortDesc: true},
+			want: struct {
+				tasks []entity.Task
+				err   error
+			}{
+				tasks: []entity.Task{task3, task4, task2},
+			},
+		},
+		{
+			name:  "success: overdue only",
+			query: TaskQuery{UserID: userID, Overdue: &overdue, Now: now},
+			want: struct {
+				tasks []entity.Task
+				err   error
+			}{
+				tasks: []entity.Task{task1},
+			},
+		},
+		{
+			name:  "success: due window and title prefix",
+			query: TaskQuery{UserID: userID, DueAfter: now.AddDate(0, 0, -1), DueBefore: now.AddDate(0, 0, 2), TitlePrefix: "Write", SortBy: TaskSortDueDate},
+			want: struct {
+				tasks []entity.Task
+				err   error
+			}{
+				tasks: []entity.Task{task1, task2},
+			},
+		},
+		{
+			name:  "success: any of the tags",
+			query: TaskQuery{UserID: userID, TagIDs: []string{"b", "c"}},
+			want: struct {
+				tasks []entity.Task
+				err   error
+			}{
+				tasks: []entity.Task{task2, task4},
+			},
+		},
+		{
+			name:  "success: all of the tags",
+			query: TaskQuery{UserID: userID, TagIDs: []string{"b", "a", "b"}, MatchAllTags: true},
+			want: struct {
+				tasks []entity.Task
+				err   error
+			}{
+				tasks: []entity.Task{task2},
+			},
+		},
+		{
+			name:  "Fail: invalid cursor",
+			query: TaskQuery{UserID: userID, Cursor: "invalid"},
+			want: struct {
+				tasks []entity.Task
+				err   error
+			}{
+				err: ErrInvalidCursor,
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, next, err := ApplyTaskQuery(tasks, tt.query)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("ApplyTaskQuery() error = %v, wantErr %v", err, tt.want.err)
+			}
+			if !reflect.DeepEqual(got, tt.want.tasks) {
+				t.Errorf("ApplyTaskQuery() got = %v, want %v", got, tt.want.tasks)
+			}
+			if next != "" {
+				t.Errorf("ApplyTaskQuery() next = %v, want empty", next)
+			}
+		})
+	}
+}
+
+func TestRepository_ApplyTaskQuery_Pagination(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	var tasks []entity.Task
+	for i := 0; i < 5; i++ {
+		tasks = append(tasks, entity.Task{
+			ID:       string(rune('a' + i)),
+			UserID:   "user",
+			DueDate:  now,
+			Priority: entity.Medium,
+		})
+	}
+
+	query := TaskQuery{UserID: "user", SortBy: TaskSortDueDate, Limit: 2}
+	var got []entity.Task
+	for pages := 0; ; pages++ {
+		if pages > len(tasks) {
+			t.Fatalf("pagination did not terminate")
+		}
+		page, next, err := ApplyTaskQuery(tasks, query)
+		if err != nil {
+			t.Fatalf("ApplyTaskQuery() error = %v", err)
+		}
+		got = append(got, page...)
+		if next == "" {
+			break
+		}
+		query.Cursor = next
+	}
+	if !reflect.DeepEqual(got, tasks) {
+		t.Errorf("ApplyTaskQuery() pages = %v, want %v", got, tasks)
+	}
+
+	query.SortBy = TaskSortPriority
+	if _, _, err := ApplyTaskQuery(tasks, query); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("ApplyTaskQuery() with a cursor of another sort error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestRepository_TaskQuery_DistinctTagIDs(t *testing.T) {
+	t.Parallel()
+
+	q := TaskQuery{TagIDs: []string{"b", "a", "b"}}
+	if got, want := q.DistinctTagIDs(), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DistinctTagIDs() = %v, want %v", got, want)
+	}
+	if got := (TaskQuery{}).DistinctTagIDs(); got != nil {
+		t.Errorf("DistinctTagIDs() = %v, want nil", got)
+	}
+}
@@ -7,10 +7,26 @@ import (
 	"github.com/tusmasoma/go-clean-arch/entity"
 )
 
+var (
+	ErrUserNotFound = entity.NewNotFoundError("user not found")
+	// ErrDuplicateEmail is returned by Create when the email is taken, which
+	// LockUserByEmail cannot prevent for an email no stored user has yet.
+	ErrDuplicateEmail = entity.NewConflictError("user with this email already exists")
+)
+
 type UserRepository interface {
+	// Get returns ErrUserNotFound when no user has the ID.
 	Get(ctx context.Context, id string) (*entity.User, error)
+	// GetByEmail returns ErrUserNotFound when no user has the email.
+	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	// Create returns ErrDuplicateEmail when another user has the email.
 	Create(ctx context.Context, user entity.User) error
+	// Update returns ErrDuplicateEmail when another user has the email,
+	// and does nothing when no user has the ID.
 	Update(ctx context.Context, user entity.User) error
+	// Delete does nothing when no user has the ID.
 	Delete(ctx context.Context, id string) error
+	// LockUserByEmail reports whether a user has the email, and holds that user
+	// until the transaction of ctx ends so that concurrent sign-ups are serialized.
 	LockUserByEmail(ctx context.Context, email string) (bool, error)
 }
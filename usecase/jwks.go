@@ -0,0 +1,27 @@
+//go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
+package usecase
+
+import (
+	"context"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+type JWKSUseCase interface {
+	GetJWKS(ctx context.Context) entity.JSONWebKeySet
+}
+
+type jwksUseCase struct {
+	ar repository.AuthRepository
+}
+
+func NewJWKSUseCase(ar repository.AuthRepository) JWKSUseCase {
+	return &jwksUseCase{
+		ar: ar,
+	}
+}
+
+func (juc *jwksUseCase) GetJWKS(_ context.Context) entity.JSONWebKeySet {
+	return juc.ar.PublicKeys()
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository/mock"
+)
+
+func TestJWKSUseCase_GetJWKS(t *testing.T) {
+	t.Parallel()
+
+	keySet := entity.JSONWebKeySet{
+		Keys: []entity.JSONWebKey{
+			{KeyType: "RSA", Use: "sig", Algorithm: "RS256", KeyID: "current", N: "n1", E: "AQAB"},
+			{KeyType: "RSA", Use: "sig", Algorithm: "RS256", KeyID: "retired", N: "n2", E: "AQAB"},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	ar := mock.NewMockAuthRepository(ctrl)
+	ar.EXPECT().PublicKeys().Return(keySet)
+
+	juc := NewJWKSUseCase(ar)
+	if got := juc.GetJWKS(context.Background()); !reflect.DeepEqual(got, keySet) {
+		t.Errorf("GetJWKS() = %v, want %v", got, keySet)
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: jwks.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	entity "github.com/tusmasoma/go-clean-arch/entity"
+)
+
+// MockJWKSUseCase is a mock of JWKSUseCase interface.
+type MockJWKSUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockJWKSUseCaseMockRecorder
+}
+
+// MockJWKSUseCaseMockRecorder is the mock recorder for MockJWKSUseCase.
+type MockJWKSUseCaseMockRecorder struct {
+	mock *MockJWKSUseCase
+}
+
+// NewMockJWKSUseCase creates a new mock instance.
+func NewMockJWKSUseCase(ctrl *gomock.Controller) *MockJWKSUseCase {
+	mock := &MockJWKSUseCase{ctrl: ctrl}
+	mock.recorder = &MockJWKSUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJWKSUseCase) EXPECT() *MockJWKSUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetJWKS mocks base method.
+func (m *MockJWKSUseCase) GetJWKS(ctx context.Context) entity.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS", ctx)
+	ret0, _ := ret[0].(entity.JSONWebKeySet)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockJWKSUseCaseMockRecorder) GetJWKS(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockJWKSUseCase)(nil).GetJWKS), ctx)
+}
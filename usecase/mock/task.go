@@ -81,18 +81,19 @@ func (mr *MockTaskUseCaseMockRecorder) GetTask(ctx, id interface{}) *gomock.Call
 }
 
 // ListTasks mocks base method.
-func (m *MockTaskUseCase) ListTasks(ctx context.Context) ([]entity.Task, error) {
+func (m *MockTaskUseCase) ListTasks(ctx context.Context, params *usecase.ListTasksParams) ([]entity.Task, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTasks", ctx)
+	ret := m.ctrl.Call(m, "ListTasks", ctx, params)
 	ret0, _ := ret[0].([]entity.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ListTasks indicates an expected call of ListTasks.
-func (mr *MockTaskUseCaseMockRecorder) ListTasks(ctx interface{}) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) ListTasks(ctx, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskUseCase)(nil).ListTasks), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskUseCase)(nil).ListTasks), ctx, params)
 }
 
 // UpdateTask mocks base method.
@@ -108,3 +109,32 @@ func (mr *MockTaskUseCaseMockRecorder) UpdateTask(ctx, params interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTask), ctx, params)
 }
+
+// UpdateTaskStatus mocks base method.
+func (m *MockTaskUseCase) UpdateTaskStatus(ctx context.Context, params *usecase.UpdateTaskStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
+func (mr *MockTaskUseCaseMockRecorder) UpdateTaskStatus(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTaskUseCase)(nil).UpdateTaskStatus), ctx, params)
+}
+
+// WatchTasks mocks base method.
+func (m *MockTaskUseCase) WatchTasks(ctx context.Context) (<-chan entity.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchTasks", ctx)
+	ret0, _ := ret[0].(<-chan entity.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchTasks indicates an expected call of WatchTasks.
+func (mr *MockTaskUseCaseMockRecorder) WatchTasks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTasks", reflect.TypeOf((*MockTaskUseCase)(nil).WatchTasks), ctx)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_event.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	entity "github.com/tusmasoma/go-clean-arch/entity"
+)
+
+// MockTaskEventBroker is a mock of TaskEventBroker interface.
+type MockTaskEventBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskEventBrokerMockRecorder
+}
+
+// MockTaskEventBrokerMockRecorder is the mock recorder for MockTaskEventBroker.
+type MockTaskEventBrokerMockRecorder struct {
+	mock *MockTaskEventBroker
+}
+
+// NewMockTaskEventBroker creates a new mock instance.
+func NewMockTaskEventBroker(ctrl *gomock.Controller) *MockTaskEventBroker {
+	mock := &MockTaskEventBroker{ctrl: ctrl}
+	mock.recorder = &MockTaskEventBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskEventBroker) EXPECT() *MockTaskEventBrokerMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockTaskEventBroker) Publish(event entity.TaskEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockTaskEventBrokerMockRecorder) Publish(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockTaskEventBroker)(nil).Publish), event)
+}
+
+// Subscribe mocks base method.
+func (m *MockTaskEventBroker) Subscribe(ctx context.Context, userID string) <-chan entity.TaskEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID)
+	ret0, _ := ret[0].(<-chan entity.TaskEvent)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockTaskEventBrokerMockRecorder) Subscribe(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockTaskEventBroker)(nil).Subscribe), ctx, userID)
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	entity "github.com/tusmasoma/go-clean-arch/entity"
+	usecase "github.com/tusmasoma/go-clean-arch/usecase"
 )
 
 // MockUserUseCase is a mock of UserUseCase interface.
@@ -37,10 +38,10 @@ func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
 }
 
 // CreateUserAndToken mocks base method.
-func (m *MockUserUseCase) CreateUserAndToken(ctx context.Context, email, passward string) (string, error) {
+func (m *MockUserUseCase) CreateUserAndToken(ctx context.Context, email, passward string) (*usecase.AuthTokens, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateUserAndToken", ctx, email, passward)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*usecase.AuthTokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -66,16 +67,74 @@ func (mr *MockUserUseCaseMockRecorder) GetUser(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserUseCase)(nil).GetUser), ctx)
 }
 
+// Login mocks base method.
+func (m *MockUserUseCase) Login(ctx context.Context, email, password string) (*usecase.AuthTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret0, _ := ret[0].(*usecase.AuthTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockUserUseCaseMockRecorder) Login(ctx, email, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUseCase)(nil).Login), ctx, email, password)
+}
+
+// Logout mocks base method.
+func (m *MockUserUseCase) Logout(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserUseCaseMockRecorder) Logout(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserUseCase)(nil).Logout), ctx, refreshToken)
+}
+
+// LogoutEverywhere mocks base method.
+func (m *MockUserUseCase) LogoutEverywhere(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutEverywhere", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutEverywhere indicates an expected call of LogoutEverywhere.
+func (mr *MockUserUseCaseMockRecorder) LogoutEverywhere(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutEverywhere", reflect.TypeOf((*MockUserUseCase)(nil).LogoutEverywhere), ctx)
+}
+
+// RefreshToken mocks base method.
+func (m *MockUserUseCase) RefreshToken(ctx context.Context, refreshToken string) (*usecase.AuthTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(*usecase.AuthTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockUserUseCaseMockRecorder) RefreshToken(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUserUseCase)(nil).RefreshToken), ctx, refreshToken)
+}
+
 // UpdateUser mocks base method.
-func (m *MockUserUseCase) UpdateUser(ctx context.Context, name string) error {
+func (m *MockUserUseCase) UpdateUser(ctx context.Context, params *usecase.UpdateUserParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, name)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, params)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserUseCaseMockRecorder) UpdateUser(ctx, name interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) UpdateUser(ctx, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserUseCase)(nil).UpdateUser), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserUseCase)(nil).UpdateUser), ctx, params)
 }
@@ -0,0 +1,136 @@
+//go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
+package usecase
+
+import (
+	"context"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+)
+
+var ErrTagNotOwned = entity.NewForbiddenError("tag does not belong to the user")
+
+type TagUseCase interface {
+	ListTags(ctx context.Context) ([]entity.Tag, error)
+	// CreateTag returns the new tag, whose ID the tasks and the other methods refer to it by.
+	CreateTag(ctx context.Context, params *CreateTagParams) (*entity.Tag, error)
+	UpdateTag(ctx context.Context, params *UpdateTagParams) error
+	// DeleteTag also removes the tag from the tasks of the user.
+	DeleteTag(ctx context.Context, id string) error
+}
+
+type tagUseCase struct {
+	tgr   repository.TagRepository
+	clock entity.Clock
+}
+
+func NewTagUseCase(tgr repository.TagRepository, clock entity.Clock) TagUseCase {
+	return &tagUseCase{
+		tgr:   tgr,
+		clock: clock,
+	}
+}
+
+func (tguc *tagUseCase) ListTags(ctx context.Context) ([]entity.Tag, error) {
+	userIDValue := ctx.Value(config.ContextUserIDKey)
+	userID, ok := userIDValue.(string)
+	if !ok {
+		log.Error("User ID not found in request context")
+		return nil, ErrUserNotInContext
+	}
+
+	tags, err := tguc.tgr.List(ctx, userID)
+	if err != nil {
+		log.Error("Failed to list tags", log.Ferror(err))
+		return nil, err
+	}
+	return tags, nil
+}
+
+type CreateTagParams struct {
+	Name string `json:"name"`
+}
+
+func (tguc *tagUseCase) CreateTag(ctx context.Context, params *CreateTagParams) (*entity.Tag, error) {
+	userIDValue := ctx.Value(config.ContextUserIDKey)
+	userID, ok := userIDValue.(string)
+	if !ok {
+		log.Error("User ID not found in request context")
+		return nil, ErrUserNotInContext
+	}
+
+	tag, err := entity.NewTag(userID, params.Name, tguc.clock.Now())
+	if err != nil {
+		log.Error("Failed to create tag", log.Ferror(err))
+		return nil, err
+	}
+	if err = tguc.tgr.Create(ctx, *tag); err != nil {
+		log.Error("Failed to create tag", log.Ferror(err))
+		return nil, err
+	}
+	return tag, nil
+}
+
+type UpdateTagParams struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (tguc *tagUseCase) UpdateTag(ctx context.Context, params *UpdateTagParams) error {
+	userIDValue := ctx.Value(config.ContextUserIDKey)
+	userID, ok := userIDValue.(string)
+	if !ok {
+		log.Error("User ID not found in request context")
+		return ErrUserNotInContext
+	}
+
+	tag, err := tguc.getOwnTag(ctx, userID, params.ID)
+	if err != nil {
+		return err
+	}
+	if err = tag.SetName(params.Name); err != nil {
+		return err
+	}
+
+	if err = tguc.tgr.Update(ctx, *tag); err != nil {
+		log.Error("Failed to update tag", log.Ferror(err))
+		return err
+	}
+	return nil
+}
+
+func (tguc *tagUseCase) DeleteTag(ctx context.Context, id string) error {
+	userIDValue := ctx.Value(config.ContextUserIDKey)
+	userID, ok := userIDValue.(string)
+	if !ok {
+		log.Error("User ID not found in request context")
+		return ErrUserNotInContext
+	}
+
+	if _, err := tguc.getOwnTag(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := tguc.tgr.Delete(ctx, id); err != nil {
+		log.Error("Failed to delete tag", log.Ferror(err))
+		return err
+	}
+	return nil
+}
+
+func (tguc *tagUseCase) getOwnTag(ctx context.Context, userID, id string) (*entity.Tag, error) {
+	tag, err := tguc.tgr.Get(ctx, id)
+	if err != nil {
+		log.Error("Failed to get tag", log.Ferror(err))
+		return nil, err
+	}
+	if tag.UserID != userID {
+		log.Error("Tag does not belong to the user", log.Fstring("tag_id", tag.ID), log.Fstring("user_id", userID))
+		return nil, ErrTagNotOwned
+	}
+	return tag, nil
+}
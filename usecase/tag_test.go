@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
+	"github.com/tusmasoma/go-clean-arch/repository/mock"
+)
+
+func TestUseCase_ListTags(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
+	tags := []entity.Tag{
+		{ID: uuid.New().String(), UserID: userID, Name: "backend"},
+		{ID: uuid.New().String(), UserID: userID, Name: "urgent"},
+	}
+
+	patterns := []struct {
+		name  string
+		setup func(m *mock.MockTagRepository)
+		arg   context.Context
+		want  struct {
+			tags []entity.Tag
+			err  error
+		}
+	}{
+		{
+			name: "success",
+			setup: func(tgr *mock.MockTagRepository) {
+				tgr.EXPECT().List(gomock.Any(), userID).Return(tags, nil)
+			},
+			arg: ctx,
+			want: struct {
+				tags []entity.Tag
+				err  error
+			}{
+				tags: tags,
+			},
+		},
+		{
+			name: "Fail: no user in the context",
+			arg:  context.Background(),
+			want: struct {
+				tags []entity.Tag
+				err  error
+			}{
+				err: ErrUserNotInContext,
+			},
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tgr := mock.NewMockTagRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tgr)
+			}
+
+			tguc := NewTagUseCase(tgr, fixedClock{now: time.Now()})
+
+			got, err := tguc.ListTags(tt.arg)
+
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("ListTags() error = %v, wantErr %v", err, tt.want.err)
+			}
+			if !reflect.DeepEqual(got, tt.want.tags) {
+				t.Errorf("ListTags() got = %v, want %v", got, tt.want.tags)
+			}
+		})
+	}
+}
+
+func TestUseCase_CreateTag(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	userID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
+
+	patterns := []struct {
+		name    string
+		setup   func(m *mock.MockTagRepository)
+		arg     *CreateTagParams
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(tgr *mock.MockTagRepository) {
+				tgr.EXPECT().Create(gomock.Any(), gomock.Any()).Do(func(_ context.Context, tag entity.Tag) {
+					if tag.UserID != userID {
+						t.Errorf("unexpected UserID: got %v, want %v", tag.UserID, userID)
+					}
+					if tag.Name != "backend" {
+						t.Errorf("unexpected Name: got %v, want %v", tag.Name, "backend")
+					}
+					if !tag.CreatedAt.Equal(now) {
+						t.Errorf("unexpected CreatedAt: got %v, want %v", tag.CreatedAt, now)
+					}
+				}).Return(nil)
+			},
+			arg: &CreateTagParams{Name: " backend "},
+		},
+		{
+			name:    "Fail: name is blank",
+			arg:     &CreateTagParams{Name: " "},
+			wantErr: entity.ErrValidation,
+		},
+		{
+			name: "Fail: name is taken",
+			setup: func(tgr *mock.MockTagRepository) {
+				tgr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrDuplicateTagName)
+			},
+			arg:     &CreateTagParams{Name: "backend"},
+			wantErr: repository.ErrDuplicateTagName,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tgr := mock.NewMockTagRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tgr)
+			}
+
+			tguc := NewTagUseCase(tgr, fixedClock{now: now})
+
+			tag, err := tguc.CreateTag(ctx, tt.arg)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (tag.ID == "" || tag.UserID != userID) {
+				t.Errorf("CreateTag() = %+v, want a new tag of the user", tag)
+			}
+		})
+	}
+}
+
+func TestUseCase_UpdateTag(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
+	tag := entity.Tag{ID: uuid.New().String(), UserID: userID, Name: "backend", CreatedAt: time.Now()}
+
+	patterns := []struct {
+		name    string
+		setup   func(m *mock.MockTagRepository)
+		arg     *UpdateTagParams
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(tgr *mock.MockTagRepository) {
+				got := tag
+				tgr.EXPECT().Get(gomock.Any(), tag.ID).Return(&got, nil)
+				want := tag
+				want.Name = "server"
+				tgr.EXPECT().Update(gomock.Any(), want).Return(nil)
+			},
+			arg: &UpdateTagParams{ID: tag.ID, Name: "server"},
+		},
+		{
+			name: "Fail: tag does not belong to the user",
+			setup: func(tgr *mock.MockTagRepository) {
+				got := tag
+				got.UserID = uuid.New().String()
+				tgr.EXPECT().Get(gomock.Any(), tag.ID).Return(&got, nil)
+			},
+			arg:     &UpdateTagParams{ID: tag.ID, Name: "server"},
+			wantErr: ErrTagNotOwned,
+		},
+		{
+			name: "Fail: name contains a comma",
+			setup: func(tgr *mock.MockTagRepository) {
+				got := tag
+				tgr.EXPECT().Get(gomock.Any(), tag.ID).Return(&got, nil)
+			},
+			arg:     &UpdateTagParams{ID: tag.ID, Name: "a,b"},
+			wantErr: entity.ErrValidation,
+		},
+		{
+			name: "Fail: tag not found",
+			setup: func(tgr *mock.MockTagRepository) {
+				tgr.EXPECT().Get(gomock.Any(), tag.ID).Return(nil, repository.ErrTagNotFound)
+			},
+			arg:     &UpdateTagParams{ID: tag.ID, Name: "server"},
+			wantErr: repository.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tgr := mock.NewMockTagRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tgr)
+			}
+
+			tguc := NewTagUseCase(tgr, fixedClock{now: time.Now()})
+
+			if err := tguc.UpdateTag(ctx, tt.arg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCase_DeleteTag(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
+	tag := entity.Tag{ID: uuid.New().String(), UserID: userID, Name: "backend"}
+
+	patterns := []struct {
+		name    string
+		setup   func(m *mock.MockTagRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(tgr *mock.MockTagRepository) {
+				got := tag
+				tgr.EXPECT().Get(gomock.Any(), tag.ID).Return(&got, nil)
+				tgr.EXPECT().Delete(gomock.Any(), tag.ID).Return(nil)
+			},
+		},
+		{
+			name: "Fail: tag does not belong to the user",
+			setup: func(tgr *mock.MockTagRepository) {
+				got := tag
+				got.UserID = uuid.New().String()
+				tgr.EXPECT().Get(gomock.Any(), tag.ID).Return(&got, nil)
+			},
+			wantErr: ErrTagNotOwned,
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tgr := mock.NewMockTagRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tgr)
+			}
+
+			tguc := NewTagUseCase(tgr, fixedClock{now: time.Now()})
+
+			if err := tguc.DeleteTag(ctx, tag.ID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
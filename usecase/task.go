@@ -3,7 +3,8 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
@@ -14,21 +15,43 @@ import (
 	"github.com/tusmasoma/go-clean-arch/repository"
 )
 
+var (
+	ErrTaskNotOwned   = entity.NewForbiddenError("task does not belong to the user")
+	ErrInvalidSortKey = entity.NewFieldValidationError("sort_by", "invalid sort key")
+)
+
 type TaskUseCase interface {
 	GetTask(ctx context.Context, id string) (*entity.Task, error)
-	ListTasks(ctx context.Context) ([]entity.Task, error)
+	ListTasks(ctx context.Context, params *ListTasksParams) ([]entity.Task, string, error)
 	CreateTask(ctx context.Context, params *CreateTaskParams) error
 	UpdateTask(ctx context.Context, params *UpdateTaskParams) error
+	UpdateTaskStatus(ctx context.Context, params *UpdateTaskStatusParams) error
 	DeleteTask(ctx context.Context, id string) error
+	// WatchTasks streams the changes of the tasks of the user until ctx is done.
+	WatchTasks(ctx context.Context) (<-chan entity.TaskEvent, error)
 }
 
 type taskUseCase struct {
-	tr repository.TaskRepository
+	tr    repository.TaskRepository
+	ur    repository.UserRepository
+	tgr   repository.TagRepository
+	clock entity.Clock
+	teb   TaskEventBroker
 }
 
-func NewTaskUseCase(tr repository.TaskRepository) TaskUseCase {
+func NewTaskUseCase(
+	tr repository.TaskRepository,
+	ur repository.UserRepository,
+	tgr repository.TagRepository,
+	clock entity.Clock,
+	teb TaskEventBroker,
+) TaskUseCase {
 	return &taskUseCase{
-		tr: tr,
+		tr:    tr,
+		ur:    ur,
+		tgr:   tgr,
+		clock: clock,
+		teb:   teb,
 	}
 }
 
@@ -37,7 +60,7 @@ func (tuc *taskUseCase) GetTask(ctx context.Context, id string) (*entity.Task, e
 	userID, ok := userIDValue.(string)
 	if !ok {
 		log.Error("User ID not found in request context")
-		return nil, errors.New("user name not found in request context")
+		return nil, ErrUserNotInContext
 	}
 
 	task, err := tuc.tr.Get(ctx, id)
@@ -48,25 +71,123 @@ func (tuc *taskUseCase) GetTask(ctx context.Context, id string) (*entity.Task, e
 
 	if task.UserID != userID {
 		log.Error("Task does not belong to the user", log.Fstring("task_id", task.ID), log.Fstring("user_id", userID))
-		return nil, errors.New("task does not belong to the user")
+		return nil, ErrTaskNotOwned
+	}
+
+	if err = tuc.resolveTags(ctx, userID, task); err != nil {
+		return nil, err
+	}
+
+	window, err := tuc.dueSoonWindow(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
+	task.SetDeadlineFlags(tuc.clock.Now(), window)
 	return task, nil
 }
 
-func (tuc *taskUseCase) ListTasks(ctx context.Context) ([]entity.Task, error) {
+const (
+	DefaultListTasksLimit = 50
+	MaxListTasksLimit     = 100
+)
+
+type ListTasksParams struct {
+	MinPriority int       `json:"min_priority"`
+	MaxPriority int       `json:"max_priority"`
+	DueAfter    time.Time `json:"due_after"`
+	DueBefore   time.Time `json:"due_before"`
+	Overdue     *bool     `json:"overdue"`
+	TitlePrefix string    `json:"title_prefix"`
+	// Tags keeps only the tasks with any of the named tags, or with all of them when MatchAllTags is true.
+	Tags         []string `json:"tags"`
+	MatchAllTags bool     `json:"match_all_tags"`
+	SortBy       string   `json:"sort_by"`
+	SortDesc     bool     `json:"sort_desc"`
+	Limit        int      `json:"limit"`
+	Cursor       string   `json:"cursor"`
+}
+
+func (tuc *taskUseCase) ListTasks(ctx context.Context, params *ListTasksParams) ([]entity.Task, string, error) {
 	userIDValue := ctx.Value(config.ContextUserIDKey)
 	userID, ok := userIDValue.(string)
 	if !ok {
 		log.Error("User ID not found in request context")
-		return nil, errors.New("user name not found in request context")
+		return nil, "", ErrUserNotInContext
 	}
 
-	tasks, err := tuc.tr.List(ctx, userID)
+	if params.SortBy != "" && !repository.ValidTaskSortKeys[params.SortBy] {
+		log.Error("Invalid sort key", log.Fstring("sort_by", params.SortBy))
+		return nil, "", ErrInvalidSortKey
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultListTasksLimit
+	}
+	if limit > MaxListTasksLimit {
+		limit = MaxListTasksLimit
+	}
+
+	var tags map[string]entity.Tag
+	var tagIDs []string
+	if len(params.Tags) != 0 {
+		var err error
+		if tags, err = tuc.listTags(ctx, userID); err != nil {
+			return nil, "", err
+		}
+		if tagIDs, ok = tagIDsByName(tags, params.Tags, params.MatchAllTags); !ok {
+			// no task has a tag the user does not have
+			return nil, "", nil
+		}
+	}
+
+	now := tuc.clock.Now()
+	tasks, next, err := tuc.tr.List(ctx, repository.TaskQuery{
+		UserID:       userID,
+		MinPriority:  params.MinPriority,
+		MaxPriority:  params.MaxPriority,
+		DueAfter:     params.DueAfter,
+		DueBefore:    params.DueBefore,
+		Overdue:      params.Overdue,
+		Now:          now,
+		TitlePrefix:  params.TitlePrefix,
+		TagIDs:       tagIDs,
+		MatchAllTags: params.MatchAllTags,
+		SortBy:       params.SortBy,
+		SortDesc:     params.SortDesc,
+		Limit:        limit,
+		Cursor:       params.Cursor,
+	})
 	if err != nil {
 		log.Error("Failed to list tasks", log.Ferror(err))
-		return nil, err
+		return nil, "", err
+	}
+
+	if tags == nil && anyTagged(tasks) {
+		if tags, err = tuc.listTags(ctx, userID); err != nil {
+			return nil, "", err
+		}
+	}
+	for i := range tasks {
+		tasks[i].ResolveTags(tags)
+	}
+
+	window, err := tuc.dueSoonWindow(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range tasks {
+		tasks[i].SetDeadlineFlags(now, window)
 	}
-	return tasks, nil
+	return tasks, next, nil
+}
+
+func (tuc *taskUseCase) dueSoonWindow(ctx context.Context, userID string) (time.Duration, error) {
+	user, err := tuc.ur.Get(ctx, userID)
+	if err != nil {
+		log.Error("Failed to get user", log.Ferror(err), log.Fstring("user_id", userID))
+		return 0, err
+	}
+	return user.DueSoonWindow(), nil
 }
 
 type CreateTaskParams struct {
@@ -74,6 +195,8 @@ type CreateTaskParams struct {
 	Description string    `json:"description"`
 	DueDate     time.Time `json:"due_date"`
 	Priority    int       `json:"priority"`
+	// Tags are the names of tags of the user.
+	Tags []string `json:"tags"`
 }
 
 func (tuc *taskUseCase) CreateTask(ctx context.Context, params *CreateTaskParams) error {
@@ -81,18 +204,22 @@ func (tuc *taskUseCase) CreateTask(ctx context.Context, params *CreateTaskParams
 	userID, ok := userIDValue.(string)
 	if !ok {
 		log.Error("User ID not found in request context")
-		return errors.New("user name not found in request context")
+		return ErrUserNotInContext
 	}
 
-	task, err := entity.NewTask(userID, params.Title, params.Description, params.DueDate, params.Priority)
+	task, err := entity.NewTask(userID, params.Title, params.Description, params.DueDate, params.Priority, tuc.clock.Now())
 	if err != nil {
 		log.Error("Failed to create task", log.Ferror(err))
 		return err
 	}
+	if err = tuc.setTags(ctx, userID, task, params.Tags); err != nil {
+		return err
+	}
 	if err = tuc.tr.Create(ctx, *task); err != nil {
 		log.Error("Failed to create task", log.Ferror(err))
 		return err
 	}
+	tuc.publish(entity.TaskEventCreated, *task)
 	return nil
 }
 
@@ -102,6 +229,9 @@ type UpdateTaskParams struct {
 	Description string    `json:"description"`
 	DueDate     time.Time `json:"due_date"`
 	Priority    int       `json:"priority"`
+	// Tags replace the tags of the task when given, an empty list removes them all.
+	// The tags are kept when Tags is nil.
+	Tags *[]string `json:"tags"`
 }
 
 func (tuc *taskUseCase) UpdateTask(ctx context.Context, params *UpdateTaskParams) error {
@@ -109,7 +239,7 @@ func (tuc *taskUseCase) UpdateTask(ctx context.Context, params *UpdateTaskParams
 	userID, ok := userIDValue.(string)
 	if !ok {
 		log.Error("User ID not found in request context")
-		return errors.New("user name not found in request context")
+		return ErrUserNotInContext
 	}
 
 	task, err := tuc.tr.Get(ctx, params.ID)
@@ -120,7 +250,7 @@ func (tuc *taskUseCase) UpdateTask(ctx context.Context, params *UpdateTaskParams
 
 	if task.UserID != userID {
 		log.Error("Task does not belong to the user", log.Fstring("task_id", task.ID), log.Fstring("user_id", userID))
-		return errors.New("task does not belong to the user")
+		return ErrTaskNotOwned
 	}
 
 	task.Title = params.Title
@@ -130,11 +260,57 @@ func (tuc *taskUseCase) UpdateTask(ctx context.Context, params *UpdateTaskParams
 		log.Error("Failed to set priority", log.Ferror(err))
 		return err
 	}
+	if params.Tags != nil {
+		if err = tuc.setTags(ctx, userID, task, *params.Tags); err != nil {
+			return err
+		}
+	}
 
 	if err = tuc.tr.Update(ctx, *task); err != nil {
 		log.Error("Failed to update task", log.Ferror(err))
 		return err
 	}
+	tuc.publish(entity.TaskEventUpdated, *task)
+	return nil
+}
+
+type UpdateTaskStatusParams struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (tuc *taskUseCase) UpdateTaskStatus(ctx context.Context, params *UpdateTaskStatusParams) error {
+	userIDValue := ctx.Value(config.ContextUserIDKey)
+	userID, ok := userIDValue.(string)
+	if !ok {
+		log.Error("User ID not found in request context")
+		return ErrUserNotInContext
+	}
+
+	task, err := tuc.tr.Get(ctx, params.ID)
+	if err != nil {
+		log.Error("Failed to get task", log.Ferror(err))
+		return err
+	}
+
+	if task.UserID != userID {
+		log.Error("Task does not belong to the user", log.Fstring("task_id", task.ID), log.Fstring("user_id", userID))
+		return ErrTaskNotOwned
+	}
+
+	if err = task.SetStatus(params.Status, tuc.clock.Now()); err != nil {
+		log.Error("Failed to set status", log.Ferror(err))
+		return err
+	}
+	if err = tuc.resolveTags(ctx, userID, task); err != nil {
+		return err
+	}
+
+	if err = tuc.tr.Update(ctx, *task); err != nil {
+		log.Error("Failed to update task", log.Ferror(err))
+		return err
+	}
+	tuc.publish(entity.TaskEventUpdated, *task)
 	return nil
 }
 
@@ -143,7 +319,7 @@ func (tuc *taskUseCase) DeleteTask(ctx context.Context, id string) error {
 	userID, ok := userIDValue.(string)
 	if !ok {
 		log.Error("User ID not found in request context")
-		return errors.New("user name not found in request context")
+		return ErrUserNotInContext
 	}
 
 	task, err := tuc.tr.Get(ctx, id)
@@ -154,12 +330,116 @@ func (tuc *taskUseCase) DeleteTask(ctx context.Context, id string) error {
 
 	if task.UserID != userID {
 		log.Error("Task does not belong to the user", log.Fstring("task_id", task.ID), log.Fstring("user_id", userID))
-		return errors.New("task does not belong to the user")
+		return ErrTaskNotOwned
 	}
 
+	if err = tuc.resolveTags(ctx, userID, task); err != nil {
+		return err
+	}
 	if err = tuc.tr.Delete(ctx, id); err != nil {
 		log.Error("Failed to delete task", log.Ferror(err))
 		return err
 	}
+	tuc.publish(entity.TaskEventDeleted, *task)
+	return nil
+}
+
+func (tuc *taskUseCase) WatchTasks(ctx context.Context) (<-chan entity.TaskEvent, error) {
+	userIDValue := ctx.Value(config.ContextUserIDKey)
+	userID, ok := userIDValue.(string)
+	if !ok {
+		log.Error("User ID not found in request context")
+		return nil, ErrUserNotInContext
+	}
+	return tuc.teb.Subscribe(ctx, userID), nil
+}
+
+// listTags returns the tags of the user by ID.
+func (tuc *taskUseCase) listTags(ctx context.Context, userID string) (map[string]entity.Tag, error) {
+	tags, err := tuc.tgr.List(ctx, userID)
+	if err != nil {
+		log.Error("Failed to list tags", log.Ferror(err), log.Fstring("user_id", userID))
+		return nil, err
+	}
+	byID := make(map[string]entity.Tag, len(tags))
+	for _, tag := range tags {
+		byID[tag.ID] = tag
+	}
+	return byID, nil
+}
+
+// resolveTags fills in the Tags of the task. The tags of the user are only read when the task has any.
+func (tuc *taskUseCase) resolveTags(ctx context.Context, userID string, task *entity.Task) error {
+	if len(task.TagIDs) == 0 {
+		return nil
+	}
+	tags, err := tuc.listTags(ctx, userID)
+	if err != nil {
+		return err
+	}
+	task.ResolveTags(tags)
+	return nil
+}
+
+// setTags replaces the tags of the task with the tags of the user with the names.
+func (tuc *taskUseCase) setTags(ctx context.Context, userID string, task *entity.Task, names []string) error {
+	if len(names) == 0 {
+		task.SetTags(nil)
+		return nil
+	}
+	tags, err := tuc.listTags(ctx, userID)
+	if err != nil {
+		return err
+	}
+	byName := tagsByName(tags)
+	selected := make([]entity.Tag, 0, len(names))
+	for _, name := range names {
+		tag, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			log.Error("Tag not found", log.Fstring("name", name), log.Fstring("user_id", userID))
+			return entity.NewFieldValidationError("tags", fmt.Sprintf("unknown tag: %s", name))
+		}
+		selected = append(selected, tag)
+	}
+	task.SetTags(selected)
 	return nil
 }
+
+func tagsByName(tags map[string]entity.Tag) map[string]entity.Tag {
+	byName := make(map[string]entity.Tag, len(tags))
+	for _, tag := range tags {
+		byName[tag.Name] = tag
+	}
+	return byName
+}
+
+// tagIDsByName returns the IDs of the tags with the names. Unknown names are left out, unless all is true,
+// in which case it reports false as no task can have them all. It also reports false when no name is known.
+func tagIDsByName(tags map[string]entity.Tag, names []string, all bool) ([]string, bool) {
+	byName := tagsByName(tags)
+	var ids []string
+	for _, name := range names {
+		tag, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			if all {
+				return nil, false
+			}
+			continue
+		}
+		ids = append(ids, tag.ID)
+	}
+	return ids, len(ids) != 0
+}
+
+func anyTagged(tasks []entity.Task) bool {
+	for _, task := range tasks {
+		if len(task.TagIDs) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (tuc *taskUseCase) publish(eventType string, task entity.Task) {
+	tuc.teb.Publish(entity.NewTaskEvent(eventType, task, tuc.clock.Now()))
+}
@@ -0,0 +1,75 @@
+//go:generate mockgen -source=$GOFILE -package=mock -destination=./mock/$GOFILE
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tusmasoma/go-tech-dojo/pkg/log"
+
+	"github.com/tusmasoma/go-clean-arch/entity"
+)
+
+// TaskEventBroker fans the changes of tasks out to the subscribers of their owner within this process.
+type TaskEventBroker interface {
+	Publish(event entity.TaskEvent)
+	// Subscribe returns the events of the tasks of userID until ctx is done, when the channel is closed.
+	Subscribe(ctx context.Context, userID string) <-chan entity.TaskEvent
+}
+
+// taskEventBufferSize is how many events a subscriber may fall behind
+// before further events are dropped for it, so that a slow client never blocks a write.
+const taskEventBufferSize = 16
+
+type taskEventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan entity.TaskEvent]struct{}
+}
+
+func NewTaskEventBroker() TaskEventBroker {
+	return &taskEventBroker{
+		subscribers: make(map[string]map[chan entity.TaskEvent]struct{}),
+	}
+}
+
+func (b *taskEventBroker) Publish(event entity.TaskEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[event.Task.UserID] {
+		select {
+		case ch <- event:
+		default:
+			log.Warn(
+				"Dropped task event for a slow subscriber",
+				log.Fstring("user_id", event.Task.UserID),
+				log.Fstring("task_id", event.Task.ID),
+			)
+		}
+	}
+}
+
+func (b *taskEventBroker) Subscribe(ctx context.Context, userID string) <-chan entity.TaskEvent {
+	ch := make(chan entity.TaskEvent, taskEventBufferSize)
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan entity.TaskEvent]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers[userID], ch)
+		if len(b.subscribers[userID]) == 0 {
+			delete(b.subscribers, userID)
+		}
+		b.mu.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
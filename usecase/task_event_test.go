@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/tusmasoma/go-clean-arch/config"
+	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository/mock"
+)
+
+func TestTaskEventBroker(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New().String()
+	otherUserID := uuid.New().String()
+	now := time.Now()
+
+	broker := NewTaskEventBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := broker.Subscribe(ctx, userID)
+
+	other := entity.NewTaskEvent(entity.TaskEventCreated, entity.Task{ID: uuid.New().String(), UserID: otherUserID}, now)
+	own := entity.NewTaskEvent(entity.TaskEventUpdated, entity.Task{ID: uuid.New().String(), UserID: userID}, now)
+	broker.Publish(other)
+	broker.Publish(own)
+
+	select {
+	case got := <-events:
+		if !reflect.DeepEqual(got, own) {
+			t.Errorf("Subscribe() received %v, want %v", got, own)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Subscribe() received no event")
+	}
+
+	// A subscriber that falls behind loses events instead of blocking the publisher.
+	for i := 0; i < taskEventBufferSize+1; i++ {
+		broker.Publish(own)
+	}
+
+	cancel()
+	received := 0
+	for range events {
+		received++
+	}
+	if received > taskEventBufferSize {
+		t.Errorf("Subscribe() buffered %d events, want at most %d", received, taskEventBufferSize)
+	}
+}
+
+func TestUseCase_WatchTasks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	userID := uuid.New().String()
+
+	ctrl := gomock.NewController(t)
+	tr := mock.NewMockTaskRepository(ctrl)
+	tr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	tuc := NewTaskUseCase(tr, mock.NewMockUserRepository(ctrl), mock.NewMockTagRepository(ctrl), fixedClock{now: now}, NewTaskEventBroker())
+
+	if _, err := tuc.WatchTasks(context.Background()); !errors.Is(err, ErrUserNotInContext) {
+		t.Fatalf("WatchTasks() error = %v, want %v", err, ErrUserNotInContext)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), config.ContextUserIDKey, userID))
+	defer cancel()
+	events, err := tuc.WatchTasks(ctx)
+	if err != nil {
+		t.Fatalf("WatchTasks() error = %v", err)
+	}
+
+	if err = tuc.CreateTask(ctx, &CreateTaskParams{
+		Title:       "title",
+		Description: "description",
+		DueDate:     now.AddDate(0, 0, 1),
+		Priority:    3,
+	}); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	select {
+	case got := <-events:
+		if got.Type != entity.TaskEventCreated || got.Task.UserID != userID || got.Task.Title != "title" || !got.OccurredAt.Equal(now) {
+			t.Errorf("WatchTasks() received %+v, want the created task", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchTasks() received no event")
+	}
+}
@@ -13,32 +13,50 @@ import (
 	"github.com/tusmasoma/go-clean-arch/config"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
 	"github.com/tusmasoma/go-clean-arch/repository/mock"
 )
 
 func TestUseCase_GetTask(t *testing.T) {
 	t.Parallel()
 
+	now := time.Now()
 	userID := uuid.New().String()
 	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
 
 	taskID := uuid.New().String()
-	dueDate := time.Now().AddDate(0, 0, 1)
 
-	task := &entity.Task{
+	task := entity.Task{
 		ID:          taskID,
 		UserID:      userID,
 		Title:       "title",
 		Description: "description",
-		DueDate:     dueDate,
+		DueDate:     now.Add(36 * time.Hour),
 		Priority:    3,
-		CreatedAt:   time.Now(),
+		Status:      entity.StatusTodo,
+		CreatedAt:   now,
+	}
+	user := &entity.User{
+		ID:           userID,
+		DueSoonHours: 48,
 	}
 
+	dueSoonTask := task
+	dueSoonTask.IsDueSoon = true
+
+	backend := entity.Tag{ID: uuid.New().String(), UserID: userID, Name: "backend"}
+	taggedTask := task
+	taggedTask.TagIDs = []string{backend.ID}
+	wantTaggedTask := dueSoonTask
+	wantTaggedTask.TagIDs = taggedTask.TagIDs
+	wantTaggedTask.Tags = []entity.Tag{backend}
+
 	patterns := []struct {
 		name  string
 		setup func(
 			m *mock.MockTaskRepository,
+			m1 *mock.MockUserRepository,
+			m2 *mock.MockTagRepository,
 		)
 		arg struct {
 			ctx context.Context
@@ -51,8 +69,10 @@ func TestUseCase_GetTask(t *testing.T) {
 	}{
 		{
 			name: "success",
-			setup: func(tr *mock.MockTaskRepository) {
-				tr.EXPECT().Get(gomock.Any(), taskID).Return(task, nil)
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				got := task
+				tr.EXPECT().Get(gomock.Any(), taskID).Return(&got, nil)
+				ur.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
 			},
 			arg: struct {
 				ctx context.Context
@@ -65,13 +85,36 @@ func TestUseCase_GetTask(t *testing.T) {
 				task *entity.Task
 				err  error
 			}{
-				task: task,
+				task: &dueSoonTask,
+				err:  nil,
+			},
+		},
+		{
+			name: "success: with tags",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				got := taggedTask
+				tr.EXPECT().Get(gomock.Any(), taskID).Return(&got, nil)
+				tgr.EXPECT().List(gomock.Any(), userID).Return([]entity.Tag{backend}, nil)
+				ur.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
+			},
+			arg: struct {
+				ctx context.Context
+				id  string
+			}{
+				ctx: ctx,
+				id:  taskID,
+			},
+			want: struct {
+				task *entity.Task
+				err  error
+			}{
+				task: &wantTaggedTask,
 				err:  nil,
 			},
 		},
 		{
 			name: "Fail: Task does not belong to the user",
-			setup: func(tr *mock.MockTaskRepository) {
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
 				tr.EXPECT().Get(gomock.Any(), taskID).Return(&entity.Task{
 					ID:     taskID,
 					UserID: uuid.New().String(),
@@ -101,12 +144,14 @@ func TestUseCase_GetTask(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			tr := mock.NewMockTaskRepository(ctrl)
+			ur := mock.NewMockUserRepository(ctrl)
+			tgr := mock.NewMockTagRepository(ctrl)
 
 			if tt.setup != nil {
-				tt.setup(tr)
+				tt.setup(tr, ur, tgr)
 			}
 
-			tuc := NewTaskUseCase(tr)
+			tuc := NewTaskUseCase(tr, ur, tgr, fixedClock{now: now}, NewTaskEventBroker())
 
 			getTask, err := tuc.GetTask(tt.arg.ctx, tt.arg.id)
 
@@ -126,56 +171,257 @@ func TestUseCase_GetTask(t *testing.T) {
 func TestUseCase_ListTasks(t *testing.T) {
 	t.Parallel()
 
+	now := time.Now()
 	userID := uuid.New().String()
 	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
-	dueDate := time.Now().AddDate(0, 0, 1)
-
-	tasks := []entity.Task{
-		{
-			ID:          uuid.New().String(),
-			UserID:      userID,
-			Title:       "title",
-			Description: "description",
-			DueDate:     dueDate,
-			Priority:    3,
-			CreatedAt:   time.Now(),
-		},
+	overdueTask := entity.Task{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Title:       "overdue",
+		Description: "description",
+		DueDate:     now.Add(-time.Hour),
+		Priority:    3,
+		Status:      entity.StatusInProgress,
+		CreatedAt:   now,
 	}
+	dueSoonTask := entity.Task{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Title:       "due soon",
+		Description: "description",
+		DueDate:     now.Add(12 * time.Hour),
+		Priority:    3,
+		Status:      entity.StatusTodo,
+		CreatedAt:   now,
+	}
+	laterTask := entity.Task{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Title:       "later",
+		Description: "description",
+		DueDate:     now.Add(36 * time.Hour),
+		Priority:    3,
+		Status:      entity.StatusTodo,
+		CreatedAt:   now,
+	}
+	user := &entity.User{
+		ID:           userID,
+		DueSoonHours: entity.DefaultDueSoonHours,
+	}
+
+	wantOverdueTask := overdueTask
+	wantOverdueTask.IsOverdue = true
+	wantDueSoonTask := dueSoonTask
+	wantDueSoonTask.IsDueSoon = true
+
+	overdue := true
+
+	backend := entity.Tag{ID: uuid.New().String(), UserID: userID, Name: "backend"}
+	urgent := entity.Tag{ID: uuid.New().String(), UserID: userID, Name: "urgent"}
+	taggedTask := laterTask
+	taggedTask.TagIDs = []string{urgent.ID, backend.ID}
+	wantTaggedTask := taggedTask
+	wantTaggedTask.Tags = []entity.Tag{backend, urgent}
 
 	patterns := []struct {
 		name  string
 		setup func(
 			m *mock.MockTaskRepository,
+			m1 *mock.MockUserRepository,
+			m2 *mock.MockTagRepository,
 		)
 		arg struct {
-			ctx context.Context
+			ctx    context.Context
+			params *ListTasksParams
 		}
 		want struct {
 			tasks []entity.Task
+			next  string
 			err   error
 		}
 	}{
 		{
 			name: "success",
-			setup: func(tr *mock.MockTaskRepository) {
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
 				tr.EXPECT().List(
 					gomock.Any(),
-					userID,
-				).Return(tasks, nil)
+					repository.TaskQuery{
+						UserID: userID,
+						Now:    now,
+						Limit:  DefaultListTasksLimit,
+					},
+				).Return([]entity.Task{overdueTask, dueSoonTask, laterTask}, "", nil)
+				ur.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
 			},
 			arg: struct {
-				ctx context.Context
+				ctx    context.Context
+				params *ListTasksParams
+			}{
+				ctx:    ctx,
+				params: &ListTasksParams{},
+			},
+			want: struct {
+				tasks []entity.Task
+				next  string
+				err   error
+			}{
+				tasks: []entity.Task{wantOverdueTask, wantDueSoonTask, laterTask},
+				next:  "",
+				err:   nil,
+			},
+		},
+		{
+			name: "success: filtered, sorted and paged",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tr.EXPECT().List(
+					gomock.Any(),
+					repository.TaskQuery{
+						UserID:      userID,
+						MinPriority: entity.Medium,
+						MaxPriority: entity.High,
+						Overdue:     &overdue,
+						Now:         now,
+						TitlePrefix: "over",
+						SortBy:      repository.TaskSortDueDate,
+						SortDesc:    true,
+						Limit:       MaxListTasksLimit,
+						Cursor:      "cursor",
+					},
+				).Return([]entity.Task{overdueTask}, "next", nil)
+				ur.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *ListTasksParams
 			}{
 				ctx: ctx,
+				params: &ListTasksParams{
+					MinPriority: entity.Medium,
+					MaxPriority: entity.High,
+					Overdue:     &overdue,
+					TitlePrefix: "over",
+					SortBy:      repository.TaskSortDueDate,
+					SortDesc:    true,
+					Limit:       MaxListTasksLimit + 1,
+					Cursor:      "cursor",
+				},
+			},
+			want: struct {
+				tasks []entity.Task
+				next  string
+				err   error
+			}{
+				tasks: []entity.Task{wantOverdueTask},
+				next:  "next",
+				err:   nil,
+			},
+		},
+		{
+			name: "success: any of the tags, one unknown",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tgr.EXPECT().List(gomock.Any(), userID).Return([]entity.Tag{backend, urgent}, nil)
+				tr.EXPECT().List(
+					gomock.Any(),
+					repository.TaskQuery{
+						UserID: userID,
+						Now:    now,
+						TagIDs: []string{backend.ID},
+						Limit:  DefaultListTasksLimit,
+					},
+				).Return([]entity.Task{taggedTask}, "", nil)
+				ur.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *ListTasksParams
+			}{
+				ctx:    ctx,
+				params: &ListTasksParams{Tags: []string{"backend", "unknown"}},
+			},
+			want: struct {
+				tasks []entity.Task
+				next  string
+				err   error
+			}{
+				tasks: []entity.Task{wantTaggedTask},
+				next:  "",
+				err:   nil,
+			},
+		},
+		{
+			name: "success: all of the tags, one unknown",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tgr.EXPECT().List(gomock.Any(), userID).Return([]entity.Tag{backend, urgent}, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *ListTasksParams
+			}{
+				ctx:    ctx,
+				params: &ListTasksParams{Tags: []string{"backend", "unknown"}, MatchAllTags: true},
+			},
+			want: struct {
+				tasks []entity.Task
+				next  string
+				err   error
+			}{
+				tasks: nil,
+				next:  "",
+				err:   nil,
+			},
+		},
+		{
+			name: "success: tagged tasks without a tag filter",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tr.EXPECT().List(
+					gomock.Any(),
+					repository.TaskQuery{
+						UserID: userID,
+						Now:    now,
+						Limit:  DefaultListTasksLimit,
+					},
+				).Return([]entity.Task{dueSoonTask, taggedTask}, "", nil)
+				tgr.EXPECT().List(gomock.Any(), userID).Return([]entity.Tag{backend, urgent}, nil)
+				ur.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *ListTasksParams
+			}{
+				ctx:    ctx,
+				params: &ListTasksParams{},
 			},
 			want: struct {
 				tasks []entity.Task
+				next  string
 				err   error
 			}{
-				tasks: tasks,
+				tasks: []entity.Task{wantDueSoonTask, wantTaggedTask},
+				next:  "",
 				err:   nil,
 			},
 		},
+		{
+			name: "Fail: invalid sort key",
+			arg: struct {
+				ctx    context.Context
+				params *ListTasksParams
+			}{
+				ctx: ctx,
+				params: &ListTasksParams{
+					SortBy: "title",
+				},
+			},
+			want: struct {
+				tasks []entity.Task
+				next  string
+				err   error
+			}{
+				tasks: nil,
+				next:  "",
+				err:   errors.New("invalid sort key"),
+			},
+		},
 	}
 
 	for _, tt := range patterns {
@@ -185,14 +431,16 @@ func TestUseCase_ListTasks(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			tr := mock.NewMockTaskRepository(ctrl)
+			ur := mock.NewMockUserRepository(ctrl)
+			tgr := mock.NewMockTagRepository(ctrl)
 
 			if tt.setup != nil {
-				tt.setup(tr)
+				tt.setup(tr, ur, tgr)
 			}
 
-			tuc := NewTaskUseCase(tr)
+			tuc := NewTaskUseCase(tr, ur, tgr, fixedClock{now: now}, NewTaskEventBroker())
 
-			getTasks, err := tuc.ListTasks(tt.arg.ctx)
+			getTasks, next, err := tuc.ListTasks(tt.arg.ctx, tt.arg.params)
 
 			if (err != nil) != (tt.want.err != nil) {
 				t.Errorf("ListTasks() error = %v, wantErr %v", err, tt.want.err)
@@ -203,6 +451,9 @@ func TestUseCase_ListTasks(t *testing.T) {
 			if !reflect.DeepEqual(getTasks, tt.want.tasks) {
 				t.Errorf("ListTasks() got = %v, want %v", getTasks, tt.want.tasks)
 			}
+			if next != tt.want.next {
+				t.Errorf("ListTasks() next = %v, want %v", next, tt.want.next)
+			}
 		})
 	}
 }
@@ -210,14 +461,19 @@ func TestUseCase_ListTasks(t *testing.T) {
 func TestUseCase_CreateTask(t *testing.T) { //nolint: gocognit // The complexity is caused by the test patterns
 	t.Parallel()
 
+	now := time.Now()
 	userID := uuid.New().String()
 	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
 	dueDate := time.Now().AddDate(0, 0, 1)
+	backend := entity.Tag{ID: "b", UserID: userID, Name: "backend"}
+	urgent := entity.Tag{ID: "a", UserID: userID, Name: "urgent"}
 
 	patterns := []struct {
 		name  string
 		setup func(
 			m *mock.MockTaskRepository,
+			m1 *mock.MockUserRepository,
+			m2 *mock.MockTagRepository,
 		)
 		arg struct {
 			ctx    context.Context
@@ -227,7 +483,7 @@ func TestUseCase_CreateTask(t *testing.T) { //nolint: gocognit // The complexity
 	}{
 		{
 			name: "success",
-			setup: func(tr *mock.MockTaskRepository) {
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
 				tr.EXPECT().Create(
 					gomock.Any(),
 					gomock.Any(),
@@ -247,6 +503,9 @@ func TestUseCase_CreateTask(t *testing.T) { //nolint: gocognit // The complexity
 					if task.Priority != 3 {
 						t.Errorf("unexpected Priority: got %v, want %v", task.Priority, 3)
 					}
+					if !task.CreatedAt.Equal(now) {
+						t.Errorf("unexpected CreatedAt: got %v, want %v", task.CreatedAt, now)
+					}
 				}).Return(nil)
 			},
 			arg: struct {
@@ -263,6 +522,54 @@ func TestUseCase_CreateTask(t *testing.T) { //nolint: gocognit // The complexity
 			},
 			wantErr: nil,
 		},
+		{
+			name: "success: with tags",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tgr.EXPECT().List(gomock.Any(), userID).Return([]entity.Tag{backend, urgent}, nil)
+				tr.EXPECT().Create(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, task entity.Task) {
+					if !reflect.DeepEqual(task.TagIDs, []string{"a", "b"}) {
+						t.Errorf("unexpected TagIDs: got %v, want %v", task.TagIDs, []string{"a", "b"})
+					}
+				}).Return(nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *CreateTaskParams
+			}{
+				ctx: ctx,
+				params: &CreateTaskParams{
+					Title:       "title",
+					Description: "description",
+					DueDate:     dueDate,
+					Priority:    3,
+					Tags:        []string{"urgent", "backend", "urgent"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Fail: unknown tag",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tgr.EXPECT().List(gomock.Any(), userID).Return([]entity.Tag{backend}, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *CreateTaskParams
+			}{
+				ctx: ctx,
+				params: &CreateTaskParams{
+					Title:       "title",
+					Description: "description",
+					DueDate:     dueDate,
+					Priority:    3,
+					Tags:        []string{"backend", "urgent"},
+				},
+			},
+			wantErr: entity.ErrValidation,
+		},
 	}
 
 	for _, tt := range patterns {
@@ -272,12 +579,14 @@ func TestUseCase_CreateTask(t *testing.T) { //nolint: gocognit // The complexity
 
 			ctrl := gomock.NewController(t)
 			tr := mock.NewMockTaskRepository(ctrl)
+			ur := mock.NewMockUserRepository(ctrl)
+			tgr := mock.NewMockTagRepository(ctrl)
 
 			if tt.setup != nil {
-				tt.setup(tr)
+				tt.setup(tr, ur, tgr)
 			}
 
-			tuc := NewTaskUseCase(tr)
+			tuc := NewTaskUseCase(tr, ur, tgr, fixedClock{now: now}, NewTaskEventBroker())
 
 			err := tuc.CreateTask(tt.arg.ctx, tt.arg.params)
 
@@ -291,6 +600,7 @@ func TestUseCase_CreateTask(t *testing.T) { //nolint: gocognit // The complexity
 func TestUseCase_UpdateTask(t *testing.T) { //nolint: gocognit // The complexity is caused by the test patterns
 	t.Parallel()
 
+	now := time.Now()
 	userID := uuid.New().String()
 	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
 	taskID := uuid.New().String()
@@ -305,11 +615,14 @@ func TestUseCase_UpdateTask(t *testing.T) { //nolint: gocognit // The complexity
 		Priority:    3,
 		CreatedAt:   time.Now(),
 	}
+	backend := entity.Tag{ID: uuid.New().String(), UserID: userID, Name: "backend"}
 
 	patterns := []struct {
 		name  string
 		setup func(
 			m *mock.MockTaskRepository,
+			m1 *mock.MockUserRepository,
+			m2 *mock.MockTagRepository,
 		)
 		arg struct {
 			ctx    context.Context
@@ -319,7 +632,7 @@ func TestUseCase_UpdateTask(t *testing.T) { //nolint: gocognit // The complexity
 	}{
 		{
 			name: "success",
-			setup: func(tr *mock.MockTaskRepository) {
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
 				tr.EXPECT().Get(
 					gomock.Any(),
 					taskID,
@@ -360,9 +673,111 @@ func TestUseCase_UpdateTask(t *testing.T) { //nolint: gocognit // The complexity
 			},
 			wantErr: nil,
 		},
+		{
+			name: "success: replaces the tags",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tagged := *task
+				tagged.TagIDs = []string{uuid.New().String()}
+				tr.EXPECT().Get(
+					gomock.Any(),
+					taskID,
+				).Return(&tagged, nil)
+				tgr.EXPECT().List(gomock.Any(), userID).Return([]entity.Tag{backend}, nil)
+				tr.EXPECT().Update(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, task entity.Task) {
+					if !reflect.DeepEqual(task.TagIDs, []string{backend.ID}) {
+						t.Errorf("unexpected TagIDs: got %v, want %v", task.TagIDs, []string{backend.ID})
+					}
+				}).Return(nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateTaskParams
+			}{
+				ctx: ctx,
+				params: &UpdateTaskParams{
+					ID:          taskID,
+					Title:       "updated title",
+					Description: "updated description",
+					DueDate:     dueDate,
+					Priority:    2,
+					Tags:        &[]string{"backend"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success: keeps the tags when they are omitted",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tagged := *task
+				tagged.TagIDs = []string{backend.ID}
+				tr.EXPECT().Get(
+					gomock.Any(),
+					taskID,
+				).Return(&tagged, nil)
+				tr.EXPECT().Update(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, task entity.Task) {
+					if !reflect.DeepEqual(task.TagIDs, []string{backend.ID}) {
+						t.Errorf("unexpected TagIDs: got %v, want %v", task.TagIDs, []string{backend.ID})
+					}
+				}).Return(nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateTaskParams
+			}{
+				ctx: ctx,
+				params: &UpdateTaskParams{
+					ID:          taskID,
+					Title:       "updated title",
+					Description: "updated description",
+					DueDate:     dueDate,
+					Priority:    2,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success: removes the tags when they are empty",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tagged := *task
+				tagged.TagIDs = []string{backend.ID}
+				tr.EXPECT().Get(
+					gomock.Any(),
+					taskID,
+				).Return(&tagged, nil)
+				tr.EXPECT().Update(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, task entity.Task) {
+					if len(task.TagIDs) != 0 {
+						t.Errorf("unexpected TagIDs: got %v, want none", task.TagIDs)
+					}
+				}).Return(nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateTaskParams
+			}{
+				ctx: ctx,
+				params: &UpdateTaskParams{
+					ID:          taskID,
+					Title:       "updated title",
+					Description: "updated description",
+					DueDate:     dueDate,
+					Priority:    2,
+					Tags:        &[]string{},
+				},
+			},
+			wantErr: nil,
+		},
 		{
 			name: "Fail: Task does not belong to the user",
-			setup: func(tr *mock.MockTaskRepository) {
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
 				tr.EXPECT().Get(
 					gomock.Any(),
 					taskID,
@@ -395,12 +810,14 @@ func TestUseCase_UpdateTask(t *testing.T) { //nolint: gocognit // The complexity
 
 			ctrl := gomock.NewController(t)
 			tr := mock.NewMockTaskRepository(ctrl)
+			ur := mock.NewMockUserRepository(ctrl)
+			tgr := mock.NewMockTagRepository(ctrl)
 
 			if tt.setup != nil {
-				tt.setup(tr)
+				tt.setup(tr, ur, tgr)
 			}
 
-			tuc := NewTaskUseCase(tr)
+			tuc := NewTaskUseCase(tr, ur, tgr, fixedClock{now: now}, NewTaskEventBroker())
 
 			err := tuc.UpdateTask(tt.arg.ctx, tt.arg.params)
 
@@ -413,9 +830,143 @@ func TestUseCase_UpdateTask(t *testing.T) { //nolint: gocognit // The complexity
 	}
 }
 
+func TestUseCase_UpdateTaskStatus(t *testing.T) { //nolint: gocognit // The complexity is caused by the test patterns
+	t.Parallel()
+
+	now := time.Now()
+	userID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
+	taskID := uuid.New().String()
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockTaskRepository,
+			m1 *mock.MockUserRepository,
+			m2 *mock.MockTagRepository,
+		)
+		arg struct {
+			ctx    context.Context
+			params *UpdateTaskStatusParams
+		}
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tr.EXPECT().Get(
+					gomock.Any(),
+					taskID,
+				).Return(&entity.Task{
+					ID:     taskID,
+					UserID: userID,
+					Status: entity.StatusInProgress,
+				}, nil)
+				tr.EXPECT().Update(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, task entity.Task) {
+					if task.Status != entity.StatusDone {
+						t.Errorf("unexpected Status: got %v, want %v", task.Status, entity.StatusDone)
+					}
+					if task.CompletedAt == nil || !task.CompletedAt.Equal(now) {
+						t.Errorf("unexpected CompletedAt: got %v, want %v", task.CompletedAt, now)
+					}
+				}).Return(nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateTaskStatusParams
+			}{
+				ctx: ctx,
+				params: &UpdateTaskStatusParams{
+					ID:     taskID,
+					Status: entity.StatusDone,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Fail: Transition is not allowed",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tr.EXPECT().Get(
+					gomock.Any(),
+					taskID,
+				).Return(&entity.Task{
+					ID:     taskID,
+					UserID: userID,
+					Status: entity.StatusArchived,
+				}, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateTaskStatusParams
+			}{
+				ctx: ctx,
+				params: &UpdateTaskStatusParams{
+					ID:     taskID,
+					Status: entity.StatusDone,
+				},
+			},
+			wantErr: errors.New("cannot change status from archived to done"),
+		},
+		{
+			name: "Fail: Task does not belong to the user",
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
+				tr.EXPECT().Get(
+					gomock.Any(),
+					taskID,
+				).Return(&entity.Task{
+					ID:     taskID,
+					UserID: uuid.New().String(),
+					Status: entity.StatusTodo,
+				}, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateTaskStatusParams
+			}{
+				ctx: ctx,
+				params: &UpdateTaskStatusParams{
+					ID:     taskID,
+					Status: entity.StatusDone,
+				},
+			},
+			wantErr: errors.New("task does not belong to the user"),
+		},
+	}
+
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tr := mock.NewMockTaskRepository(ctrl)
+			ur := mock.NewMockUserRepository(ctrl)
+			tgr := mock.NewMockTagRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(tr, ur, tgr)
+			}
+
+			tuc := NewTaskUseCase(tr, ur, tgr, fixedClock{now: now}, NewTaskEventBroker())
+
+			err := tuc.UpdateTaskStatus(tt.arg.ctx, tt.arg.params)
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("UpdateTaskStatus() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil && tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("UpdateTaskStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestUsaCase_DeleteTask(t *testing.T) {
 	t.Parallel()
 
+	now := time.Now()
 	userID := uuid.New().String()
 	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
 	taskID := uuid.New().String()
@@ -424,6 +975,8 @@ func TestUsaCase_DeleteTask(t *testing.T) {
 		name  string
 		setup func(
 			m *mock.MockTaskRepository,
+			m1 *mock.MockUserRepository,
+			m2 *mock.MockTagRepository,
 		)
 		arg struct {
 			ctx context.Context
@@ -433,7 +986,7 @@ func TestUsaCase_DeleteTask(t *testing.T) {
 	}{
 		{
 			name: "success",
-			setup: func(tr *mock.MockTaskRepository) {
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
 				tr.EXPECT().Get(
 					gomock.Any(),
 					taskID,
@@ -454,7 +1007,7 @@ func TestUsaCase_DeleteTask(t *testing.T) {
 		},
 		{
 			name: "Fail: Task does not belong to the user",
-			setup: func(tr *mock.MockTaskRepository) {
+			setup: func(tr *mock.MockTaskRepository, ur *mock.MockUserRepository, tgr *mock.MockTagRepository) {
 				tr.EXPECT().Get(
 					gomock.Any(),
 					taskID,
@@ -481,12 +1034,14 @@ func TestUsaCase_DeleteTask(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			tr := mock.NewMockTaskRepository(ctrl)
+			ur := mock.NewMockUserRepository(ctrl)
+			tgr := mock.NewMockTagRepository(ctrl)
 
 			if tt.setup != nil {
-				tt.setup(tr)
+				tt.setup(tr, ur, tgr)
 			}
 
-			tuc := NewTaskUseCase(tr)
+			tuc := NewTaskUseCase(tr, ur, tgr, fixedClock{now: now}, NewTaskEventBroker())
 
 			err := tuc.DeleteTask(tt.arg.ctx, tt.arg.id)
 
@@ -498,3 +1053,11 @@ func TestUsaCase_DeleteTask(t *testing.T) {
 		})
 	}
 }
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
@@ -4,7 +4,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"sync"
 
+	"github.com/google/uuid"
 	"github.com/tusmasoma/go-tech-dojo/pkg/log"
 
 	"github.com/tusmasoma/go-clean-arch/config"
@@ -15,25 +17,60 @@ import (
 
 type UserUseCase interface {
 	GetUser(ctx context.Context) (*entity.User, error)
-	CreateUserAndToken(ctx context.Context, email string, passward string) (string, error)
-	UpdateUser(ctx context.Context, name string) error
+	CreateUserAndToken(ctx context.Context, email string, passward string) (*AuthTokens, error)
+	Login(ctx context.Context, email string, password string) (*AuthTokens, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutEverywhere(ctx context.Context) error
+	UpdateUser(ctx context.Context, params *UpdateUserParams) error
+}
+
+var (
+	ErrInvalidCredentials  = entity.NewUnauthenticatedError("invalid email or password")
+	ErrInvalidRefreshToken = entity.NewUnauthenticatedError("invalid refresh token")
+	ErrRefreshTokenReused  = entity.NewUnauthenticatedError("refresh token reused")
+	ErrUserNotInContext    = entity.NewUnauthenticatedError("user name not found in request context")
+	ErrUserAlreadyExists   = entity.NewConflictError("user with this email already exists")
+)
+
+// AuthTokens are issued on sign up, login and refresh.
+// The access token is short-lived; the opaque refresh token is exchanged for a new pair at /api/auth/refresh.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
 }
 
 type userUseCase struct {
 	ur repository.UserRepository
 	tr repository.TransactionRepository
 	ar repository.AuthRepository
+	pr repository.PasswordRepository
+	rr repository.RefreshTokenRepository
+	vr repository.RevocationRepository
+
+	clock entity.Clock
+
+	dummyOnce sync.Once
+	dummyHash string
 }
 
 func NewUserUseCase(
 	ur repository.UserRepository,
 	tr repository.TransactionRepository,
 	ar repository.AuthRepository,
+	pr repository.PasswordRepository,
+	rr repository.RefreshTokenRepository,
+	vr repository.RevocationRepository,
+	clock entity.Clock,
 ) UserUseCase {
 	return &userUseCase{
-		ur: ur,
-		tr: tr,
-		ar: ar,
+		ur:    ur,
+		tr:    tr,
+		ar:    ar,
+		pr:    pr,
+		rr:    rr,
+		vr:    vr,
+		clock: clock,
 	}
 }
 
@@ -42,7 +79,7 @@ func (uuc *userUseCase) GetUser(ctx context.Context) (*entity.User, error) {
 	userID, ok := userIDValue.(string)
 	if !ok {
 		log.Error("User ID not found in request context")
-		return nil, errors.New("user name not found in request context")
+		return nil, ErrUserNotInContext
 	}
 	user, err := uuc.ur.Get(ctx, userID)
 	if err != nil {
@@ -52,9 +89,19 @@ func (uuc *userUseCase) GetUser(ctx context.Context) (*entity.User, error) {
 	return user, nil
 }
 
-func (uuc *userUseCase) CreateUserAndToken(ctx context.Context, email string, password string) (string, error) {
+func (uuc *userUseCase) CreateUserAndToken(ctx context.Context, email string, password string) (*AuthTokens, error) {
+	if password == "" {
+		log.Error("password is required")
+		return nil, entity.NewFieldValidationError("password", "password is required")
+	}
+	hash, err := uuc.pr.Hash(password)
+	if err != nil {
+		log.Error("Error hashing password", log.Fstring("email", email))
+		return nil, err
+	}
+
 	var user *entity.User
-	if err := uuc.tr.Transaction(ctx, func(ctx context.Context) error {
+	if err = uuc.tr.Transaction(ctx, func(ctx context.Context) error {
 		exists, err := uuc.ur.LockUserByEmail(ctx, email)
 		if err != nil {
 			log.Error("Error retrieving user by email", log.Fstring("email", email))
@@ -62,10 +109,10 @@ func (uuc *userUseCase) CreateUserAndToken(ctx context.Context, email string, pa
 		}
 		if exists {
 			log.Info("User with this email already exists", log.Fstring("email", email))
-			return errors.New("user with this email already exists")
+			return ErrUserAlreadyExists
 		}
 
-		user, err = entity.NewUser(email, password) // hash password
+		user, err = entity.NewUser(email, hash)
 		if err != nil {
 			log.Error("Error creating new user", log.Fstring("email", email))
 			return err
@@ -78,19 +125,199 @@ func (uuc *userUseCase) CreateUserAndToken(ctx context.Context, email string, pa
 
 		return nil
 	}); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return uuc.issueTokens(ctx, user, "")
+}
+
+func (uuc *userUseCase) Login(ctx context.Context, email string, password string) (*AuthTokens, error) {
+	user, err := uuc.ur.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			// compare anyway so that unknown emails take as long to reject as wrong passwords
+			_ = uuc.pr.Compare(uuc.dummyPasswordHash(), password)
+			log.Info("Login with unknown email", log.Fstring("email", email))
+			return nil, ErrInvalidCredentials
+		}
+		log.Error("Error getting user by email", log.Fstring("email", email))
+		return nil, err
+	}
+
+	if err = uuc.pr.Compare(user.Password, password); err != nil {
+		if errors.Is(err, repository.ErrPasswordMismatch) {
+			log.Info("Login with wrong password", log.Fstring("user_id", user.ID))
+			return nil, ErrInvalidCredentials
+		}
+		log.Error("Error comparing password", log.Fstring("user_id", user.ID))
+		return nil, err
+	}
+
+	if uuc.pr.NeedsRehash(user.Password) {
+		uuc.rehashPassword(ctx, user, password)
+	}
+
+	return uuc.issueTokens(ctx, user, "")
+}
+
+func (uuc *userUseCase) RefreshToken(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	tokenHash := entity.HashRefreshToken(refreshToken)
+	stored, err := uuc.rr.Get(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			log.Info("Unknown refresh token")
+			return nil, ErrInvalidRefreshToken
+		}
+		log.Error("Error getting refresh token", log.Ferror(err))
+		return nil, err
+	}
+	if stored.Revoked {
+		log.Info("Revoked refresh token", log.Fstring("family_id", stored.FamilyID))
+		return nil, ErrInvalidRefreshToken
+	}
+	if stored.Used {
+		return nil, uuc.revokeReusedFamily(ctx, stored)
+	}
+	if stored.IsExpired(uuc.clock.Now()) {
+		log.Info("Expired refresh token", log.Fstring("family_id", stored.FamilyID))
+		return nil, ErrInvalidRefreshToken
+	}
+
+	marked, err := uuc.rr.MarkUsed(ctx, tokenHash)
+	if err != nil {
+		log.Error("Error marking refresh token as used", log.Ferror(err))
+		return nil, err
+	}
+	if !marked {
+		// another request used the same token in the meantime
+		return nil, uuc.revokeReusedFamily(ctx, stored)
+	}
+
+	user, err := uuc.ur.Get(ctx, stored.UserID)
+	if err != nil {
+		log.Error("Error getting user", log.Fstring("user_id", stored.UserID))
+		return nil, err
 	}
+	return uuc.issueTokens(ctx, user, stored.FamilyID)
+}
 
+// Logout revokes the access token of the request and, when given, the family of the refresh token.
+func (uuc *userUseCase) Logout(ctx context.Context, refreshToken string) error {
+	claims, ok := ctx.Value(config.ContextTokenClaimsKey).(*repository.TokenClaims)
+	if !ok {
+		log.Error("Token claims not found in request context")
+		return entity.NewUnauthenticatedError("token claims not found in request context")
+	}
+	if err := uuc.vr.RevokeToken(ctx, claims.JTI, claims.ExpiresAt); err != nil {
+		log.Error("Error revoking access token", log.Fstring("jti", claims.JTI))
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	stored, err := uuc.rr.Get(ctx, entity.HashRefreshToken(refreshToken))
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		return nil
+	} else if err != nil {
+		log.Error("Error getting refresh token", log.Ferror(err))
+		return err
+	}
+	if stored.UserID != claims.UserID {
+		log.Warn("Logout with a refresh token of another user", log.Fstring("user_id", claims.UserID))
+		return nil
+	}
+	if err = uuc.rr.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		log.Error("Error revoking refresh token family", log.Fstring("family_id", stored.FamilyID))
+		return err
+	}
+	return nil
+}
+
+// LogoutEverywhere revokes every access and refresh token issued to the user so far,
+// including the access token of the request.
+func (uuc *userUseCase) LogoutEverywhere(ctx context.Context) error {
+	userIDValue := ctx.Value(config.ContextUserIDKey)
+	userID, ok := userIDValue.(string)
+	if !ok {
+		log.Error("User ID not found in request context")
+		return ErrUserNotInContext
+	}
+	if err := uuc.vr.RevokeUserTokens(ctx, userID, uuc.clock.Now()); err != nil {
+		log.Error("Error revoking access tokens", log.Fstring("user_id", userID))
+		return err
+	}
+	if err := uuc.rr.RevokeUser(ctx, userID); err != nil {
+		log.Error("Error revoking refresh tokens", log.Fstring("user_id", userID))
+		return err
+	}
+	return nil
+}
+
+// revokeReusedFamily revokes every token of the family of a refresh token that was presented twice.
+// Either the legitimate client or an attacker holds a copy, and the two cannot be told apart.
+func (uuc *userUseCase) revokeReusedFamily(ctx context.Context, stored *entity.RefreshToken) error {
+	log.Warn("Refresh token reuse detected", log.Fstring("user_id", stored.UserID), log.Fstring("family_id", stored.FamilyID))
+	if err := uuc.rr.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		log.Error("Error revoking refresh token family", log.Fstring("family_id", stored.FamilyID))
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// issueTokens issues an access token and a refresh token in the given family, or in a new one when familyID is empty.
+func (uuc *userUseCase) issueTokens(ctx context.Context, user *entity.User, familyID string) (*AuthTokens, error) {
 	jwt, _ := uuc.ar.GenerateToken(user.ID, user.Email)
-	return jwt, nil
+	refreshToken, stored := uuc.ar.GenerateRefreshToken(user.ID, familyID)
+	if err := uuc.rr.Create(ctx, stored); err != nil {
+		log.Error("Error storing refresh token", log.Fstring("user_id", user.ID))
+		return nil, err
+	}
+	return &AuthTokens{
+		AccessToken:  jwt,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// dummyPasswordHash returns a hash made with the current parameters, computed once.
+func (uuc *userUseCase) dummyPasswordHash() string {
+	uuc.dummyOnce.Do(func() {
+		hash, err := uuc.pr.Hash(uuid.New().String())
+		if err != nil {
+			log.Warn("Failed to hash dummy password", log.Ferror(err))
+			return
+		}
+		uuc.dummyHash = hash
+	})
+	return uuc.dummyHash
 }
 
-func (uuc *userUseCase) UpdateUser(ctx context.Context, name string) error {
+// rehashPassword upgrades the stored hash to the current cost.
+// Failing to do so does not fail the login; it is retried on the next one.
+func (uuc *userUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	hash, err := uuc.pr.Hash(password)
+	if err != nil {
+		log.Warn("Failed to rehash password", log.Fstring("user_id", user.ID), log.Ferror(err))
+		return
+	}
+	user.Password = hash
+	if err = uuc.ur.Update(ctx, *user); err != nil {
+		log.Warn("Failed to store rehashed password", log.Fstring("user_id", user.ID), log.Ferror(err))
+	}
+}
+
+type UpdateUserParams struct {
+	Name string `json:"name"`
+	// DueSoonHours is left unchanged when zero.
+	DueSoonHours int `json:"due_soon_hours"`
+}
+
+func (uuc *userUseCase) UpdateUser(ctx context.Context, params *UpdateUserParams) error {
 	userIDValue := ctx.Value(config.ContextUserIDKey)
 	userID, ok := userIDValue.(string)
 	if !ok {
 		log.Error("User ID not found in request context")
-		return errors.New("user name not found in request context")
+		return ErrUserNotInContext
 	}
 	user, err := uuc.ur.Get(ctx, userID)
 	if err != nil {
@@ -99,9 +326,15 @@ func (uuc *userUseCase) UpdateUser(ctx context.Context, name string) error {
 	}
 
 	// TODO: setter method for user
-	user.Name = name
+	user.Name = params.Name
 	// user.Email = email
 	// user.Password = password
+	if params.DueSoonHours != 0 {
+		if err = user.SetDueSoonHours(params.DueSoonHours); err != nil {
+			log.Error("Failed to set due soon hours", log.Ferror(err))
+			return err
+		}
+	}
 
 	if err = uuc.ur.Update(ctx, *user); err != nil {
 		log.Error("Error updating user", log.Fstring("user_id", userID))
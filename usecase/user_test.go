@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"github.com/tusmasoma/go-clean-arch/config"
 
 	"github.com/tusmasoma/go-clean-arch/entity"
+	"github.com/tusmasoma/go-clean-arch/repository"
 	"github.com/tusmasoma/go-clean-arch/repository/mock"
 )
 
@@ -61,12 +63,15 @@ func TestUserUseCase_GetUser(t *testing.T) {
 			ur := mock.NewMockUserRepository(ctrl)
 			tr := mock.NewMockTransactionRepository(ctrl)
 			ar := mock.NewMockAuthRepository(ctrl)
+			pr := mock.NewMockPasswordRepository(ctrl)
+			rr := mock.NewMockRefreshTokenRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
 
 			if tt.setup != nil {
 				tt.setup(ur, tr)
 			}
 
-			usecase := NewUserUseCase(ur, tr, ar)
+			usecase := NewUserUseCase(ur, tr, ar, pr, rr, vr, fixedClock{now: time.Now()})
 			_, err := usecase.GetUser(tt.ctx)
 
 			if (err != nil) != (tt.wantErr != nil) {
@@ -87,6 +92,8 @@ func TestUserUseCase_CreateUserAndToken(t *testing.T) {
 			m *mock.MockUserRepository,
 			m1 *mock.MockTransactionRepository,
 			m2 *mock.MockAuthRepository,
+			m3 *mock.MockPasswordRepository,
+			m4 *mock.MockRefreshTokenRepository,
 		)
 		arg struct {
 			ctx      context.Context
@@ -97,7 +104,8 @@ func TestUserUseCase_CreateUserAndToken(t *testing.T) {
 	}{
 		{
 			name: "success",
-			setup: func(m *mock.MockUserRepository, m1 *mock.MockTransactionRepository, m2 *mock.MockAuthRepository) {
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockTransactionRepository, m2 *mock.MockAuthRepository, m3 *mock.MockPasswordRepository, m4 *mock.MockRefreshTokenRepository) {
+				m3.EXPECT().Hash("password123").Return("hashed", nil)
 				m1.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
 					return fn(ctx)
 				})
@@ -115,12 +123,22 @@ func TestUserUseCase_CreateUserAndToken(t *testing.T) {
 					if user.Name != "test" {
 						t.Errorf("unexpected Name: got %v, want %v", user.Name, "test")
 					}
-					// TODO: check password hash
+					if user.Password != "hashed" {
+						t.Errorf("unexpected Password: got %v, want %v", user.Password, "hashed")
+					}
 				}).Return(nil)
 				m2.EXPECT().GenerateToken(
 					gomock.Any(),
 					"test@gmail.com",
 				).Return("jwt", "jti")
+				m2.EXPECT().GenerateRefreshToken(
+					gomock.Any(),
+					"",
+				).Return("refresh", entity.RefreshToken{TokenHash: entity.HashRefreshToken("refresh")})
+				m4.EXPECT().Create(
+					gomock.Any(),
+					entity.RefreshToken{TokenHash: entity.HashRefreshToken("refresh")},
+				).Return(nil)
 			},
 			arg: struct {
 				ctx      context.Context
@@ -135,7 +153,8 @@ func TestUserUseCase_CreateUserAndToken(t *testing.T) {
 		},
 		{
 			name: "Fail: Username already exists",
-			setup: func(m *mock.MockUserRepository, m1 *mock.MockTransactionRepository, m2 *mock.MockAuthRepository) {
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockTransactionRepository, m2 *mock.MockAuthRepository, m3 *mock.MockPasswordRepository, m4 *mock.MockRefreshTokenRepository) {
+				m3.EXPECT().Hash("password123").Return("hashed", nil)
 				m1.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
 					return fn(ctx)
 				})
@@ -165,13 +184,16 @@ func TestUserUseCase_CreateUserAndToken(t *testing.T) {
 			ur := mock.NewMockUserRepository(ctrl)
 			tr := mock.NewMockTransactionRepository(ctrl)
 			ar := mock.NewMockAuthRepository(ctrl)
+			pr := mock.NewMockPasswordRepository(ctrl)
+			rr := mock.NewMockRefreshTokenRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
 
 			if tt.setup != nil {
-				tt.setup(ur, tr, ar)
+				tt.setup(ur, tr, ar, pr, rr)
 			}
 
-			usecase := NewUserUseCase(ur, tr, ar)
-			jwt, err := usecase.CreateUserAndToken(tt.arg.ctx, tt.arg.email, tt.arg.password)
+			usecase := NewUserUseCase(ur, tr, ar, pr, rr, vr, fixedClock{now: time.Now()})
+			tokens, err := usecase.CreateUserAndToken(tt.arg.ctx, tt.arg.email, tt.arg.password)
 
 			if (err != nil) != (tt.wantErr != nil) {
 				t.Errorf("CreateUserAndToken() error = %v, wantErr %v", err, tt.wantErr)
@@ -179,24 +201,457 @@ func TestUserUseCase_CreateUserAndToken(t *testing.T) {
 				t.Errorf("CreateUserAndToken() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			if tt.wantErr == nil && jwt == "" {
+			if tt.wantErr == nil && (tokens.AccessToken == "" || tokens.RefreshToken == "") {
 				t.Error("Failed to generate token")
 			}
 		})
 	}
 }
 
-func TestUserUseCase_UpdateUser(t *testing.T) {
+func TestUserUseCase_Login(t *testing.T) {
+	t.Parallel()
+
+	user := entity.User{
+		ID:       "userID",
+		Name:     "test",
+		Email:    "test@gmail.com",
+		Password: "hashed",
+	}
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockUserRepository,
+			m1 *mock.MockAuthRepository,
+			m2 *mock.MockPasswordRepository,
+			m3 *mock.MockRefreshTokenRepository,
+		)
+		arg struct {
+			ctx      context.Context
+			email    string
+			password string
+		}
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockPasswordRepository, m3 *mock.MockRefreshTokenRepository) {
+				u := user
+				m.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(&u, nil)
+				m2.EXPECT().Compare("hashed", "password123").Return(nil)
+				m2.EXPECT().NeedsRehash("hashed").Return(false)
+				m1.EXPECT().GenerateToken("userID", "test@gmail.com").Return("jwt", "jti")
+				m1.EXPECT().GenerateRefreshToken("userID", "").Return("refresh", entity.RefreshToken{FamilyID: "familyID"})
+				m3.EXPECT().Create(gomock.Any(), entity.RefreshToken{FamilyID: "familyID"}).Return(nil)
+			},
+			arg: struct {
+				ctx      context.Context
+				email    string
+				password string
+			}{
+				ctx:      context.Background(),
+				email:    "test@gmail.com",
+				password: "password123",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success: rehash with the current cost",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockPasswordRepository, m3 *mock.MockRefreshTokenRepository) {
+				u := user
+				m.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(&u, nil)
+				m2.EXPECT().Compare("hashed", "password123").Return(nil)
+				m2.EXPECT().NeedsRehash("hashed").Return(true)
+				m2.EXPECT().Hash("password123").Return("rehashed", nil)
+				m.EXPECT().Update(
+					gomock.Any(),
+					gomock.Any(),
+				).Do(func(_ context.Context, user entity.User) {
+					if user.Password != "rehashed" {
+						t.Errorf("unexpected Password: got %v, want %v", user.Password, "rehashed")
+					}
+				}).Return(nil)
+				m1.EXPECT().GenerateToken("userID", "test@gmail.com").Return("jwt", "jti")
+				m1.EXPECT().GenerateRefreshToken("userID", "").Return("refresh", entity.RefreshToken{FamilyID: "familyID"})
+				m3.EXPECT().Create(gomock.Any(), entity.RefreshToken{FamilyID: "familyID"}).Return(nil)
+			},
+			arg: struct {
+				ctx      context.Context
+				email    string
+				password string
+			}{
+				ctx:      context.Background(),
+				email:    "test@gmail.com",
+				password: "password123",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Fail: wrong password",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockPasswordRepository, m3 *mock.MockRefreshTokenRepository) {
+				u := user
+				m.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(&u, nil)
+				m2.EXPECT().Compare("hashed", "wrong").Return(repository.ErrPasswordMismatch)
+			},
+			arg: struct {
+				ctx      context.Context
+				email    string
+				password string
+			}{
+				ctx:      context.Background(),
+				email:    "test@gmail.com",
+				password: "wrong",
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name: "Fail: unknown email still compares a hash",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockPasswordRepository, m3 *mock.MockRefreshTokenRepository) {
+				m.EXPECT().GetByEmail(gomock.Any(), "unknown@gmail.com").Return(nil, repository.ErrUserNotFound)
+				m2.EXPECT().Hash(gomock.Any()).Return("dummy", nil)
+				m2.EXPECT().Compare("dummy", "password123").Return(repository.ErrPasswordMismatch)
+			},
+			arg: struct {
+				ctx      context.Context
+				email    string
+				password string
+			}{
+				ctx:      context.Background(),
+				email:    "unknown@gmail.com",
+				password: "password123",
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name: "Fail: repository error",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockPasswordRepository, m3 *mock.MockRefreshTokenRepository) {
+				m.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(nil, errors.New("connection refused"))
+			},
+			arg: struct {
+				ctx      context.Context
+				email    string
+				password string
+			}{
+				ctx:      context.Background(),
+				email:    "test@gmail.com",
+				password: "password123",
+			},
+			wantErr: errors.New("connection refused"),
+		},
+	}
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			ur := mock.NewMockUserRepository(ctrl)
+			tr := mock.NewMockTransactionRepository(ctrl)
+			ar := mock.NewMockAuthRepository(ctrl)
+			pr := mock.NewMockPasswordRepository(ctrl)
+			rr := mock.NewMockRefreshTokenRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(ur, ar, pr, rr)
+			}
+
+			usecase := NewUserUseCase(ur, tr, ar, pr, rr, vr, fixedClock{now: time.Now()})
+			tokens, err := usecase.Login(tt.arg.ctx, tt.arg.email, tt.arg.password)
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Login() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil && tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && (tokens.AccessToken == "" || tokens.RefreshToken == "") {
+				t.Error("Failed to generate token")
+			}
+		})
+	}
+}
+
+func TestUserUseCase_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tokenHash := entity.HashRefreshToken("refresh")
+
+	patterns := []struct {
+		name  string
+		setup func(
+			m *mock.MockUserRepository,
+			m1 *mock.MockAuthRepository,
+			m2 *mock.MockRefreshTokenRepository,
+		)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockRefreshTokenRepository) {
+				m2.EXPECT().Get(gomock.Any(), tokenHash).Return(&entity.RefreshToken{
+					TokenHash: tokenHash,
+					FamilyID:  "familyID",
+					UserID:    "userID",
+					ExpiresAt: now.Add(time.Hour),
+				}, nil)
+				m2.EXPECT().MarkUsed(gomock.Any(), tokenHash).Return(true, nil)
+				m.EXPECT().Get(gomock.Any(), "userID").Return(&entity.User{ID: "userID", Email: "test@gmail.com"}, nil)
+				m1.EXPECT().GenerateToken("userID", "test@gmail.com").Return("jwt", "jti")
+				m1.EXPECT().GenerateRefreshToken("userID", "familyID").Return("rotated", entity.RefreshToken{FamilyID: "familyID"})
+				m2.EXPECT().Create(gomock.Any(), entity.RefreshToken{FamilyID: "familyID"}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Fail: unknown token",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockRefreshTokenRepository) {
+				m2.EXPECT().Get(gomock.Any(), tokenHash).Return(nil, repository.ErrRefreshTokenNotFound)
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "Fail: expired token",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockRefreshTokenRepository) {
+				m2.EXPECT().Get(gomock.Any(), tokenHash).Return(&entity.RefreshToken{
+					TokenHash: tokenHash,
+					FamilyID:  "familyID",
+					UserID:    "userID",
+					ExpiresAt: now.Add(-time.Hour),
+				}, nil)
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "Fail: revoked token",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockRefreshTokenRepository) {
+				m2.EXPECT().Get(gomock.Any(), tokenHash).Return(&entity.RefreshToken{
+					TokenHash: tokenHash,
+					FamilyID:  "familyID",
+					UserID:    "userID",
+					ExpiresAt: now.Add(time.Hour),
+					Revoked:   true,
+				}, nil)
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "Fail: reused token revokes the family",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockRefreshTokenRepository) {
+				m2.EXPECT().Get(gomock.Any(), tokenHash).Return(&entity.RefreshToken{
+					TokenHash: tokenHash,
+					FamilyID:  "familyID",
+					UserID:    "userID",
+					ExpiresAt: now.Add(time.Hour),
+					Used:      true,
+				}, nil)
+				m2.EXPECT().RevokeFamily(gomock.Any(), "familyID").Return(nil)
+			},
+			wantErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "Fail: token used concurrently revokes the family",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockAuthRepository, m2 *mock.MockRefreshTokenRepository) {
+				m2.EXPECT().Get(gomock.Any(), tokenHash).Return(&entity.RefreshToken{
+					TokenHash: tokenHash,
+					FamilyID:  "familyID",
+					UserID:    "userID",
+					ExpiresAt: now.Add(time.Hour),
+				}, nil)
+				m2.EXPECT().MarkUsed(gomock.Any(), tokenHash).Return(false, nil)
+				m2.EXPECT().RevokeFamily(gomock.Any(), "familyID").Return(nil)
+			},
+			wantErr: ErrRefreshTokenReused,
+		},
+	}
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			ur := mock.NewMockUserRepository(ctrl)
+			tr := mock.NewMockTransactionRepository(ctrl)
+			ar := mock.NewMockAuthRepository(ctrl)
+			pr := mock.NewMockPasswordRepository(ctrl)
+			rr := mock.NewMockRefreshTokenRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(ur, ar, rr)
+			}
+
+			usecase := NewUserUseCase(ur, tr, ar, pr, rr, vr, fixedClock{now: now})
+			tokens, err := usecase.RefreshToken(context.Background(), "refresh")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && (tokens.AccessToken != "jwt" || tokens.RefreshToken != "rotated") {
+				t.Errorf("RefreshToken() tokens = %v", tokens)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_Logout(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New().String()
+	expiresAt := time.Now().Add(15 * time.Minute)
+	claims := &repository.TokenClaims{JTI: "jti", UserID: userID, ExpiresAt: expiresAt}
+	ctx := context.WithValue(context.Background(), config.ContextTokenClaimsKey, claims)
+	tokenHash := entity.HashRefreshToken("refresh")
+
+	patterns := []struct {
+		name  string
+		ctx   context.Context
+		arg   string
+		setup func(
+			m *mock.MockRefreshTokenRepository,
+			m1 *mock.MockRevocationRepository,
+		)
+		wantErr error
+	}{
+		{
+			name: "success: access token only",
+			ctx:  ctx,
+			setup: func(m *mock.MockRefreshTokenRepository, m1 *mock.MockRevocationRepository) {
+				m1.EXPECT().RevokeToken(ctx, "jti", expiresAt).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success: with refresh token",
+			ctx:  ctx,
+			arg:  "refresh",
+			setup: func(m *mock.MockRefreshTokenRepository, m1 *mock.MockRevocationRepository) {
+				m1.EXPECT().RevokeToken(ctx, "jti", expiresAt).Return(nil)
+				m.EXPECT().Get(ctx, tokenHash).Return(&entity.RefreshToken{TokenHash: tokenHash, FamilyID: "familyID", UserID: userID}, nil)
+				m.EXPECT().RevokeFamily(ctx, "familyID").Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success: refresh token of another user is left alone",
+			ctx:  ctx,
+			arg:  "refresh",
+			setup: func(m *mock.MockRefreshTokenRepository, m1 *mock.MockRevocationRepository) {
+				m1.EXPECT().RevokeToken(ctx, "jti", expiresAt).Return(nil)
+				m.EXPECT().Get(ctx, tokenHash).Return(&entity.RefreshToken{TokenHash: tokenHash, FamilyID: "familyID", UserID: "other"}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Fail: Token claims not found in request context",
+			ctx:     context.Background(),
+			wantErr: errors.New("token claims not found in request context"),
+		},
+	}
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			ur := mock.NewMockUserRepository(ctrl)
+			tr := mock.NewMockTransactionRepository(ctrl)
+			ar := mock.NewMockAuthRepository(ctrl)
+			pr := mock.NewMockPasswordRepository(ctrl)
+			rr := mock.NewMockRefreshTokenRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(rr, vr)
+			}
+
+			usecase := NewUserUseCase(ur, tr, ar, pr, rr, vr, fixedClock{now: time.Now()})
+			err := usecase.Logout(tt.ctx, tt.arg)
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Logout() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil && tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_LogoutEverywhere(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New().String()
+	now := time.Now()
 	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
 
-	user := entity.User{
-		ID:    userID,
-		Name:  "test",
-		Email: "test@gmail.com",
+	patterns := []struct {
+		name  string
+		ctx   context.Context
+		setup func(
+			m *mock.MockRefreshTokenRepository,
+			m1 *mock.MockRevocationRepository,
+		)
+		wantErr error
+	}{
+		{
+			name: "success",
+			ctx:  ctx,
+			setup: func(m *mock.MockRefreshTokenRepository, m1 *mock.MockRevocationRepository) {
+				m1.EXPECT().RevokeUserTokens(ctx, userID, now).Return(nil)
+				m.EXPECT().RevokeUser(ctx, userID).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Fail: revocation store error",
+			ctx:  ctx,
+			setup: func(m *mock.MockRefreshTokenRepository, m1 *mock.MockRevocationRepository) {
+				m1.EXPECT().RevokeUserTokens(ctx, userID, now).Return(errors.New("connection refused"))
+			},
+			wantErr: errors.New("connection refused"),
+		},
+		{
+			name:    "Fail: User ID not found in request context",
+			ctx:     context.Background(),
+			wantErr: errors.New("user name not found in request context"),
+		},
 	}
+	for _, tt := range patterns {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			ur := mock.NewMockUserRepository(ctrl)
+			tr := mock.NewMockTransactionRepository(ctrl)
+			ar := mock.NewMockAuthRepository(ctrl)
+			pr := mock.NewMockPasswordRepository(ctrl)
+			rr := mock.NewMockRefreshTokenRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(rr, vr)
+			}
+
+			usecase := NewUserUseCase(ur, tr, ar, pr, rr, vr, fixedClock{now: now})
+			err := usecase.LogoutEverywhere(tt.ctx)
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("LogoutEverywhere() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil && tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("LogoutEverywhere() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_UpdateUser(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), config.ContextUserIDKey, userID)
 
 	patterns := []struct {
 		name  string
@@ -205,8 +660,8 @@ func TestUserUseCase_UpdateUser(t *testing.T) {
 			m1 *mock.MockTransactionRepository,
 		)
 		arg struct {
-			ctx  context.Context
-			name string
+			ctx    context.Context
+			params *UpdateUserParams
 		}
 		wantErr error
 	}{
@@ -216,30 +671,102 @@ func TestUserUseCase_UpdateUser(t *testing.T) {
 				m.EXPECT().Get(
 					ctx,
 					userID,
-				).Return(&user, nil)
-				user.Name = "updatedName"
+				).Return(&entity.User{
+					ID:           userID,
+					Name:         "test",
+					Email:        "test@gmail.com",
+					DueSoonHours: entity.DefaultDueSoonHours,
+				}, nil)
+				m.EXPECT().Update(
+					gomock.Any(),
+					entity.User{
+						ID:           userID,
+						Name:         "updatedName",
+						Email:        "test@gmail.com",
+						DueSoonHours: entity.DefaultDueSoonHours,
+					},
+				).Return(nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateUserParams
+			}{
+				ctx: ctx,
+				params: &UpdateUserParams{
+					Name: "updatedName",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success: update due soon hours",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockTransactionRepository) {
+				m.EXPECT().Get(
+					ctx,
+					userID,
+				).Return(&entity.User{
+					ID:           userID,
+					Name:         "test",
+					Email:        "test@gmail.com",
+					DueSoonHours: entity.DefaultDueSoonHours,
+				}, nil)
 				m.EXPECT().Update(
 					gomock.Any(),
-					user,
+					entity.User{
+						ID:           userID,
+						Name:         "updatedName",
+						Email:        "test@gmail.com",
+						DueSoonHours: 72,
+					},
 				).Return(nil)
 			},
 			arg: struct {
-				ctx  context.Context
-				name string
+				ctx    context.Context
+				params *UpdateUserParams
 			}{
-				ctx:  ctx,
-				name: "updatedName",
+				ctx: ctx,
+				params: &UpdateUserParams{
+					Name:         "updatedName",
+					DueSoonHours: 72,
+				},
 			},
 			wantErr: nil,
 		},
+		{
+			name: "Fail: due soon hours out of range",
+			setup: func(m *mock.MockUserRepository, m1 *mock.MockTransactionRepository) {
+				m.EXPECT().Get(
+					ctx,
+					userID,
+				).Return(&entity.User{
+					ID:           userID,
+					Name:         "test",
+					Email:        "test@gmail.com",
+					DueSoonHours: entity.DefaultDueSoonHours,
+				}, nil)
+			},
+			arg: struct {
+				ctx    context.Context
+				params *UpdateUserParams
+			}{
+				ctx: ctx,
+				params: &UpdateUserParams{
+					Name:         "updatedName",
+					DueSoonHours: -1,
+				},
+			},
+			wantErr: errors.New("due soon hours must be between 1 and 720"),
+		},
 		{
 			name: "Fail: User ID not found in request context",
 			arg: struct {
-				ctx  context.Context
-				name string
+				ctx    context.Context
+				params *UpdateUserParams
 			}{
-				ctx:  context.Background(),
-				name: "updatedName",
+				ctx: context.Background(),
+				params: &UpdateUserParams{
+					Name: "updatedName",
+				},
 			},
 			wantErr: errors.New("user name not found in request context"),
 		},
@@ -253,13 +780,16 @@ func TestUserUseCase_UpdateUser(t *testing.T) {
 			ur := mock.NewMockUserRepository(ctrl)
 			tr := mock.NewMockTransactionRepository(ctrl)
 			ar := mock.NewMockAuthRepository(ctrl)
+			pr := mock.NewMockPasswordRepository(ctrl)
+			rr := mock.NewMockRefreshTokenRepository(ctrl)
+			vr := mock.NewMockRevocationRepository(ctrl)
 
 			if tt.setup != nil {
 				tt.setup(ur, tr)
 			}
 
-			usecase := NewUserUseCase(ur, tr, ar)
-			err := usecase.UpdateUser(tt.arg.ctx, tt.arg.name)
+			usecase := NewUserUseCase(ur, tr, ar, pr, rr, vr, fixedClock{now: time.Now()})
+			err := usecase.UpdateUser(tt.arg.ctx, tt.arg.params)
 
 			if (err != nil) != (tt.wantErr != nil) {
 				t.Errorf("UpdateUser() error = %v, wantErr %v", err, tt.wantErr)